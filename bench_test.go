@@ -0,0 +1,43 @@
+package swag
+
+import (
+	"io"
+	"log"
+	"testing"
+)
+
+// The benchmarks parse the largest testdata trees, compare runs with benchstat before and after a change:
+//
+//	go test -run '^$' -bench ParseAPI -benchmem -count 10 . > old.txt
+func benchmarkParseAPI(b *testing.B, searchDir string, options ...func(*Parser)) {
+	options = append([]func(*Parser){SetDebugger(log.New(io.Discard, "", 0))}, options...)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		p := New(options...)
+		if err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseAPI_Simple(b *testing.B) {
+	benchmarkParseAPI(b, "testdata/simple")
+}
+
+func BenchmarkParseAPI_State(b *testing.B) {
+	benchmarkParseAPI(b, "testdata/state")
+}
+
+func BenchmarkParseAPI_Generics(b *testing.B) {
+	benchmarkParseAPI(b, "testdata/generics_basic")
+}
+
+func BenchmarkParseAPI_GenericsPackageAlias(b *testing.B) {
+	benchmarkParseAPI(b, "testdata/generics_package_alias/internal", SetParseDependency(1))
+}
+
+func BenchmarkParseAPI_SharedPackageCache(b *testing.B) {
+	benchmarkParseAPI(b, "testdata/simple", SetPackageCache(NewPackageCache()))
+}
@@ -0,0 +1,86 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// CallbacksExtension keeps the callbacks of an operation, which Swagger 2.0 can not express, the callbacks of the
+// operation in OpenAPI 3.0. The callbacks are keyed by name, then by the runtime expression of their URL.
+const CallbacksExtension = "x-callbacks"
+
+// Callback the requests of a callback by the runtime expression of their URL, e.g. {$request.body#/callbackUrl}.
+type Callback map[string]spec.PathItem
+
+// callbackBodyPattern matches the request body of @Callback: {object} web.PaymentEvent "description".
+var callbackBodyPattern = regexp.MustCompile(`^\{(\w+)\}\s+([^\s"]+)\s*(".*")?$`)
+
+// ParseCallbackComment parses a callback request of the operation:
+// @Callback onPaid {$request.body#/callbackUrl} post {object} web.PaymentEvent "the payment is received".
+func (operation *Operation) ParseCallbackComment(commentLine string, astFile *ast.File) error {
+	fields := FieldsByAnySpace(commentLine, 4)
+	if len(fields) != 4 {
+		return fmt.Errorf("can not parse callback comment \"%s\", expected name, expression, method and body", commentLine)
+	}
+
+	name, expression, method := fields[0], fields[1], strings.ToUpper(fields[2])
+
+	if _, ok := allMethod[method]; !ok {
+		return fmt.Errorf("invalid method %s of callback %s", fields[2], name)
+	}
+
+	matches := callbackBodyPattern.FindStringSubmatch(fields[3])
+	if matches == nil {
+		return fmt.Errorf("can not parse callback comment \"%s\", expected a body like {object} web.Event", commentLine)
+	}
+
+	schema, err := operation.parseAPIObjectSchema(commentLine, matches[1], matches[2], astFile)
+	if err != nil {
+		return fmt.Errorf("callback %s: %w", name, err)
+	}
+
+	callbacks, _ := operation.Extensions[CallbacksExtension].(map[string]Callback)
+	if callbacks == nil {
+		callbacks = make(map[string]Callback)
+	}
+
+	callback := callbacks[name]
+	if callback == nil {
+		callback = make(Callback)
+	}
+
+	item := callback[expression]
+
+	op := refRouteMethodOp(&item, method)
+	if *op != nil {
+		return fmt.Errorf("callback %s %s %s is declared several times", name, method, expression)
+	}
+
+	*op = &spec.Operation{
+		OperationProps: spec.OperationProps{
+			Description: unquoteAttribute(matches[3]),
+			Parameters: []spec.Parameter{{
+				ParamProps: spec.ParamProps{Name: "body", In: "body", Required: true, Schema: schema},
+			}},
+			Responses: &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusOK: *spec.NewResponse().WithDescription(http.StatusText(http.StatusOK)),
+					},
+				},
+			},
+		},
+	}
+
+	callback[expression] = item
+	callbacks[name] = callback
+
+	setExtension(operation.Extensions, CallbacksExtension, callbacks)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCallbackComment(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type PaymentEvent struct {
+	ID string ` + "`json:\"id\"`" + `
+}
+
+// @Success  201
+// @Callback onPaid     {$request.body#/callbackUrl} post {object} PaymentEvent "the payment is received"
+// @Callback onPaid     {$request.body#/callbackUrl} put  {object} PaymentEvent
+// @Callback onRefunded {$request.body#/refundUrl}   post {string} string
+// @Router   /payments [post]
+func CreatePayment(){
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	callbacks, ok := p.swagger.Paths.Paths["/payments"].Post.Extensions[CallbacksExtension].(map[string]Callback)
+	require.True(t, ok)
+	assert.Equal(t, []string{"onPaid", "onRefunded"}, sortedKeys(callbacks))
+
+	paid := callbacks["onPaid"]["{$request.body#/callbackUrl}"]
+	require.NotNil(t, paid.Post)
+	assert.Equal(t, "the payment is received", paid.Post.Description)
+	assert.Equal(t, "#/definitions/api.PaymentEvent", paid.Post.Parameters[0].Schema.Ref.String())
+	assert.Equal(t, "OK", paid.Post.Responses.StatusCodeResponses[200].Description)
+	require.NotNil(t, paid.Put)
+	assert.Empty(t, paid.Put.Description)
+
+	refunded := callbacks["onRefunded"]["{$request.body#/refundUrl}"]
+	require.NotNil(t, refunded.Post)
+	assert.Equal(t, "string", refunded.Post.Parameters[0].Schema.Type[0])
+}
+
+func TestParseCallbackComment_errors(t *testing.T) {
+	t.Parallel()
+
+	for comment, expected := range map[string]string{
+		"onPaid {$request.body#/url} post":                      "can not parse callback comment \"onPaid {$request.body#/url} post\", expected name, expression, method and body",
+		"onPaid {$request.body#/url} send {string} string":      "invalid method send of callback onPaid",
+		"onPaid {$request.body#/url} post string":               "can not parse callback comment \"onPaid {$request.body#/url} post string\", expected a body like {object} web.Event",
+		"onPaid {$request.body#/url} post {object} api.Missing": "callback onPaid: cannot find type definition: api.Missing",
+	} {
+		operation := NewOperation(nil)
+		assert.EqualError(t, operation.ParseCallbackComment(comment, nil), expected, comment)
+	}
+
+	operation := NewOperation(nil)
+	require.NoError(t, operation.ParseCallbackComment("onPaid {$request.body#/url} post {string} string", nil))
+	assert.EqualError(t, operation.ParseCallbackComment("onPaid {$request.body#/url} post {string} string", nil),
+		"callback onPaid POST {$request.body#/url} is declared several times")
+}
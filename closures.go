@@ -0,0 +1,171 @@
+package swag
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// closureRoute the route of a closure handler registered right below an annotation block, or whose function
+// literal is right below it, parsed with ParseFuncBody:
+//
+//	// @Summary Get a user
+//	r.GET("/users/:id", func(c *gin.Context) {...})
+//
+//	r.GET("/users",
+//		// @Summary List the users
+//		func(c *gin.Context) {...})
+type closureRoute struct {
+	method string
+	path   string
+}
+
+// closureRoutes returns the routes of the registration calls of closure handlers of fileInfo, e.g.
+// r.GET("/users/:id", func(c *gin.Context) {...}), by the comment group on the line right above them or above their
+// function literal.
+func closureRoutes(fileInfo *AstFileInfo) map[*ast.CommentGroup]closureRoute {
+	if fileInfo.FileSet == nil {
+		return nil
+	}
+
+	byLine := make(map[int]closureRoute)
+
+	ast.Inspect(fileInfo.File, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		if route, ok := registrationRoute(call); ok {
+			byLine[fileInfo.FileSet.Position(call.Pos()).Line] = route
+			byLine[fileInfo.FileSet.Position(call.Args[len(call.Args)-1].Pos()).Line] = route
+		}
+
+		return true
+	})
+
+	routes := make(map[*ast.CommentGroup]closureRoute)
+
+	for _, group := range fileInfo.File.Comments {
+		if route, ok := byLine[fileInfo.FileSet.Position(group.End()).Line+1]; ok {
+			routes[group] = route
+		}
+	}
+
+	return routes
+}
+
+// registrationRoute returns the route of a call registering a closure handler for an HTTP method, like
+// r.GET("/users/:id", func(c *gin.Context) {...}) of gin or echo, or r.Get of chi or fiber.
+func registrationRoute(call *ast.CallExpr) (closureRoute, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) < 2 {
+		return closureRoute{}, false
+	}
+
+	method := strings.ToUpper(selector.Sel.Name)
+	if _, ok := allMethod[method]; !ok || (selector.Sel.Name != method &&
+		selector.Sel.Name != method[:1]+strings.ToLower(method[1:])) {
+		return closureRoute{}, false
+	}
+
+	if _, ok := call.Args[len(call.Args)-1].(*ast.FuncLit); !ok {
+		return closureRoute{}, false
+	}
+
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return closureRoute{}, false
+	}
+
+	path, err := strconv.Unquote(lit.Value)
+	if err != nil || !strings.HasPrefix(path, "/") {
+		return closureRoute{}, false
+	}
+
+	return closureRoute{method: strings.ToLower(method), path: routePath(path)}, true
+}
+
+// routePath returns the path of a route whose parameters are written :id, :id? or *path, e.g. /users/{id}.
+func routePath(path string) string {
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = "{" + strings.TrimSuffix(segment[1:], "?") + "}"
+		case strings.HasPrefix(segment, "*") && len(segment) > 1:
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// operationID returns the synthetic ID of the operation of the route, its method followed by the words of its path,
+// those of the parameters after By, e.g. getUsersById for GET /users/{id} and getUsersId for GET /users/id.
+func (route closureRoute) operationID() string {
+	var id strings.Builder
+
+	id.WriteString(route.method)
+
+	for _, segment := range strings.Split(route.path, "/") {
+		if parameter, ok := strings.CutPrefix(segment, "{"); ok {
+			id.WriteString("By")
+			segment = strings.TrimSuffix(parameter, "}")
+		}
+
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return id.String()
+}
+
+// closureComments returns the comments of the annotation block of the closure handler of route, with the @Router
+// of the registration call and the synthetic @ID of the route when the block has none. The comments of a block
+// without annotations are returned as they are.
+func closureComments(comments []*ast.Comment, route closureRoute) []*ast.Comment {
+	var hasAnnotation, hasRouter, hasCRUD, hasID bool
+
+	for _, comment := range comments {
+		fields := strings.Fields(strings.TrimLeft(comment.Text, "/"))
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "@") {
+			continue
+		}
+
+		hasAnnotation = true
+
+		switch strings.ToLower(fields[0]) {
+		case routerAttr:
+			hasRouter = true
+		case crudAttr:
+			hasCRUD = true
+		case idAttr:
+			hasID = true
+		}
+	}
+
+	comments = append([]*ast.Comment(nil), comments...)
+	last := comments[len(comments)-1]
+
+	// the template expands into its own routes and IDs, and a plain comment is no operation
+	if hasCRUD || !hasAnnotation {
+		return comments
+	}
+
+	if !hasRouter {
+		comments = append(comments, &ast.Comment{Slash: last.Slash, Text: "// @Router " + route.path + " [" + route.method + "]"})
+	}
+
+	if !hasID {
+		comments = append(comments, &ast.Comment{Slash: last.Slash, Text: "// @ID " + route.operationID()})
+	}
+
+	return comments
+}
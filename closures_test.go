@@ -0,0 +1,93 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ClosureHandlers(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+func routes(r *gin.Engine) {
+	// @Summary Get a user
+	// @Success 200 {string} string
+	r.GET("/users/:id", func(c *gin.Context) {})
+
+	// @Summary Delete the files of a user
+	// @ID      deleteFiles
+	r.Delete("/users/:id/files/*path", func(w http.ResponseWriter, r *http.Request) {})
+
+	// @Summary Create a user
+	// @Router  /v1/users [post]
+	r.POST("/users", func(c *gin.Context) {})
+
+	// registers the health check
+	r.GET("/health", func(c *gin.Context) {})
+
+	// @Summary List the users
+
+	r.GET("/users", func(c *gin.Context) {})
+
+	r.PUT("/users/:id",
+		// @Summary Update a user
+		func(c *gin.Context) {})
+
+	// @Summary Get a user by its login
+	r.GET("/users/id", func(c *gin.Context) {})
+}
+`
+	p := New()
+	p.ParseFuncBody = true
+
+	require.NoError(t, p.packages.ParseFile("api", "testdata/closures/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	paths := p.swagger.Paths.Paths
+
+	get := paths["/users/{id}"].Get
+	require.NotNil(t, get)
+	assert.Equal(t, "getUsersById", get.ID)
+	assert.Equal(t, "Get a user", get.Summary)
+
+	// the block above the function literal of a multi-line registration
+	put := paths["/users/{id}"].Put
+	require.NotNil(t, put)
+	assert.Equal(t, "putUsersById", put.ID)
+	assert.Equal(t, "Update a user", put.Summary)
+
+	// a path parameter and a segment of the same name have different IDs
+	getID := paths["/users/id"].Get
+	require.NotNil(t, getID)
+	assert.Equal(t, "getUsersId", getID.ID)
+
+	deleteFiles := paths["/users/{id}/files/{path}"].Delete
+	require.NotNil(t, deleteFiles)
+	assert.Equal(t, "deleteFiles", deleteFiles.ID)
+
+	// the @Router of the block wins, the ID comes from the registration
+	post := paths["/v1/users"].Post
+	require.NotNil(t, post)
+	assert.Equal(t, "postUsers", post.ID)
+	assert.NotContains(t, paths, "/users")
+
+	// plain comments and blocks separated by a blank line are not operations
+	assert.NotContains(t, paths, "/health")
+}
+
+func TestRoutePath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/users/{id}", routePath("/users/:id"))
+	assert.Equal(t, "/users/{id}", routePath("/users/:id?"))
+	assert.Equal(t, "/static/{filepath}", routePath("/static/*filepath"))
+	assert.Equal(t, "/users/{id}", routePath("/users/{id}"))
+}
@@ -1,15 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
+	"maps"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/diff"
 	"github.com/swaggo/swag/format"
 	"github.com/swaggo/swag/gen"
 )
@@ -20,6 +25,7 @@ const (
 	generalInfoFlag          = "generalInfo"
 	pipeFlag                 = "pipe"
 	propertyStrategyFlag     = "propertyStrategy"
+	propertyTagsFlag         = "propertyTags"
 	outputFlag               = "output"
 	outputTypesFlag          = "outputTypes"
 	parseVendorFlag          = "parseVendor"
@@ -27,16 +33,37 @@ const (
 	useStructNameFlag        = "useStructName"
 	parseDependencyLevelFlag = "parseDependencyLevel"
 	markdownFilesFlag        = "markdownFiles"
+	markdownBaseURLFlag      = "markdownBaseURL"
 	codeExampleFilesFlag     = "codeExampleFiles"
+	codeSamplesFlag          = "codeSamples"
+	generateCurlSamplesFlag  = "generateCurlSamples"
+	extensionFilesFlag       = "extensionFiles"
 	parseInternalFlag        = "parseInternal"
 	generatedTimeFlag        = "generatedTime"
+	compressDocFlag          = "compressDoc"
+	compactFlag              = "compact"
+	sortFlag                 = "sort"
+	splitByTagFlag           = "splitByTag"
+	staticDocFlag            = "staticDoc"
 	requiredByDefaultFlag    = "requiredByDefault"
+	requiredInResponsesFlag  = "requiredInResponses"
+	securityAlternativesFlag = "securityAlternatives"
+	timeFormatFlag           = "timeFormat"
+	rawMessageTypeFlag       = "rawMessageType"
 	parseDepthFlag           = "parseDepth"
 	instanceNameFlag         = "instanceName"
+	instancesFileFlag        = "instancesFile"
+	environmentsFileFlag     = "environmentsFile"
 	overridesFileFlag        = "overridesFile"
+	operationsFileFlag       = "operationsFile"
+	operationTemplatesFlag   = "operationTemplatesFile"
+	extensionsFileFlag       = "extensionsFile"
+	tagsFileFlag             = "tagsFile"
 	parseGoListFlag          = "parseGoList"
 	quietFlag                = "quiet"
 	tagsFlag                 = "tags"
+	onlyFlag                 = "only"
+	onlyRoutesFlag           = "onlyRoutes"
 	parseExtensionFlag       = "parseExtension"
 	templateDelimsFlag       = "templateDelims"
 	packageName              = "packageName"
@@ -44,7 +71,45 @@ const (
 	packagePrefixFlag        = "packagePrefix"
 	stateFlag                = "state"
 	parseFuncBodyFlag        = "parseFuncBody"
+	includeGeneratedFlag     = "includeGenerated"
 	parseGoPackagesFlag      = "parseGoPackages"
+	inferInfoFromModuleFlag  = "inferInfoFromModule"
+	setFlag                  = "set"
+	timeoutFlag              = "timeout"
+	configFlag               = "config"
+	openAPIVersionFlag       = "openapiVersion"
+	maxSchemaDepthFlag       = "maxSchemaDepth"
+	maxDefinitionsFlag       = "maxDefinitions"
+	maxGenericsFlag          = "maxGenericInstantiations"
+	authResponsesFlag        = "authResponses"
+	inheritMimeTypesFlag     = "inheritMimeTypes"
+	nullablePointersFlag     = "nullablePointers"
+	pruneUnusedFlag          = "pruneUnused"
+	pruneUnusedDefsFlag      = "pruneUnusedDefinitions"
+	defaultSuccessFlag       = "defaultSuccess"
+	refStrategyFlag          = "refStrategy"
+	vendorRemoteRefsFlag     = "vendorRemoteRefs"
+	remoteRefsCacheFlag      = "remoteRefsCache"
+	enumsAsRefsFlag          = "enumsAsRefs"
+	stringerEnumsFlag        = "stringerEnums"
+	omitEmptyExtensionFlag   = "omitEmptyExtension"
+	modelFiltersFlag         = "modelFilters"
+	jobsFlag                 = "jobs"
+	htmlRendererFlag         = "htmlRenderer"
+	configMapNameFlag        = "configMapName"
+	configMapNamespaceFlag   = "configMapNamespace"
+	postHookFlag             = "postHook"
+	runsFlag                 = "runs"
+	maxDurationFlag          = "maxDuration"
+	maxAllocsFlag            = "maxAllocs"
+	fromFlag                 = "from"
+	toFlag                   = "to"
+	strategyFlag             = "strategy"
+	prefixesFlag             = "prefixes"
+	typeFlag                 = "type"
+	registryFlag             = "registry"
+	apiFlag                  = "api"
+	registryURLFlag          = "registryURL"
 )
 
 var initFlags = []cli.Flag{
@@ -75,17 +140,39 @@ var initFlags = []cli.Flag{
 		Value:   swag.CamelCase,
 		Usage:   "Property Naming Strategy like " + swag.SnakeCase + "," + swag.CamelCase + "," + swag.PascalCase,
 	},
+	&cli.StringFlag{
+		Name:  propertyTagsFlag,
+		Usage: "Struct tags naming properties instead of json, comma separated, e.g. bson, or github.com/acme/store/models=bson for the packages with that import path prefix",
+	},
 	&cli.StringFlag{
 		Name:    outputFlag,
 		Aliases: []string{"o"},
 		Value:   "./docs",
-		Usage:   "Output directory for all the generated files(swagger.json, swagger.yaml and docs.go)",
+		Usage:   "Output directory for all the generated files(swagger.json, swagger.yaml and docs.go), - for the standard output of a single output type",
 	},
 	&cli.StringFlag{
 		Name:    outputTypesFlag,
 		Aliases: []string{"ot"},
 		Value:   "go,json,yaml",
-		Usage:   "Output types of generated files (docs.go, swagger.json, swagger.yaml) like go,json,yaml",
+		Usage:   "Output types of generated files (docs.go, swagger.json, swagger.yaml, index.html, schemas/*.json, k6.js, requests.http, terraform.json, configmap.yaml) like go,json,yaml,html,schemas,k6,http,terraform,configmap",
+	},
+	&cli.StringFlag{
+		Name:  htmlRendererFlag,
+		Value: gen.RedocRenderer,
+		Usage: "Renderer of the static index.html of the html output type: " + gen.RedocRenderer + " or " + gen.SwaggerUIRenderer,
+	},
+	&cli.StringFlag{
+		Name:  configMapNameFlag,
+		Value: gen.DefaultConfigMapName,
+		Usage: "Name of the ConfigMap of the configmap output type",
+	},
+	&cli.StringFlag{
+		Name:  configMapNamespaceFlag,
+		Usage: "Namespace of the ConfigMap of the configmap output type, none by default",
+	},
+	&cli.StringSliceFlag{
+		Name:  postHookFlag,
+		Usage: "Run a command for each generated file, {file} replaced by its path, e.g. --postHook \"aws s3 cp {file} s3://docs/\"",
 	},
 	&cli.BoolFlag{
 		Name:  parseVendorFlag,
@@ -112,12 +199,30 @@ var initFlags = []cli.Flag{
 		Value:   "",
 		Usage:   "Parse folder containing markdown files to use as description, disabled by default",
 	},
+	&cli.StringFlag{
+		Name:  markdownBaseURLFlag,
+		Value: "",
+		Usage: "Base URL that relative links and images in markdown files are rewritten against",
+	},
 	&cli.StringFlag{
 		Name:    codeExampleFilesFlag,
 		Aliases: []string{"cef"},
 		Value:   "",
 		Usage:   "Parse folder containing code example files to use for the x-codeSamples extension, disabled by default",
 	},
+	&cli.StringFlag{
+		Name:  codeSamplesFlag,
+		Usage: "Comma-separated tools of the x-codeSamples synthesized for the operations without code samples: curl and httpie",
+	},
+	&cli.BoolFlag{
+		Name:  generateCurlSamplesFlag,
+		Usage: "Synthesize curl x-codeSamples for the operations without code samples, like --codeSamples curl",
+	},
+	&cli.StringFlag{
+		Name:  extensionFilesFlag,
+		Value: "",
+		Usage: "Folder containing files loaded by @x-name file(name.json) extension values",
+	},
 	&cli.BoolFlag{
 		Name:  parseInternalFlag,
 		Usage: "Parse go files in internal packages, disabled by default",
@@ -126,6 +231,26 @@ var initFlags = []cli.Flag{
 		Name:  generatedTimeFlag,
 		Usage: "Generate timestamp at the top of docs.go, disabled by default",
 	},
+	&cli.BoolFlag{
+		Name:  compressDocFlag,
+		Usage: "Embed the document of docs.go compressed by gzip, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  compactFlag,
+		Usage: "Write swagger.json, openapi.json and the document of docs.go without indentation, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  sortFlag,
+		Usage: "Sort the tags, parameters, required properties, mime types and security requirements by name, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  staticDocFlag,
+		Usage: "Embed the final document in docs.go instead of a template executed at runtime, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  splitByTagFlag,
+		Usage: "Write the document of each tag too, e.g. users.swagger.json, in the json and yaml output types",
+	},
 	&cli.IntFlag{
 		Name:  parseDepthFlag,
 		Value: 100,
@@ -135,16 +260,57 @@ var initFlags = []cli.Flag{
 		Name:  requiredByDefaultFlag,
 		Usage: "Set validation required for all fields by default",
 	},
+	&cli.BoolFlag{
+		Name:  requiredInResponsesFlag,
+		Usage: "Make the fields without omitempty or omitzero required in the definitions only responses refer to",
+	},
+	&cli.BoolFlag{
+		Name:  securityAlternativesFlag,
+		Usage: "Separate alternative requirements of @Security with ||, which combines schemes like && otherwise",
+	},
+	&cli.StringFlag{
+		Name:  timeFormatFlag,
+		Usage: "How time.Time is documented: date-time, date or another string format, or unix and unixmilli for integer timestamps, a string without format by default",
+	},
+	&cli.StringFlag{
+		Name:  rawMessageTypeFlag,
+		Value: swag.RawMessageObject,
+		Usage: "How json.RawMessage is documented: " + swag.RawMessageObject + ", a free-form object, or " + swag.RawMessageString,
+	},
 	&cli.StringFlag{
 		Name:  instanceNameFlag,
 		Value: "",
 		Usage: "This parameter can be used to name different swagger document instances. It is optional.",
 	},
+	&cli.StringFlag{
+		Name:  instancesFileFlag,
+		Usage: "A JSON or YAML file of instances registered by docs.go too, each selecting operations like --tags",
+	},
+	&cli.StringFlag{
+		Name:  environmentsFileFlag,
+		Usage: "A JSON or YAML file of environments whose documents are written too, e.g. swagger.prod.json",
+	},
 	&cli.StringFlag{
 		Name:  overridesFileFlag,
 		Value: gen.DefaultOverridesFile,
 		Usage: "File to read global type overrides from.",
 	},
+	&cli.StringFlag{
+		Name:  operationsFileFlag,
+		Usage: "JSON or YAML file of operations by ID, routed by //swag:route comments",
+	},
+	&cli.StringFlag{
+		Name:  operationTemplatesFlag,
+		Usage: "JSON or YAML file of operation templates by name, expanded by @crud annotations",
+	},
+	&cli.StringFlag{
+		Name:  extensionsFileFlag,
+		Usage: "JSON or YAML file of extensions of the document, and of its info under the info key",
+	},
+	&cli.StringFlag{
+		Name:  tagsFileFlag,
+		Usage: "JSON or YAML list of the tags of the document, in the order portals show them",
+	},
 	&cli.BoolFlag{
 		Name:  parseGoListFlag,
 		Value: true,
@@ -161,6 +327,16 @@ var initFlags = []cli.Flag{
 		Value:   "",
 		Usage:   "A comma-separated list of tags to filter the APIs for which the documentation is generated.Special case if the tag is prefixed with the '!' character then the APIs with that tag will be excluded",
 	},
+	&cli.StringFlag{
+		Name:  onlyFlag,
+		Value: "",
+		Usage: "Regenerate only the operations of these comma-separated directories, e.g. ./internal/handlers/billing/..., and merge them into the existing docs",
+	},
+	&cli.StringFlag{
+		Name:  onlyRoutesFlag,
+		Value: "",
+		Usage: "Regenerate only the operations of the routes matching these comma-separated patterns, e.g. /billing/*, and merge them into the existing docs",
+	},
 	&cli.StringFlag{
 		Name:    templateDelimsFlag,
 		Aliases: []string{"td"},
@@ -192,19 +368,401 @@ var initFlags = []cli.Flag{
 		Name:  parseFuncBodyFlag,
 		Usage: "Parse API info within body of functions in go files, disabled by default",
 	},
+	&cli.BoolFlag{
+		Name:  includeGeneratedFlag,
+		Usage: "Parse API info in generated go files, with a \"Code generated ... DO NOT EDIT.\" header, disabled by default",
+	},
 	&cli.BoolFlag{
 		Name:  parseGoPackagesFlag,
 		Usage: "Parse Go sources by golang.org/x/tools/go/packages, disabled by default",
 	},
+	&cli.BoolFlag{
+		Name:  inferInfoFromModuleFlag,
+		Usage: "Fill missing license, contact and version from LICENSE, CODEOWNERS, go.mod and git tags, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  authResponsesFlag,
+		Usage: "Document 401 and 403 responses with the WWW-Authenticate header for every secured operation, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  inheritMimeTypesFlag,
+		Usage: "Copy the @Accept and @Produce of general API info into the operations which declare none, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  nullablePointersFlag,
+		Usage: "Mark the pointer fields of structs with x-nullable, nullable in OpenAPI 3.0, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  defaultSuccessFlag,
+		Value: swag.DefaultSuccessNone,
+		Usage: "Operations without @Success: " + swag.DefaultSuccessNone + " leaves them without success response, " +
+			swag.DefaultSuccessEmpty + " documents an empty 200 response, " + swag.DefaultSuccessError + " makes them an error",
+	},
+	&cli.BoolFlag{
+		Name:  pruneUnusedFlag,
+		Usage: "Remove the security definitions which are never required and the tags without operations, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  pruneUnusedDefsFlag,
+		Usage: "Remove the definitions which no path refers to, e.g. models of dependencies never used, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  enumsAsRefsFlag,
+		Usage: "Turn the inline enums, e.g. of the enums struct tag, into definitions which the fields refer to, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  stringerEnumsFlag,
+		Usage: "Document the string forms of the integer enums whose type has a String method: " + swag.StringerEnumsVarNames + " in x-enum-varnames, " + swag.StringerEnumsString + " as string enums",
+	},
+	&cli.StringFlag{
+		Name:  refStrategyFlag,
+		Usage: "Rewrite how the schemas refer to each other: " + swag.RefStrategyFlatten + " inlines the definitions, except the recursive ones, " + swag.RefStrategyBundle + " extracts the inline objects into definitions",
+	},
+	&cli.BoolFlag{
+		Name:  vendorRemoteRefsFlag,
+		Usage: "Copy the remote schemas referred to by the annotations, e.g. {object} https://schemas.example.com/user.json, into definitions, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  remoteRefsCacheFlag,
+		Usage: "The folder of the downloaded remote schemas, swag/remote-refs of the user cache folder by default",
+	},
+	&cli.BoolFlag{
+		Name:  omitEmptyExtensionFlag,
+		Usage: "Add x-omitempty to the properties of structs, true when the json tag has omitempty, disabled by default",
+	},
+	&cli.StringFlag{
+		Name:  modelFiltersFlag,
+		Usage: "Comma-separated filters of ORM bookkeeping fields: gorm (soft delete as nullable date-time), gorm-skip-deleted (no soft delete) and ent (no edges)",
+	},
+	&cli.StringSliceFlag{
+		Name:  setFlag,
+		Usage: "Set a key=value variable used by {{.key}} placeholders in general API info, e.g. --set BuildVersion=$(git describe)",
+	},
+	&cli.StringFlag{
+		Name:  openAPIVersionFlag,
+		Value: "2.0",
+		Usage: "OpenAPI version of the generated docs: 2.0, 3.0, or 2.0,3.0 for both. OpenAPI 3.0 files are named openapi.json and openapi.yaml",
+	},
+	&cli.IntFlag{
+		Name:  maxSchemaDepthFlag,
+		Usage: "Fail when definitions are nested deeper than this, 0 for no limit",
+	},
+	&cli.IntFlag{
+		Name:  maxDefinitionsFlag,
+		Usage: "Fail when the docs have more definitions than this, 0 for no limit",
+	},
+	&cli.IntFlag{
+		Name:  maxGenericsFlag,
+		Usage: "Fail when generic types are instantiated more times than this, 0 for no limit",
+	},
+	&cli.IntFlag{
+		Name:  jobsFlag,
+		Usage: "Number of files parsed and written concurrently, the number of CPUs by default. The docs are the same for every value",
+	},
+	&cli.DurationFlag{
+		Name:  timeoutFlag,
+		Usage: "Abort the generation when it takes longer than this duration, e.g. 2m, no timeout by default",
+	},
+	&cli.StringFlag{
+		Name:  configFlag,
+		Usage: "JSON or YAML file of these flags by name, e.g. tagsFile: tags.yaml, overridden by the flags of the command line",
+	},
 }
 
 func initAction(ctx *cli.Context) error {
+	config, err := newConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	buildCtx, cancel := signal.NotifyContext(ctx.Context, os.Interrupt)
+	defer cancel()
+
+	if timeout := ctx.Duration(timeoutFlag); timeout > 0 {
+		buildCtx, cancel = context.WithTimeout(buildCtx, timeout)
+		defer cancel()
+	}
+
+	return gen.New().BuildContext(buildCtx, config)
+}
+
+func validateAction(ctx *cli.Context) error {
+	var issues []swag.ValidationIssue
+
+	if ctx.NArg() > 0 {
+		for _, name := range ctx.Args().Slice() {
+			fileIssues, err := gen.New().ValidateFile(name)
+			if err != nil {
+				return err
+			}
+
+			for i := range fileIssues {
+				fileIssues[i].Source = name
+			}
+
+			issues = append(issues, fileIssues...)
+		}
+	} else {
+		config, err := newConfig(ctx)
+		if err != nil {
+			return err
+		}
+
+		issues, err = gen.New().Validate(config)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%d elements do not validate against their schemas", len(issues))
+	}
+
+	return nil
+}
+
+func convertAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected the document to convert, e.g. swag convert docs/swagger.json")
+	}
+
+	return gen.New().Convert(ctx.String(fromFlag), ctx.String(toFlag), ctx.Args().First(), ctx.String(outputFlag))
+}
+
+var convertFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  fromFlag,
+		Value: gen.Swagger2Version,
+		Usage: "Version of the document to convert",
+	},
+	&cli.StringFlag{
+		Name:  toFlag,
+		Value: gen.OpenAPI3Version,
+		Usage: "Version of the converted document",
+	},
+	&cli.StringFlag{
+		Name:    outputFlag,
+		Aliases: []string{"o"},
+		Usage:   "File of the converted document, in YAML with a .yaml or .yml extension, standard output in JSON by default",
+	},
+}
+
+func mergeAction(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return fmt.Errorf("expected the documents to merge, e.g. swag merge users/swagger.json orders/swagger.json")
+	}
+
+	var prefixes []string
+	if value := ctx.String(prefixesFlag); value != "" {
+		prefixes = strings.Split(value, ",")
+	}
+
+	return gen.New().Merge(&gen.MergeConfig{
+		InputFiles: ctx.Args().Slice(),
+		OutputFile: ctx.String(outputFlag),
+		Strategy:   ctx.String(strategyFlag),
+		Prefixes:   prefixes,
+	})
+}
+
+var mergeFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    outputFlag,
+		Aliases: []string{"o"},
+		Usage:   "File of the merged document, in YAML with a .yaml or .yml extension, standard output in JSON by default",
+	},
+	&cli.StringFlag{
+		Name:  strategyFlag,
+		Value: gen.MergePrefixNone,
+		Usage: "Prefix the definitions, operation IDs and routes of the documents: none, where collisions are errors, collisions or all",
+	},
+	&cli.StringFlag{
+		Name:  prefixesFlag,
+		Usage: "Comma-separated prefixes of the documents, the base names of the files, or of their directories for swagger.json, by default",
+	},
+}
+
+func fuzzCorpusAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected the document of the API, e.g. swag fuzz-corpus -o corpus docs/swagger.json")
+	}
+
+	return gen.New().FuzzCorpus(ctx.Args().First(), ctx.String(outputFlag))
+}
+
+var fuzzCorpusFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    outputFlag,
+		Aliases: []string{"o"},
+		Value:   "corpus",
+		Usage:   "Directory of the corpus, with a directory of JSON requests by operation",
+	},
+}
+
+var benchFlags = append([]cli.Flag{
+	&cli.IntFlag{
+		Name:  runsFlag,
+		Value: 5,
+		Usage: "Number of times the sources are parsed",
+	},
+	&cli.DurationFlag{
+		Name:  maxDurationFlag,
+		Usage: "Fail when the mean duration of a parse is longer than this, e.g. 2s, no threshold by default",
+	},
+	&cli.Uint64Flag{
+		Name:  maxAllocsFlag,
+		Usage: "Fail when a parse allocates more times than this on average, 0 for no threshold",
+	},
+}, initFlags...)
+
+func benchAction(ctx *cli.Context) error {
+	config, err := newConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the logs of every run would hide the result
+	config.Debugger = log.New(io.Discard, "", log.LstdFlags)
+
+	result, err := gen.New().Bench(ctx.Context, config, ctx.Int(runsFlag))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result)
+
+	if maxDuration := ctx.Duration(maxDurationFlag); maxDuration > 0 && result.Mean > maxDuration {
+		return fmt.Errorf("mean duration %s is longer than --%s %s", result.Mean, maxDurationFlag, maxDuration)
+	}
+
+	if maxAllocs := ctx.Uint64(maxAllocsFlag); maxAllocs > 0 && result.AllocsPerRun > maxAllocs {
+		return fmt.Errorf("%d allocs per run are more than --%s %d", result.AllocsPerRun, maxAllocsFlag, maxAllocs)
+	}
+
+	return nil
+}
+
+var crdFlags = append([]cli.Flag{
+	&cli.StringFlag{
+		Name:     typeFlag,
+		Required: true,
+		Usage:    "Type of the schema, e.g. v1.CronTabSpec",
+	},
+}, initFlags...)
+
+func crdAction(ctx *cli.Context) error {
+	config, err := newConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the logs would be mixed with the schema
+	config.Debugger = log.New(io.Discard, "", log.LstdFlags)
+
+	b, err := gen.New().CRD(config, ctx.String(typeFlag))
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(b)
+
+	return err
+}
+
+func pushAction(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		return fmt.Errorf("expected the document to push, e.g. swag push --registry swaggerhub --api acme/petstore/1.0 docs/swagger.json")
+	}
+
+	inputFile := "docs/swagger.json"
+	if ctx.NArg() == 1 {
+		inputFile = ctx.Args().First()
+	}
+
+	return gen.New().Push(ctx.Context, &gen.PushConfig{
+		Registry:    ctx.String(registryFlag),
+		API:         ctx.String(apiFlag),
+		InputFile:   inputFile,
+		RegistryURL: ctx.String(registryURLFlag),
+	})
+}
+
+var pushFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     registryFlag,
+		Required: true,
+		Usage:    "Registry the document is published to: swaggerhub, with the API key of " + gen.SwaggerHubAPIKeyEnv + ", or apigee, with the access token of " + gen.ApigeeTokenEnv,
+	},
+	&cli.StringFlag{
+		Name:     apiFlag,
+		Required: true,
+		Usage:    "API in the registry, owner/name/version for swaggerhub, projects/p/locations/l/apis/a/versions/v/specs/s for apigee",
+	},
+	&cli.StringFlag{
+		Name:  registryURLFlag,
+		Usage: "URL of the REST API of the registry, e.g. of an on-premise SwaggerHub, the public one by default",
+	},
+}
+
+func diffAction(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("expected the old and the new document, e.g. swag diff old/swagger.json docs/swagger.json")
+	}
+
+	changes, err := diff.Files(ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	if len(changes) > 0 {
+		return fmt.Errorf("%d breaking changes", len(changes))
+	}
+
+	return nil
+}
+
+func deprecationsAction(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		return fmt.Errorf("expected the document to report, e.g. swag deprecations docs/swagger.json")
+	}
+
+	inputFile := "docs/swagger.json"
+	if ctx.NArg() == 1 {
+		inputFile = ctx.Args().First()
+	}
+
+	deprecations, err := diff.DeprecationsFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	for _, deprecation := range deprecations {
+		fmt.Println(deprecation)
+	}
+
+	return nil
+}
+
+// newConfig returns the gen.Config of the init flags.
+func newConfig(ctx *cli.Context) (*gen.Config, error) {
+	if err := setConfigFlags(ctx); err != nil {
+		return nil, err
+	}
+
 	strategy := ctx.String(propertyStrategyFlag)
 
 	switch strategy {
 	case swag.CamelCase, swag.SnakeCase, swag.PascalCase:
 	default:
-		return fmt.Errorf("not supported %s propertyStrategy", strategy)
+		return nil, fmt.Errorf("not supported %s propertyStrategy", strategy)
 	}
 
 	leftDelim, rightDelim := "{{", "}}"
@@ -212,11 +770,11 @@ func initAction(ctx *cli.Context) error {
 	if ctx.IsSet(templateDelimsFlag) {
 		delims := strings.Split(ctx.String(templateDelimsFlag), ",")
 		if len(delims) != 2 {
-			return fmt.Errorf(
+			return nil, fmt.Errorf(
 				"exactly two template delimiters must be provided, comma separated",
 			)
 		} else if delims[0] == delims[1] {
-			return fmt.Errorf("template delimiters must be different")
+			return nil, fmt.Errorf("template delimiters must be different")
 		}
 		leftDelim, rightDelim = strings.TrimSpace(
 			delims[0],
@@ -227,9 +785,20 @@ func initAction(ctx *cli.Context) error {
 
 	outputTypes := strings.Split(ctx.String(outputTypesFlag), ",")
 	if len(outputTypes) == 0 {
-		return fmt.Errorf("no output types specified")
+		return nil, fmt.Errorf("no output types specified")
 	}
+	outputDir := ctx.String(outputFlag)
+
+	var output io.Writer
+
+	// the logs must not mix with the document
 	logger := log.New(os.Stdout, "", log.LstdFlags)
+
+	if outputDir == "-" {
+		output, outputDir = os.Stdout, "docs"
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+
 	if ctx.Bool(quietFlag) {
 		logger = log.New(io.Discard, "", log.LstdFlags)
 	}
@@ -238,49 +807,173 @@ func initAction(ctx *cli.Context) error {
 		ctx.String(collectionFormatFlag),
 	)
 	if collectionFormat == "" {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"not supported %s collectionFormat",
 			ctx.String(collectionFormat),
 		)
 	}
 
+	variables := make(map[string]string)
+	for _, keyValue := range ctx.StringSlice(setFlag) {
+		parts := strings.SplitN(keyValue, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid --%s value %q, expected key=value", setFlag, keyValue)
+		}
+
+		variables[parts[0]] = parts[1]
+	}
+
+	var codeSamples []string
+	for _, tool := range strings.Split(ctx.String(codeSamplesFlag), ",") {
+		if tool = strings.TrimSpace(tool); tool != "" {
+			codeSamples = append(codeSamples, tool)
+		}
+	}
+
+	if ctx.Bool(generateCurlSamplesFlag) && !slices.Contains(codeSamples, "curl") {
+		codeSamples = append([]string{"curl"}, codeSamples...)
+	}
+
+	var modelFilters []swag.ModelFilter
+	for _, name := range strings.Split(ctx.String(modelFiltersFlag), ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "gorm":
+			modelFilters = append(modelFilters, swag.GormFilter{})
+		case "gorm-skip-deleted":
+			modelFilters = append(modelFilters, swag.GormFilter{SkipDeletedAt: true})
+		case "ent":
+			modelFilters = append(modelFilters, swag.EntFilter{})
+		default:
+			return nil, fmt.Errorf("not supported %s model filter", name)
+		}
+	}
+
 	var pdv = ctx.Int(parseDependencyLevelFlag)
 	if pdv == 0 {
 		if ctx.Bool(parseDependencyFlag) {
 			pdv = 1
 		}
 	}
-	return gen.New().Build(&gen.Config{
-		SearchDir:           ctx.String(searchDirFlag),
-		Excludes:            ctx.String(excludeFlag),
-		ParseExtension:      ctx.String(parseExtensionFlag),
-		MainAPIFile:         ctx.String(generalInfoFlag),
-		PropNamingStrategy:  strategy,
-		OutputDir:           ctx.String(outputFlag),
-		OutputTypes:         outputTypes,
-		ParseVendor:         ctx.Bool(parseVendorFlag),
-		ParseDependency:     pdv,
-		MarkdownFilesDir:    ctx.String(markdownFilesFlag),
-		ParseInternal:       ctx.Bool(parseInternalFlag),
-		UseStructNames:      ctx.Bool(useStructNameFlag),
-		GeneratedTime:       ctx.Bool(generatedTimeFlag),
-		RequiredByDefault:   ctx.Bool(requiredByDefaultFlag),
-		CodeExampleFilesDir: ctx.String(codeExampleFilesFlag),
-		ParseDepth:          ctx.Int(parseDepthFlag),
-		InstanceName:        ctx.String(instanceNameFlag),
-		OverridesFile:       ctx.String(overridesFileFlag),
-		ParseGoList:         ctx.Bool(parseGoListFlag),
-		Tags:                ctx.String(tagsFlag),
-		LeftTemplateDelim:   leftDelim,
-		RightTemplateDelim:  rightDelim,
-		PackageName:         ctx.String(packageName),
-		Debugger:            logger,
-		CollectionFormat:    collectionFormat,
-		PackagePrefix:       ctx.String(packagePrefixFlag),
-		State:               ctx.String(stateFlag),
-		ParseFuncBody:       ctx.Bool(parseFuncBodyFlag),
-		ParseGoPackages:     ctx.Bool(parseGoPackagesFlag),
-	})
+	return &gen.Config{
+		SearchDir:                ctx.String(searchDirFlag),
+		Excludes:                 ctx.String(excludeFlag),
+		ParseExtension:           ctx.String(parseExtensionFlag),
+		MainAPIFile:              ctx.String(generalInfoFlag),
+		PropNamingStrategy:       strategy,
+		PropertyTags:             ctx.String(propertyTagsFlag),
+		OutputDir:                outputDir,
+		Output:                   output,
+		OutputTypes:              outputTypes,
+		ParseVendor:              ctx.Bool(parseVendorFlag),
+		ParseDependency:          pdv,
+		MarkdownFilesDir:         ctx.String(markdownFilesFlag),
+		MarkdownBaseURL:          ctx.String(markdownBaseURLFlag),
+		ParseInternal:            ctx.Bool(parseInternalFlag),
+		UseStructNames:           ctx.Bool(useStructNameFlag),
+		GeneratedTime:            ctx.Bool(generatedTimeFlag),
+		CompressDoc:              ctx.Bool(compressDocFlag),
+		Compact:                  ctx.Bool(compactFlag),
+		Sort:                     ctx.Bool(sortFlag),
+		SplitByTag:               ctx.Bool(splitByTagFlag),
+		StaticDoc:                ctx.Bool(staticDocFlag),
+		RequiredByDefault:        ctx.Bool(requiredByDefaultFlag),
+		SecurityAlternatives:     ctx.Bool(securityAlternativesFlag),
+		RequiredInResponses:      ctx.Bool(requiredInResponsesFlag),
+		TimeFormat:               ctx.String(timeFormatFlag),
+		RawMessageType:           ctx.String(rawMessageTypeFlag),
+		CodeExampleFilesDir:      ctx.String(codeExampleFilesFlag),
+		CodeSamples:              codeSamples,
+		ExtensionFilesDir:        ctx.String(extensionFilesFlag),
+		ParseDepth:               ctx.Int(parseDepthFlag),
+		InstanceName:             ctx.String(instanceNameFlag),
+		InstancesFile:            ctx.String(instancesFileFlag),
+		EnvironmentsFile:         ctx.String(environmentsFileFlag),
+		OverridesFile:            ctx.String(overridesFileFlag),
+		OperationsFile:           ctx.String(operationsFileFlag),
+		OperationTemplatesFile:   ctx.String(operationTemplatesFlag),
+		ExtensionsFile:           ctx.String(extensionsFileFlag),
+		TagsFile:                 ctx.String(tagsFileFlag),
+		ParseGoList:              ctx.Bool(parseGoListFlag),
+		Tags:                     ctx.String(tagsFlag),
+		OnlyPackages:             ctx.String(onlyFlag),
+		OnlyRoutes:               ctx.String(onlyRoutesFlag),
+		OpenAPIVersion:           ctx.String(openAPIVersionFlag),
+		MaxSchemaDepth:           ctx.Int(maxSchemaDepthFlag),
+		MaxDefinitions:           ctx.Int(maxDefinitionsFlag),
+		MaxGenericInstantiations: ctx.Int(maxGenericsFlag),
+		Jobs:                     ctx.Int(jobsFlag),
+		HTMLRenderer:             ctx.String(htmlRendererFlag),
+		ConfigMapName:            ctx.String(configMapNameFlag),
+		ConfigMapNamespace:       ctx.String(configMapNamespaceFlag),
+		PostHooks:                ctx.StringSlice(postHookFlag),
+		LeftTemplateDelim:        leftDelim,
+		RightTemplateDelim:       rightDelim,
+		PackageName:              ctx.String(packageName),
+		Debugger:                 logger,
+		CollectionFormat:         collectionFormat,
+		PackagePrefix:            ctx.String(packagePrefixFlag),
+		State:                    ctx.String(stateFlag),
+		ParseFuncBody:            ctx.Bool(parseFuncBodyFlag),
+		IncludeGenerated:         ctx.Bool(includeGeneratedFlag),
+		ParseGoPackages:          ctx.Bool(parseGoPackagesFlag),
+		InferInfoFromModule:      ctx.Bool(inferInfoFromModuleFlag),
+		AuthResponses:            ctx.Bool(authResponsesFlag),
+		InheritMimeTypes:         ctx.Bool(inheritMimeTypesFlag),
+		NullablePointers:         ctx.Bool(nullablePointersFlag),
+		PruneUnused:              ctx.Bool(pruneUnusedFlag),
+		PruneUnusedDefinitions:   ctx.Bool(pruneUnusedDefsFlag),
+		DefaultSuccess:           ctx.String(defaultSuccessFlag),
+		RefStrategy:              ctx.String(refStrategyFlag),
+		VendorRemoteRefs:         ctx.Bool(vendorRemoteRefsFlag),
+		RemoteRefsCacheDir:       ctx.String(remoteRefsCacheFlag),
+		EnumsAsRefs:              ctx.Bool(enumsAsRefsFlag),
+		StringerEnums:            ctx.String(stringerEnumsFlag),
+		OmitEmptyExtension:       ctx.Bool(omitEmptyExtensionFlag),
+		ModelFilters:             modelFilters,
+		Variables:                variables,
+	}, nil
+}
+
+// setConfigFlags sets the flags of the config file that the command line does not set. A list sets a flag once per
+// value.
+func setConfigFlags(ctx *cli.Context) error {
+	name := ctx.String(configFlag)
+	if name == "" {
+		return nil
+	}
+
+	var flags map[string]any
+	if err := swag.ReadYAMLOrJSON(name, &flags); err != nil {
+		return err
+	}
+
+	for _, flag := range slices.Sorted(maps.Keys(flags)) {
+		if flag == configFlag {
+			return fmt.Errorf("%s: the config file cannot set %s", name, configFlag)
+		}
+
+		if ctx.IsSet(flag) {
+			continue
+		}
+
+		values, ok := flags[flag].([]any)
+		if !ok {
+			values = []any{flags[flag]}
+		}
+
+		for _, value := range values {
+			if _, ok := value.(map[string]any); ok {
+				return fmt.Errorf("%s: %s must be a value or a list of values", name, flag)
+			}
+
+			if err := ctx.Set(flag, fmt.Sprint(value)); err != nil {
+				return fmt.Errorf("%s: %s: %w", name, flag, err)
+			}
+		}
+	}
+
+	return nil
 }
 
 func main() {
@@ -295,6 +988,66 @@ func main() {
 			Action:  initAction,
 			Flags:   initFlags,
 		},
+		{
+			Name:      "validate",
+			Aliases:   []string{"v"},
+			Usage:     "Check the parsed docs, or the given documents, against the OpenAPI schema and the examples",
+			ArgsUsage: "[swagger.json ...]",
+			Action:    validateAction,
+			Flags:     initFlags,
+		},
+		{
+			Name:      "diff",
+			Usage:     "Report the breaking changes between two swagger documents, failing when there are some",
+			ArgsUsage: "old.json new.json",
+			Action:    diffAction,
+		},
+		{
+			Name:      "deprecations",
+			Usage:     "Report the deprecated operations and properties of a swagger document, the next sunsets first",
+			ArgsUsage: "[swagger.json]",
+			Action:    deprecationsAction,
+		},
+		{
+			Name:      "convert",
+			Usage:     "Convert a generated Swagger 2.0 document to OpenAPI 3.0",
+			ArgsUsage: "swagger.json",
+			Action:    convertAction,
+			Flags:     convertFlags,
+		},
+		{
+			Name:      "merge",
+			Usage:     "Merge generated Swagger 2.0 documents, e.g. of several services into the document of their gateway",
+			ArgsUsage: "a.json b.json ...",
+			Action:    mergeAction,
+			Flags:     mergeFlags,
+		},
+		{
+			Name:      "fuzz-corpus",
+			Usage:     "Write boundary-value requests of the operations of a Swagger 2.0 document, for fuzzing and negative tests",
+			ArgsUsage: "swagger.json",
+			Action:    fuzzCorpusAction,
+			Flags:     fuzzCorpusFlags,
+		},
+		{
+			Name:   "crd",
+			Usage:  "Print the structural schema of a type for the openAPIV3Schema of a Kubernetes CRD",
+			Action: crdAction,
+			Flags:  crdFlags,
+		},
+		{
+			Name:      "push",
+			Usage:     "Publish a generated document to an API registry, SwaggerHub or Apigee",
+			ArgsUsage: "[docs/swagger.json]",
+			Action:    pushAction,
+			Flags:     pushFlags,
+		},
+		{
+			Name:   "bench",
+			Usage:  "Measure the time and memory it takes to parse the sources",
+			Action: benchAction,
+			Flags:  benchFlags,
+		},
 		{
 			Name:    "fmt",
 			Aliases: []string{"f"},
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// newContext returns the context of swag init with the arguments args.
+func newContext(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+
+	set := flag.NewFlagSet("init", flag.ContinueOnError)
+	for _, f := range initFlags {
+		require.NoError(t, f.Apply(set))
+	}
+
+	require.NoError(t, set.Parse(args))
+
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func TestNewConfig_ConfigFile(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "swag.yaml")
+	require.NoError(t, os.WriteFile(name, []byte(`tagsFile: tags.yaml
+parseDepth: 3
+parseDependency: true
+output: ./api
+postHook:
+  - gzip -k {file}
+  - echo {file}
+`), 0o644))
+
+	config, err := newConfig(newContext(t, "--config", name, "--output", "./docs"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "tags.yaml", config.TagsFile)
+	assert.Equal(t, 3, config.ParseDepth)
+	assert.True(t, config.ParseDependency > 0)
+	assert.Equal(t, "./docs", config.OutputDir)
+	assert.Equal(t, []string{"gzip -k {file}", "echo {file}"}, config.PostHooks)
+}
+
+func TestNewConfig_ConfigFileUnknownFlag(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "swag.json")
+	require.NoError(t, os.WriteFile(name, []byte(`{"tagFile": "tags.yaml"}`), 0o644))
+
+	_, err := newConfig(newContext(t, "--config", name))
+	assert.ErrorContains(t, err, "tagFile")
+}
+
+func TestNewConfig_CodeSamples(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		args     []string
+		expected []string
+	}{
+		{nil, nil},
+		{[]string{"--generateCurlSamples"}, []string{"curl"}},
+		{[]string{"--codeSamples", "httpie"}, []string{"httpie"}},
+		{[]string{"--generateCurlSamples", "--codeSamples", "httpie"}, []string{"curl", "httpie"}},
+		{[]string{"--generateCurlSamples", "--codeSamples", "httpie, curl"}, []string{"httpie", "curl"}},
+	} {
+		config, err := newConfig(newContext(t, test.args...))
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, config.CodeSamples, test.args)
+	}
+}
@@ -0,0 +1,449 @@
+package swag
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Tools of the code samples synthesized by SetCodeSamples.
+const (
+	CodeSampleCurl   = "curl"
+	CodeSampleHTTPie = "httpie"
+)
+
+// codeSamplesExtension is the operation extension of the code samples, as Redoc and other portals read it.
+const codeSamplesExtension = "x-codeSamples"
+
+// maxSampleDepth stops building the sample bodies of deeply nested or recursive schemas.
+const maxSampleDepth = 8
+
+// SetCodeSamples synthesizes an x-codeSamples snippet of each tool, CodeSampleCurl or CodeSampleHTTPie, for the
+// operations which have none, from their parameters, their examples and their security.
+func SetCodeSamples(tools []string) func(*Parser) {
+	return func(p *Parser) {
+		p.codeSamples = tools
+	}
+}
+
+// codeSample an x-codeSamples entry.
+type codeSample struct {
+	Lang   string `json:"lang"`
+	Label  string `json:"label"`
+	Source string `json:"source"`
+}
+
+// sampleRequest the parts of an operation request shared by the tools.
+type sampleRequest struct {
+	method      string
+	url         string
+	baseURL     string
+	target      string
+	headers     [][2]string
+	credentials string
+	contentType string
+	body        string
+	query       [][2]string
+	form        [][2]string
+	files       []string
+}
+
+// addCodeSamples adds the code samples of SetCodeSamples to the operations.
+func (parser *Parser) addCodeSamples() {
+	if len(parser.codeSamples) == 0 || parser.swagger.Paths == nil {
+		return
+	}
+
+	for _, path := range sortedKeys(parser.swagger.Paths.Paths) {
+		item := parser.swagger.Paths.Paths[path]
+
+		for _, method := range sortedMethods() {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil || hasCodeSamples(op) {
+				continue
+			}
+
+			request := newSampleRequest(parser.swagger, method, path, &item, op)
+
+			samples := make([]codeSample, 0, len(parser.codeSamples))
+
+			for _, tool := range parser.codeSamples {
+				switch tool {
+				case CodeSampleCurl:
+					samples = append(samples, codeSample{Lang: "Shell", Label: "curl", Source: request.curl()})
+				case CodeSampleHTTPie:
+					samples = append(samples, codeSample{Lang: "Shell", Label: "HTTPie", Source: request.httpie()})
+				}
+			}
+
+			if op.Extensions == nil {
+				op.Extensions = make(spec.Extensions)
+			}
+
+			setExtension(op.Extensions, codeSamplesExtension, samples)
+		}
+	}
+}
+
+// hasCodeSamples reports whether op has code samples, e.g. of @x-codeSamples.
+func hasCodeSamples(op *spec.Operation) bool {
+	for name := range op.Extensions {
+		if strings.EqualFold(name, codeSamplesExtension) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newSampleRequest builds the request of an operation of doc, the values of its parameters are their examples,
+// defaults or first enum values, or placeholders like {id}.
+func newSampleRequest(doc *spec.Swagger, method, path string, item *spec.PathItem, op *spec.Operation) *sampleRequest {
+	scheme := "http"
+	if len(op.Schemes) > 0 {
+		scheme = op.Schemes[0]
+	} else if len(doc.Schemes) > 0 {
+		scheme = doc.Schemes[0]
+	}
+
+	host := doc.Host
+	if host == "" {
+		host = "localhost"
+	}
+
+	request := &sampleRequest{method: method}
+
+	consumes := op.Consumes
+	if len(consumes) == 0 {
+		consumes = doc.Consumes
+	}
+
+	parameters := append(append([]spec.Parameter{}, item.Parameters...), op.Parameters...)
+	for _, param := range parameters {
+		if param.Ref.String() != "" {
+			resolved, ok := doc.Parameters[strings.TrimPrefix(param.Ref.String(), "#/parameters/")]
+			if !ok {
+				continue
+			}
+
+			param = resolved
+		}
+
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", parameterSample(&param))
+		case "query":
+			if param.Required || param.Example != nil || param.Default != nil {
+				request.query = append(request.query, [2]string{param.Name, parameterSample(&param)})
+			}
+		case "header":
+			request.headers = append(request.headers, [2]string{param.Name, parameterSample(&param)})
+		case "formData":
+			if isFileParameter(&param) {
+				request.files = append(request.files, param.Name)
+			} else {
+				request.form = append(request.form, [2]string{param.Name, parameterSample(&param)})
+			}
+		case "body":
+			if param.Schema != nil {
+				request.body = formatValue(schemaSample(doc.Definitions, param.Schema, 0))
+			}
+		}
+	}
+
+	switch {
+	case request.body != "":
+		request.contentType = firstMime(consumes, mimeJSON)
+	case len(request.files) > 0:
+		request.contentType = mimeMultipartForm
+	case len(request.form) > 0:
+		request.contentType = firstMime(consumes, mimeURLEncoded)
+	}
+
+	sampleCredentials(doc, request, op)
+
+	request.baseURL = scheme + "://" + host + strings.TrimSuffix(doc.BasePath, "/")
+	request.target = path
+
+	for i, param := range request.query {
+		separator := "&"
+		if i == 0 {
+			separator = "?"
+		}
+
+		// the placeholders stay readable
+		request.target += separator + url.QueryEscape(param[0]) + "=" +
+			strings.NewReplacer("%7B", "{", "%7D", "}").Replace(url.QueryEscape(param[1]))
+	}
+
+	request.url = request.baseURL + request.target
+
+	return request
+}
+
+const (
+	mimeJSON          = "application/json"
+	mimeURLEncoded    = "application/x-www-form-urlencoded"
+	mimeMultipartForm = "multipart/form-data"
+)
+
+// firstMime returns the first of mimes, or fallback when there is none.
+func firstMime(mimes []string, fallback string) string {
+	if len(mimes) == 0 {
+		return fallback
+	}
+
+	return mimes[0]
+}
+
+// sampleCredentials adds the placeholders of the credentials of the first security requirement of op.
+func sampleCredentials(doc *spec.Swagger, request *sampleRequest, op *spec.Operation) {
+	security := op.Security
+	if security == nil {
+		security = doc.Security
+	}
+
+	if len(security) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(security[0]))
+	for name := range security[0] {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		scheme, ok := doc.SecurityDefinitions[name]
+		if !ok {
+			continue
+		}
+
+		switch scheme.Type {
+		case "apiKey":
+			if scheme.In == "query" {
+				request.query = append(request.query, [2]string{scheme.Name, "{" + scheme.Name + "}"})
+			} else {
+				request.headers = append(request.headers, [2]string{scheme.Name, "{" + scheme.Name + "}"})
+			}
+		case "basic":
+			request.credentials = "{username}:{password}"
+		case "oauth2":
+			request.headers = append(request.headers, [2]string{"Authorization", "Bearer {token}"})
+		}
+	}
+}
+
+// parameterSample returns the sample value of a non body parameter.
+func parameterSample(param *spec.Parameter) string {
+	value := param.Example
+	if value == nil {
+		value = param.Default
+	}
+
+	if value == nil && len(param.Enum) > 0 {
+		value = param.Enum[0]
+	}
+
+	if value == nil && param.Items != nil && len(param.Items.Enum) > 0 {
+		value = param.Items.Enum[0]
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return "{" + param.Name + "}"
+	case string:
+		return value
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			values = append(values, fmt.Sprint(v))
+		}
+
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// schemaSample builds a sample value of schema from its examples, defaults and enums, or from the zero values of
+// its types, following the references to definitions.
+func schemaSample(definitions spec.Definitions, schema *spec.Schema, depth int) any {
+	if schema.Example != nil {
+		return schema.Example
+	}
+
+	if name, ok := definitionName(schema); ok {
+		definition, found := definitions[name]
+		if !found || depth >= maxSampleDepth {
+			return map[string]any{}
+		}
+
+		return schemaSample(definitions, &definition, depth+1)
+	}
+
+	if schema.Default != nil {
+		return schema.Default
+	}
+
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := map[string]any{}
+
+		for i := range schema.AllOf {
+			if value, ok := schemaSample(definitions, &schema.AllOf[i], depth+1).(map[string]any); ok {
+				for name, v := range value {
+					merged[name] = v
+				}
+			}
+		}
+
+		for name, property := range schema.Properties {
+			merged[name] = schemaSample(definitions, &property, depth+1)
+		}
+
+		return merged
+	}
+
+	switch {
+	case schema.Type.Contains(ARRAY):
+		if schema.Items == nil || schema.Items.Schema == nil || depth >= maxSampleDepth {
+			return []any{}
+		}
+
+		return []any{schemaSample(definitions, schema.Items.Schema, depth+1)}
+	case schema.Type.Contains(STRING):
+		value := STRING
+		if schema.MaxLength != nil && int64(len(value)) > *schema.MaxLength {
+			value = value[:*schema.MaxLength]
+		}
+
+		if schema.MinLength != nil && int64(len(value)) < *schema.MinLength {
+			value += strings.Repeat("a", int(*schema.MinLength)-len(value))
+		}
+
+		return value
+	case schema.Type.Contains(INTEGER), schema.Type.Contains(NUMBER):
+		// zero unless it is out of the range
+		switch {
+		case schema.Minimum != nil && (*schema.Minimum > 0 || *schema.Minimum == 0 && schema.ExclusiveMinimum):
+			if schema.ExclusiveMinimum {
+				return *schema.Minimum + 1
+			}
+
+			return *schema.Minimum
+		case schema.Maximum != nil && (*schema.Maximum < 0 || *schema.Maximum == 0 && schema.ExclusiveMaximum):
+			if schema.ExclusiveMaximum {
+				return *schema.Maximum - 1
+			}
+
+			return *schema.Maximum
+		}
+
+		return 0
+	case schema.Type.Contains(BOOLEAN):
+		return false
+	}
+
+	object := map[string]any{}
+
+	if depth < maxSampleDepth {
+		for name, property := range schema.Properties {
+			object[name] = schemaSample(definitions, &property, depth+1)
+		}
+	}
+
+	return object
+}
+
+// curl returns the curl command of the request.
+func (request *sampleRequest) curl() string {
+	args := []string{"curl"}
+	if request.method != "GET" {
+		args = append(args, "-X "+request.method)
+	}
+
+	lines := []string{strings.Join(append(args, shellQuote(request.url)), " ")}
+
+	if request.credentials != "" {
+		lines = append(lines, "-u "+shellQuote(request.credentials))
+	}
+
+	for _, header := range request.headers {
+		lines = append(lines, "-H "+shellQuote(header[0]+": "+header[1]))
+	}
+
+	if request.contentType != "" && request.contentType != mimeMultipartForm {
+		lines = append(lines, "-H "+shellQuote("Content-Type: "+request.contentType))
+	}
+
+	if request.body != "" {
+		lines = append(lines, "-d "+shellQuote(request.body))
+	}
+
+	for _, field := range request.form {
+		if request.contentType == mimeMultipartForm {
+			lines = append(lines, "-F "+shellQuote(field[0]+"="+field[1]))
+		} else {
+			lines = append(lines, "--data-urlencode "+shellQuote(field[0]+"="+field[1]))
+		}
+	}
+
+	for _, file := range request.files {
+		lines = append(lines, "-F "+shellQuote(file+"=@{"+file+"}"))
+	}
+
+	return strings.Join(lines, " \\\n  ")
+}
+
+// httpie returns the HTTPie command of the request.
+func (request *sampleRequest) httpie() string {
+	args := []string{"http"}
+
+	switch {
+	case len(request.files) > 0:
+		args = append(args, "--multipart")
+	case len(request.form) > 0:
+		args = append(args, "--form")
+	}
+
+	if request.credentials != "" {
+		args = append(args, "-a "+shellQuote(request.credentials))
+	}
+
+	if request.body != "" {
+		args = append(args, "--raw "+shellQuote(request.body))
+	}
+
+	lines := []string{strings.Join(append(args, request.method, shellQuote(request.url)), " ")}
+
+	for _, header := range request.headers {
+		lines = append(lines, shellQuote(header[0]+":"+header[1]))
+	}
+
+	if request.body != "" {
+		lines = append(lines, shellQuote("Content-Type:"+request.contentType))
+	}
+
+	for _, field := range request.form {
+		lines = append(lines, shellQuote(field[0]+"="+field[1]))
+	}
+
+	for _, file := range request.files {
+		lines = append(lines, shellQuote(file+"@{"+file+"}"))
+	}
+
+	return strings.Join(lines, " \\\n  ")
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,109 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_CodeSamples(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Pet struct {
+	Name string   ` + "`json:\"name\" example:\"doggie\"`" + `
+	Tags []string ` + "`json:\"tags\"`" + `
+}
+
+// @Param    id     path  int  true  "pet ID" example(42)
+// @Param    dryRun query bool false "validate only" default(true)
+// @Param    limit  query int  false "page size"
+// @Param    pet    body  Pet  true  "the pet"
+// @Security ApiKeyAuth
+// @Success  200
+// @Router   /pets/{id} [put]
+func UpdatePet(){
+}
+
+// @Param        name formData string true "the name"
+// @Param        photo formData file true "the photo"
+// @x-codeSamples [{"lang": "Go", "source": "client.Upload()"}]
+// @Success      200
+// @Router       /photos [post]
+func Upload(){
+}
+
+// @Success 200
+// @Router  /health [get]
+func Health(){
+}
+`
+	p := New(SetCodeSamples([]string{CodeSampleCurl, CodeSampleHTTPie}))
+	p.swagger.Host = "petstore.example.com"
+	p.swagger.BasePath = "/v1"
+	p.swagger.Schemes = []string{"https"}
+	p.swagger.SecurityDefinitions = spec.SecurityDefinitions{
+		"ApiKeyAuth": spec.APIKeyAuth("X-API-Key", "header"),
+	}
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	p.addCodeSamples()
+
+	assert.Equal(t, []codeSample{
+		{
+			Lang:  "Shell",
+			Label: "curl",
+			Source: `curl -X PUT 'https://petstore.example.com/v1/pets/42?dryRun=true' \
+  -H 'X-API-Key: {X-API-Key}' \
+  -H 'Content-Type: application/json' \
+  -d '{"name":"doggie","tags":["string"]}'`,
+		},
+		{
+			Lang:  "Shell",
+			Label: "HTTPie",
+			Source: `http --raw '{"name":"doggie","tags":["string"]}' PUT 'https://petstore.example.com/v1/pets/42?dryRun=true' \
+  'X-API-Key:{X-API-Key}' \
+  'Content-Type:application/json'`,
+		},
+	}, p.swagger.Paths.Paths["/pets/{id}"].Put.Extensions[codeSamplesExtension])
+
+	assert.Equal(t, []codeSample{
+		{Lang: "Shell", Label: "curl", Source: `curl 'https://petstore.example.com/v1/health'`},
+		{Lang: "Shell", Label: "HTTPie", Source: `http GET 'https://petstore.example.com/v1/health'`},
+	}, p.swagger.Paths.Paths["/health"].Get.Extensions[codeSamplesExtension])
+
+	// the written samples are kept
+	assert.Equal(t, []any{map[string]any{"lang": "Go", "source": "client.Upload()"}},
+		p.swagger.Paths.Paths["/photos"].Post.Extensions[codeSamplesExtension])
+}
+
+func TestSampleRequest_Form(t *testing.T) {
+	t.Parallel()
+
+	request := &sampleRequest{
+		method:      "POST",
+		url:         "http://localhost/photos",
+		credentials: "{username}:{password}",
+		contentType: mimeMultipartForm,
+		form:        [][2]string{{"name", "it's me"}},
+		files:       []string{"photo"},
+	}
+
+	assert.Equal(t, `curl -X POST 'http://localhost/photos' \
+  -u '{username}:{password}' \
+  -F 'name=it'\''s me' \
+  -F 'photo=@{photo}'`, request.curl())
+	assert.Equal(t, `http --multipart -a '{username}:{password}' POST 'http://localhost/photos' \
+  'name=it'\''s me' \
+  'photo@{photo}'`, request.httpie())
+}
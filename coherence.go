@@ -0,0 +1,209 @@
+package swag
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/go-openapi/spec"
+)
+
+// checkValueCoherence validates that declared default and example values are members of the enums
+// and satisfy the minimum, maximum, length and pattern validations. Inconsistencies are errors in
+// strict mode and warnings otherwise.
+func (parser *Parser) checkValueCoherence() error {
+	var issues []string
+
+	paths := make([]string, 0, len(parser.swagger.Paths.Paths))
+	for path := range parser.swagger.Paths.Paths {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := parser.swagger.Paths.Paths[path]
+
+		for _, method := range sortedMethods() {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil {
+				continue
+			}
+
+			for _, param := range op.Parameters {
+				if param.Schema != nil || param.Type == ARRAY {
+					continue
+				}
+
+				location := fmt.Sprintf("%s %s param %s", method, path, param.Name)
+				issues = append(issues, checkValues(location, param.Default, param.Example, &param.CommonValidations)...)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(parser.swagger.Definitions))
+	for name := range parser.swagger.Definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := parser.swagger.Definitions[name]
+		issues = append(issues, checkSchemaValues(name, &schema)...)
+	}
+
+	for _, issue := range issues {
+		if parser.Strict {
+			return fmt.Errorf("%s", issue)
+		}
+
+		parser.debug.Printf("warning: %s", issue)
+	}
+
+	return nil
+}
+
+// checkSchemaValues checks the values of the properties of schema, recursively.
+func checkSchemaValues(location string, schema *spec.Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var issues []string
+
+	for _, name := range names {
+		property := schema.Properties[name]
+		propertyLocation := location + "." + name
+
+		if !property.Type.Contains(ARRAY) && !property.Type.Contains(OBJECT) {
+			validations := schemaValidations(&property)
+			issues = append(issues, checkValues(propertyLocation, property.Default, property.Example, &validations)...)
+		}
+
+		issues = append(issues, checkSchemaValues(propertyLocation, &property)...)
+	}
+
+	return issues
+}
+
+// schemaValidations returns the validations of schema which apply to scalar values.
+func schemaValidations(schema *spec.Schema) spec.CommonValidations {
+	return spec.CommonValidations{
+		Maximum:          schema.Maximum,
+		ExclusiveMaximum: schema.ExclusiveMaximum,
+		Minimum:          schema.Minimum,
+		ExclusiveMinimum: schema.ExclusiveMinimum,
+		MaxLength:        schema.MaxLength,
+		MinLength:        schema.MinLength,
+		Pattern:          schema.Pattern,
+		Enum:             schema.Enum,
+	}
+}
+
+// checkValues checks the default and example values against the validations.
+func checkValues(location string, defaultValue, example any, validations *spec.CommonValidations) []string {
+	var issues []string
+
+	for _, value := range []struct {
+		name  string
+		value any
+	}{{"default", defaultValue}, {"example", example}} {
+		if value.value == nil {
+			continue
+		}
+
+		if issue := checkValue(value.value, validations); issue != "" {
+			issues = append(issues, fmt.Sprintf("%s: %s %v %s", location, value.name, value.value, issue))
+		}
+	}
+
+	return issues
+}
+
+// checkValue returns why value violates the validations, or an empty string.
+func checkValue(value any, validations *spec.CommonValidations) string {
+	if len(validations.Enum) > 0 && !isEnumMember(value, validations.Enum) {
+		return fmt.Sprintf("is not one of the enums %v", validations.Enum)
+	}
+
+	if number, ok := toFloat(value); ok {
+		if validations.Minimum != nil && (number < *validations.Minimum ||
+			validations.ExclusiveMinimum && number == *validations.Minimum) {
+			return fmt.Sprintf("is less than the minimum %v", *validations.Minimum)
+		}
+
+		if validations.Maximum != nil && (number > *validations.Maximum ||
+			validations.ExclusiveMaximum && number == *validations.Maximum) {
+			return fmt.Sprintf("is greater than the maximum %v", *validations.Maximum)
+		}
+	}
+
+	if text, ok := value.(string); ok {
+		length := int64(utf8.RuneCountInString(text))
+
+		if validations.MinLength != nil && length < *validations.MinLength {
+			return fmt.Sprintf("is shorter than the minimum length %d", *validations.MinLength)
+		}
+
+		if validations.MaxLength != nil && length > *validations.MaxLength {
+			return fmt.Sprintf("is longer than the maximum length %d", *validations.MaxLength)
+		}
+
+		if validations.Pattern != "" {
+			pattern, err := regexp.Compile(validations.Pattern)
+			if err == nil && !pattern.MatchString(text) {
+				return fmt.Sprintf("does not match the pattern %s", validations.Pattern)
+			}
+		}
+	}
+
+	return ""
+}
+
+// isEnumMember compares by the printed values, since enums and values are not always parsed into the same types.
+func isEnumMember(value any, enums []any) bool {
+	for _, enum := range enums {
+		if fmt.Sprint(enum) == fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, !math.IsNaN(v)
+	case string:
+		return 0, false
+	default:
+		number, err := strconv.ParseFloat(fmt.Sprint(v), 64)
+
+		return number, err == nil
+	}
+}
+
+func sortedMethods() []string {
+	methods := make([]string, 0, len(allMethod))
+	for method := range allMethod {
+		methods = append(methods, method)
+	}
+
+	sort.Strings(methods)
+
+	return methods
+}
@@ -0,0 +1,79 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckValue(t *testing.T) {
+	t.Parallel()
+
+	minimum, maximum := 1.0, 10.0
+	minLength := int64(2)
+
+	tests := []struct {
+		name        string
+		value       any
+		validations spec.CommonValidations
+		want        string
+	}{
+		{"enum member", 2, spec.CommonValidations{Enum: []any{1, 2}}, ""},
+		{"not an enum member", "c", spec.CommonValidations{Enum: []any{"a", "b"}}, "is not one of the enums [a b]"},
+		{"below minimum", 0, spec.CommonValidations{Minimum: &minimum}, "is less than the minimum 1"},
+		{"exclusive minimum", 1.0, spec.CommonValidations{Minimum: &minimum, ExclusiveMinimum: true}, "is less than the minimum 1"},
+		{"above maximum", 11, spec.CommonValidations{Maximum: &maximum}, "is greater than the maximum 10"},
+		{"too short", "a", spec.CommonValidations{MinLength: &minLength}, "is shorter than the minimum length 2"},
+		{"pattern mismatch", "abc", spec.CommonValidations{Pattern: "^[0-9]+$"}, "does not match the pattern ^[0-9]+$"},
+		{"pattern match", "123", spec.CommonValidations{Pattern: "^[0-9]+$"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checkValue(tt.value, &tt.validations))
+		})
+	}
+}
+
+func TestParser_checkValueCoherence(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package test
+
+type Order struct {
+	Status string ` + "`" + `json:"status" enums:"placed,approved" default:"delivered"` + "`" + `
+}
+
+// @Param limit query int false "limit" minimum(1) maximum(100) default(500)
+// @Success 200 {object} Order
+// @Router /orders [get]
+func Test(){
+}
+`
+	for _, strict := range []bool{false, true} {
+		p := New(SetStrict(strict))
+
+		err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+		assert.NoError(t, err)
+
+		_, err = p.packages.ParseTypes()
+		assert.NoError(t, err)
+
+		err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+		assert.NoError(t, err)
+
+		err = p.checkValueCoherence()
+		if !strict {
+			assert.NoError(t, err)
+
+			continue
+		}
+
+		assert.EqualError(t, err, "GET /orders param limit: default 500 is greater than the maximum 100")
+
+		p.swagger.Paths.Paths["/orders"].Get.Parameters[0].Default = 50
+		assert.EqualError(t, p.checkValueCoherence(), "test.Order.status: default delivered is not one of the enums [placed approved]")
+	}
+}
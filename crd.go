@@ -0,0 +1,127 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// crdSchemaKeywords the keywords of JSON schemas kept in the openAPIV3Schema of a Kubernetes CRD, besides the
+// x-kubernetes extensions.
+var crdSchemaKeywords = map[string]bool{
+	"description": true, "type": true, "format": true, "title": true, "default": true, "example": true,
+	"maximum": true, "exclusiveMaximum": true, "minimum": true, "exclusiveMinimum": true, "multipleOf": true,
+	"maxLength": true, "minLength": true, "pattern": true, "maxItems": true, "minItems": true, "uniqueItems": true,
+	"maxProperties": true, "minProperties": true, "enum": true, "required": true, "nullable": true,
+	"items": true, "properties": true, "additionalProperties": true, "externalDocs": true,
+}
+
+// CRDSchema returns the structural schema of the type typeName, e.g. v1.CronTabSpec, for the openAPIV3Schema of a
+// Kubernetes custom resource definition. The type is parsed like the models of the operations, the definitions it
+// references are inlined, the allOf compositions merged, the schemas without type, e.g. of interface{}, preserve
+// the unknown fields, and the keywords Kubernetes does not accept are left out.
+func (parser *Parser) CRDSchema(typeName string) (map[string]any, error) {
+	schema, err := parser.getTypeSchema(typeName, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return crdSchema(parser.swagger, schema, typeName)
+}
+
+// crdSchema returns the structural schema of schema named name, whose references are the definitions of doc.
+func crdSchema(doc *spec.Swagger, schema *spec.Schema, name string) (map[string]any, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var structural spec.Schema
+	if err := json.Unmarshal(b, &structural); err != nil {
+		return nil, err
+	}
+
+	if err := flattenSchema(doc, &structural, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	transformer := subsetTransformer{doc: doc}
+	if err := transformer.schema(&structural, name, map[string]bool{}); err != nil {
+		return nil, err
+	}
+
+	b, err = json.Marshal(structural)
+	if err != nil {
+		return nil, err
+	}
+
+	var value map[string]any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+
+	return value, structuralSchema(value, name)
+}
+
+// structuralSchema rewrites the schema value at location into a structural schema in place.
+func structuralSchema(value map[string]any, location string) error {
+	if ref, ok := value["$ref"].(string); ok {
+		return fmt.Errorf("%s: %s refers to itself, which a structural schema can not express", location, ref)
+	}
+
+	if nullable, _ := value["x-nullable"].(bool); nullable {
+		value["nullable"] = true
+	}
+
+	for key := range value {
+		if !crdSchemaKeywords[key] && !strings.HasPrefix(key, "x-kubernetes-") {
+			delete(value, key)
+		}
+	}
+
+	if _, ok := value["type"]; !ok {
+		value["x-kubernetes-preserve-unknown-fields"] = true
+	}
+
+	// properties and additionalProperties can not be used together
+	if _, ok := value["properties"]; ok {
+		delete(value, "additionalProperties")
+	}
+
+	if properties, ok := value["properties"].(map[string]any); ok {
+		for _, name := range sortedKeys(properties) {
+			if property, ok := properties[name].(map[string]any); ok {
+				if err := structuralSchema(property, location+"/properties/"+escapePointer(name)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	switch items := value["items"].(type) {
+	case map[string]any:
+		if err := structuralSchema(items, location+"/items"); err != nil {
+			return err
+		}
+	case []any:
+		return fmt.Errorf("%s: tuple items can not be expressed by a structural schema", location)
+	}
+
+	switch additionalProperties := value["additionalProperties"].(type) {
+	case map[string]any:
+		if err := structuralSchema(additionalProperties, location+"/additionalProperties"); err != nil {
+			return err
+		}
+	case bool:
+		if additionalProperties {
+			// any value, like an untyped schema
+			value["additionalProperties"] = map[string]any{"x-kubernetes-preserve-unknown-fields": true}
+		} else {
+			delete(value, "additionalProperties")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_CRDSchema(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package v1
+
+type CronTabSpec struct {
+	// the schedule in cron format
+	Schedule string ` + "`json:\"schedule\" example:\"*/5 * * * *\" validate:\"required\"`" + `
+	Image    *Image ` + "`json:\"image\" extensions:\"x-nullable\"`" + `
+	Labels   map[string]string ` + "`json:\"labels\"`" + `
+	Config   interface{} ` + "`json:\"config\"`" + `
+}
+
+type Image struct {
+	Name string ` + "`json:\"name\" readonly:\"true\"`" + `
+}
+
+type Node struct {
+	Children []Node ` + "`json:\"children\"`" + `
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("v1", "v1/v1.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	schema, err := p.CRDSchema("v1.CronTabSpec")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"type":     "object",
+		"required": []any{"schedule"},
+		"properties": map[string]any{
+			"schedule": map[string]any{
+				"type":        "string",
+				"description": "the schedule in cron format",
+				"example":     "*/5 * * * *",
+			},
+			"image": map[string]any{
+				"type":     "object",
+				"nullable": true,
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string"},
+				},
+			},
+			"labels": map[string]any{
+				"type":                 "object",
+				"additionalProperties": map[string]any{"type": "string"},
+			},
+			"config": map[string]any{"x-kubernetes-preserve-unknown-fields": true},
+		},
+	}, schema)
+
+	_, err = p.CRDSchema("v1.Node")
+	assert.EqualError(t, err,
+		"v1.Node/properties/children/items: #/definitions/v1.Node refers to itself, which a structural schema can not express")
+
+	_, err = p.CRDSchema("v1.Missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// defaultsDirective is the prefix of the single line comments declaring annotations applied to every operation of
+// their package, usually in doc.go:
+//
+//	//swag:defaults @Produce json @Security ApiKeyAuth @Tags billing
+//
+// An operation declaring one of these attributes itself, e.g. its own @Tags, does not get the default ones.
+const defaultsDirective = "//swag:defaults"
+
+// packageDefaults returns the annotation lines of the //swag:defaults comments of the package of fileInfo, in the
+// order of the files and their comments.
+func (parser *Parser) packageDefaults(fileInfo *AstFileInfo) ([]string, error) {
+	if lines, ok := parser.defaults[fileInfo.PackagePath]; ok {
+		return lines, nil
+	}
+
+	files := make([]*AstFileInfo, 0)
+
+	for _, info := range parser.packages.files {
+		if info.PackagePath == fileInfo.PackagePath {
+			files = append(files, info)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	var lines []string
+
+	for _, info := range files {
+		for _, group := range info.File.Comments {
+			for _, comment := range group.List {
+				args, ok := strings.CutPrefix(comment.Text, defaultsDirective)
+				if !ok || args != "" && args[0] != ' ' && args[0] != '\t' {
+					continue
+				}
+
+				annotations := splitAnnotations(args)
+				if len(annotations) == 0 {
+					return nil, fmt.Errorf("%s in file %s: expected annotations, got '%s'",
+						defaultsDirective, info.Path, strings.TrimSpace(args))
+				}
+
+				lines = append(lines, annotations...)
+			}
+		}
+	}
+
+	if parser.defaults == nil {
+		parser.defaults = make(map[string][]string)
+	}
+
+	parser.defaults[fileInfo.PackagePath] = lines
+
+	return lines, nil
+}
+
+// splitAnnotations splits a line of annotations, e.g. @Produce json @Tags billing, into one per annotation.
+func splitAnnotations(line string) []string {
+	var annotations []string
+
+	for _, field := range strings.Fields(line) {
+		switch {
+		case strings.HasPrefix(field, "@"):
+			annotations = append(annotations, field)
+		case len(annotations) > 0:
+			annotations[len(annotations)-1] += " " + field
+		default:
+			return nil
+		}
+	}
+
+	return annotations
+}
+
+// withPackageDefaults returns the comments of an operation preceded by the defaults of its package whose attributes
+// the operation does not declare. The comments without @Router or @crud are no operation, they are left as they are.
+func (parser *Parser) withPackageDefaults(comments []*ast.Comment, fileInfo *AstFileInfo) ([]*ast.Comment, error) {
+	if len(comments) == 0 || parser.packages == nil {
+		return comments, nil
+	}
+
+	declared := make(map[string]bool, len(comments))
+
+	for _, comment := range comments {
+		if fields := strings.Fields(strings.TrimLeft(comment.Text, "/")); len(fields) > 0 {
+			declared[strings.ToLower(fields[0])] = true
+		}
+	}
+
+	if !declared[routerAttr] && !declared[crudAttr] {
+		return comments, nil
+	}
+
+	defaults, err := parser.packageDefaults(fileInfo)
+	if err != nil || len(defaults) == 0 {
+		return comments, err
+	}
+
+	withDefaults := make([]*ast.Comment, 0, len(defaults)+len(comments))
+
+	for _, line := range defaults {
+		if !declared[strings.ToLower(strings.Fields(line)[0])] {
+			withDefaults = append(withDefaults, &ast.Comment{Slash: comments[0].Slash, Text: "// " + line})
+		}
+	}
+
+	return append(withDefaults, comments...), nil
+}
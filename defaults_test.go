@@ -0,0 +1,89 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_PackageDefaults(t *testing.T) {
+	t.Parallel()
+
+	doc := `
+//swag:defaults @Produce json @Security ApiKeyAuth
+//swag:defaults @Tags billing
+package billing
+`
+	src := `
+package billing
+
+// @Summary List the invoices
+// @Router  /invoices [get]
+func ListInvoices() {}
+
+// @Summary Download an invoice
+// @Produce application/pdf
+// @Tags    billing,documents
+// @Router  /invoices/{id}/pdf [get]
+func DownloadInvoice() {}
+
+// helper is no operation
+func helper() {}
+`
+	other := `
+package users
+
+// @Router /users [get]
+func ListUsers() {}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("billing", "testdata/defaults/billing/doc.go", doc, ParseAll))
+	require.NoError(t, p.packages.ParseFile("billing", "testdata/defaults/billing/api.go", src, ParseAll))
+	require.NoError(t, p.packages.ParseFile("users", "testdata/defaults/users/api.go", other, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	paths := p.swagger.Paths.Paths
+
+	list := paths["/invoices"].Get
+	assert.Equal(t, []string{"application/json"}, list.Produces)
+	assert.Equal(t, []map[string][]string{{"ApiKeyAuth": {}}}, list.Security)
+	assert.Equal(t, []string{"billing"}, list.Tags)
+
+	download := paths["/invoices/{id}/pdf"].Get
+	assert.Equal(t, []string{"application/pdf"}, download.Produces)
+	assert.Equal(t, []map[string][]string{{"ApiKeyAuth": {}}}, download.Security)
+	assert.Equal(t, []string{"billing", "documents"}, download.Tags)
+
+	users := paths["/users"].Get
+	assert.Empty(t, users.Produces)
+	assert.Empty(t, users.Security)
+	assert.Empty(t, users.Tags)
+}
+
+func TestParser_PackageDefaultsErrors(t *testing.T) {
+	t.Parallel()
+
+	src := `
+//swag:defaults json
+package billing
+
+// @Router /invoices [get]
+func ListInvoices() {}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("billing", "testdata/defaults/billing/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.ErrorContains(t, err, "//swag:defaults in file ")
+	assert.ErrorContains(t, err, "testdata/defaults/billing/api.go: expected annotations, got 'json'")
+}
@@ -0,0 +1,95 @@
+package swag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	// DeprecatedExtension marks the properties of the deprecated fields. Swagger 2.0 has no deprecated schemas,
+	// OpenAPI 3.0 documents have the real keyword.
+	DeprecatedExtension = "x-deprecated"
+
+	// DeprecatedReasonExtension is the extension of an operation, or a property, holding the reason of its
+	// deprecation.
+	DeprecatedReasonExtension = "x-deprecated-reason"
+
+	// SunsetExtension is the extension of a deprecated operation, or property, holding the date it is removed, like
+	// the Sunset header of RFC 8594.
+	SunsetExtension = "x-sunset"
+)
+
+// sunsetPrefix introduces the sunset date of a @Deprecated annotation or a deprecated tag.
+const sunsetPrefix = "sunset "
+
+// ParseDeprecatedComment marks the operation as deprecated, with the optional reason and sunset date of the
+// annotation: @Deprecated use /v2/users; sunset 2025-06-01.
+func (operation *Operation) ParseDeprecatedComment(commentLine string) error {
+	operation.Deprecate()
+
+	reason, sunset, err := parseDeprecation(commentLine)
+	if err != nil {
+		return fmt.Errorf("%w of @Deprecated, expected YYYY-MM-DD", err)
+	}
+
+	if sunset != "" {
+		operation.Extensions[SunsetExtension] = sunset
+	}
+
+	if reason != "" {
+		operation.Extensions[DeprecatedReasonExtension] = reason
+	}
+
+	return nil
+}
+
+// complementDeprecation marks the property of a field deprecated by its deprecated tag, either "true" or a reason
+// with an optional sunset date like @Deprecated: deprecated:"use fullName; sunset 2025-06-01".
+func (ps *tagBaseFieldParser) complementDeprecation(schema *spec.Schema) error {
+	value := strings.TrimSpace(ps.tag.Get(deprecatedTag))
+	if value == "" || value == "false" {
+		return nil
+	}
+
+	schema.AddExtension(DeprecatedExtension, true)
+
+	if value == "true" {
+		return nil
+	}
+
+	reason, sunset, err := parseDeprecation(value)
+	if err != nil {
+		return fmt.Errorf("%w of the %s tag, expected YYYY-MM-DD", err, deprecatedTag)
+	}
+
+	if sunset != "" {
+		schema.AddExtension(SunsetExtension, sunset)
+	}
+
+	if reason != "" {
+		schema.AddExtension(DeprecatedReasonExtension, reason)
+	}
+
+	return nil
+}
+
+// parseDeprecation splits a deprecation into its reason and the sunset date after the last semicolon, if any.
+func parseDeprecation(value string) (reason, sunset string, err error) {
+	reason = strings.TrimSpace(value)
+
+	index := strings.LastIndex(reason, ";")
+	if last := strings.TrimSpace(reason[index+1:]); strings.HasPrefix(strings.ToLower(last), sunsetPrefix) {
+		reason, sunset = strings.TrimSpace(reason[:max(index, 0)]), strings.TrimSpace(last[len(sunsetPrefix):])
+	}
+
+	if sunset != "" {
+		if _, err := time.Parse(time.DateOnly, sunset); err != nil {
+			return "", "", fmt.Errorf("invalid sunset date %s", sunset)
+		}
+	}
+
+	return reason, sunset, nil
+}
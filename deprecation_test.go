@@ -0,0 +1,90 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeprecatedComment(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		comment string
+		reason  any
+		sunset  any
+	}{
+		{comment: "@Deprecated"},
+		{comment: "@Deprecated use /v2/users", reason: "use /v2/users"},
+		{comment: "@Deprecated use /v2/users; sunset 2025-06-01", reason: "use /v2/users", sunset: "2025-06-01"},
+		{comment: "@Deprecated sunset 2025-06-01", sunset: "2025-06-01"},
+		{comment: "@Deprecated slow; use /v2/users", reason: "slow; use /v2/users"},
+	} {
+		operation := NewOperation(nil)
+		require.NoError(t, operation.ParseComment(tc.comment, nil), tc.comment)
+
+		assert.True(t, operation.Deprecated, tc.comment)
+		assert.Equal(t, tc.reason, operation.Extensions[DeprecatedReasonExtension], tc.comment)
+		assert.Equal(t, tc.sunset, operation.Extensions[SunsetExtension], tc.comment)
+	}
+
+	operation := NewOperation(nil)
+	assert.EqualError(t, operation.ParseComment("@Deprecated use /v2/users; sunset June 2025", nil),
+		"invalid sunset date June 2025 of @Deprecated, expected YYYY-MM-DD")
+}
+
+func TestParser_DeprecatedTag(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name     string ` + "`json:\"name\" deprecated:\"use fullName; sunset 2025-06-01\"`" + `
+	Nickname string ` + "`json:\"nickname\" deprecated:\"true\"`" + `
+	FullName string ` + "`json:\"fullName\" deprecated:\"false\"`" + `
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "testdata/deprecation/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	schema, err := p.getTypeSchema("api.User", nil, false)
+	require.NoError(t, err)
+
+	properties := schema.Properties
+
+	name := properties["name"]
+	assert.Equal(t, true, name.Extensions[DeprecatedExtension])
+	assert.Equal(t, "use fullName", name.Extensions[DeprecatedReasonExtension])
+	assert.Equal(t, "2025-06-01", name.Extensions[SunsetExtension])
+
+	nickname := properties["nickname"]
+	assert.Equal(t, true, nickname.Extensions[DeprecatedExtension])
+	assert.NotContains(t, nickname.Extensions, DeprecatedReasonExtension)
+
+	assert.NotContains(t, properties["fullName"].Extensions, DeprecatedExtension)
+}
+
+func TestParser_DeprecatedTagInvalidSunset(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name string ` + "`json:\"name\" deprecated:\"use fullName; sunset June 2025\"`" + `
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "testdata/deprecation/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	_, err = p.getTypeSchema("api.User", nil, false)
+	assert.ErrorContains(t, err, "invalid sunset date June 2025 of the deprecated tag, expected YYYY-MM-DD")
+}
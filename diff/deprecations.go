@@ -0,0 +1,133 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Extensions of the deprecated operations and properties, see the swag package.
+const (
+	deprecatedExtension       = "x-deprecated"
+	deprecatedReasonExtension = "x-deprecated-reason"
+	sunsetExtension           = "x-sunset"
+)
+
+// Deprecation is a deprecated operation or property of a document.
+type Deprecation struct {
+	// Location the path and method of the operation, or the definition, which is deprecated
+	Location string
+
+	// Property the deprecated property of the definition, empty for operations
+	Property string
+
+	Reason string
+
+	// Sunset the date the operation or property is removed, YYYY-MM-DD, if known
+	Sunset string
+}
+
+// String returns the deprecation in a single line.
+func (d Deprecation) String() string {
+	var b strings.Builder
+
+	b.WriteString(d.Location)
+
+	if d.Property != "" {
+		b.WriteString(": property " + d.Property)
+	}
+
+	b.WriteString(" is deprecated")
+
+	if d.Reason != "" {
+		b.WriteString(", " + d.Reason)
+	}
+
+	if d.Sunset != "" {
+		b.WriteString(" (sunset " + d.Sunset + ")")
+	}
+
+	return b.String()
+}
+
+// DeprecationsFile returns the deprecations of the swagger document of a file, in JSON or YAML.
+func DeprecationsFile(name string) ([]Deprecation, error) {
+	doc, err := readFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return Deprecations(doc), nil
+}
+
+// Deprecations returns the deprecated operations and definition properties of doc, sorted by sunset date, the
+// ones without a date last, then by location and property.
+func Deprecations(doc *spec.Swagger) []Deprecation {
+	var deprecations []Deprecation
+
+	for path, item := range paths(doc) {
+		for _, method := range methods {
+			op := operation(item, method)
+			if op == nil || !op.Deprecated {
+				continue
+			}
+
+			deprecations = append(deprecations, Deprecation{
+				Location: method + " " + path,
+				Reason:   stringExtension(op.Extensions, deprecatedReasonExtension),
+				Sunset:   stringExtension(op.Extensions, sunsetExtension),
+			})
+		}
+	}
+
+	for name, schema := range doc.Definitions {
+		deprecations = appendPropertyDeprecations(deprecations, "definition "+name, "", &schema)
+	}
+
+	sort.Slice(deprecations, func(i, j int) bool {
+		a, b := deprecations[i], deprecations[j]
+		if a.Sunset != b.Sunset {
+			return b.Sunset == "" || a.Sunset != "" && a.Sunset < b.Sunset
+		}
+
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+
+		return a.Property < b.Property
+	})
+
+	return deprecations
+}
+
+// appendPropertyDeprecations appends the deprecated properties of schema, and of its items and nested objects.
+// Referenced definitions are reported on their own.
+func appendPropertyDeprecations(deprecations []Deprecation, location, name string, schema *spec.Schema) []Deprecation {
+	if name != "" {
+		if deprecated, _ := schema.Extensions[deprecatedExtension].(bool); deprecated {
+			deprecations = append(deprecations, Deprecation{
+				Location: location,
+				Property: name,
+				Reason:   stringExtension(schema.Extensions, deprecatedReasonExtension),
+				Sunset:   stringExtension(schema.Extensions, sunsetExtension),
+			})
+		}
+	}
+
+	if schema.Items != nil && schema.Items.Schema != nil {
+		deprecations = appendPropertyDeprecations(deprecations, location, join(name, "[]"), schema.Items.Schema)
+	}
+
+	for property, propertySchema := range schema.Properties {
+		deprecations = appendPropertyDeprecations(deprecations, location, join(name, property), &propertySchema)
+	}
+
+	return deprecations
+}
+
+func stringExtension(extensions spec.Extensions, key string) string {
+	value, _ := extensions.GetString(key)
+
+	return value
+}
@@ -0,0 +1,53 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecations(t *testing.T) {
+	assert.Empty(t, Deprecations(&spec.Swagger{}))
+
+	listPets := spec.NewOperation("listPets")
+	listPets.Deprecated = true
+	listPets.Extensions = spec.Extensions{"x-deprecated-reason": "use /v2/pets", "x-sunset": "2025-06-01"}
+
+	getPet := spec.NewOperation("getPet")
+	getPet.Deprecated = true
+
+	nickname := *spec.StringProperty()
+	nickname.Extensions = spec.Extensions{"x-deprecated": true}
+
+	street := *spec.StringProperty()
+	street.Extensions = spec.Extensions{"x-deprecated": true, "x-sunset": "2025-01-01"}
+
+	doc := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+			"/pets":      {PathItemProps: spec.PathItemProps{Get: listPets}},
+			"/pets/{id}": {PathItemProps: spec.PathItemProps{Get: getPet, Delete: spec.NewOperation("deletePet")}},
+		}},
+		Definitions: spec.Definitions{
+			"Pet": *spec.MapProperty(nil).
+				SetProperty("nickname", nickname).
+				SetProperty("addresses", *spec.ArrayProperty(new(spec.Schema).SetProperty("street", street))),
+		},
+	}}
+
+	var lines []string
+	for _, deprecation := range Deprecations(doc) {
+		lines = append(lines, deprecation.String())
+	}
+
+	assert.Equal(t, []string{
+		"definition Pet: property addresses[].street is deprecated (sunset 2025-01-01)",
+		"GET /pets is deprecated, use /v2/pets (sunset 2025-06-01)",
+		"GET /pets/{id} is deprecated",
+		"definition Pet: property nickname is deprecated",
+	}, lines)
+
+	_, err := DeprecationsFile("testdata/missing.json")
+	require.Error(t, err)
+}
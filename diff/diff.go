@@ -0,0 +1,517 @@
+// Package diff reports the breaking changes between two versions of a swagger document.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
+)
+
+// Kind is the kind of a breaking change.
+type Kind string
+
+// Kinds of breaking changes.
+const (
+	RemovedPath       Kind = "removed-path"
+	RemovedOperation  Kind = "removed-operation"
+	RemovedDefinition Kind = "removed-definition"
+	ChangedType       Kind = "changed-type"
+	NewRequired       Kind = "new-required"
+	RemovedEnum       Kind = "removed-enum"
+
+	// RemovedProperty a property of a response which is removed
+	RemovedProperty Kind = "removed-property"
+
+	// NewEnum a value added to the enum of a response, which clients may not handle
+	NewEnum Kind = "new-enum"
+)
+
+// Change is a change of the new document which breaks the clients of the old one.
+type Change struct {
+	Kind Kind
+
+	// Location the path and method of the operation, or the definition, which changed
+	Location string
+
+	Message string
+}
+
+// String returns the change in a single line.
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s (%s)", c.Location, c.Message, c.Kind)
+}
+
+// Files compares the swagger documents of two files, in JSON or YAML.
+func Files(oldFile, newFile string) ([]Change, error) {
+	oldDoc, err := readFile(oldFile)
+	if err != nil {
+		return nil, err
+	}
+
+	newDoc, err := readFile(newFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compare(oldDoc, newDoc), nil
+}
+
+func readFile(name string) (*spec.Swagger, error) {
+	var doc spec.Swagger
+	if err := readYAMLOrJSON(name, &doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// readYAMLOrJSON reads the JSON or YAML file name into v.
+func readYAMLOrJSON(name string, v any) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	// JSON is YAML too
+	b, err = yaml.YAMLToJSON(b)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Compare returns the breaking changes from oldDoc to newDoc, sorted by location and message: removed paths,
+// operations and definitions, parameters and properties whose type changed, and removed enum values and new required
+// parameters and properties of requests, and removed properties and new enum values of responses. Definitions are
+// compared as the requests, the responses or both refer to them in oldDoc.
+func Compare(oldDoc, newDoc *spec.Swagger) []Change {
+	c := comparison{oldDoc: oldDoc, newDoc: newDoc, usages: definitionUsages(oldDoc)}
+
+	c.comparePaths()
+	c.compareDefinitions()
+
+	sort.Slice(c.changes, func(i, j int) bool {
+		if c.changes[i].Location != c.changes[j].Location {
+			return c.changes[i].Location < c.changes[j].Location
+		}
+
+		return c.changes[i].Message < c.changes[j].Message
+	})
+
+	return c.changes
+}
+
+// usage tells whether a schema is sent by requests, returned by responses, or both.
+type usage uint8
+
+const (
+	inRequest usage = 1 << iota
+	inResponse
+)
+
+type comparison struct {
+	oldDoc  *spec.Swagger
+	newDoc  *spec.Swagger
+	usages  map[string]usage
+	changes []Change
+}
+
+func (c *comparison) add(kind Kind, location, format string, args ...any) {
+	c.changes = append(c.changes, Change{Kind: kind, Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+var methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH"}
+
+func operation(item spec.PathItem, method string) *spec.Operation {
+	switch method {
+	case "GET":
+		return item.Get
+	case "PUT":
+		return item.Put
+	case "POST":
+		return item.Post
+	case "DELETE":
+		return item.Delete
+	case "OPTIONS":
+		return item.Options
+	case "HEAD":
+		return item.Head
+	default:
+		return item.Patch
+	}
+}
+
+func paths(doc *spec.Swagger) map[string]spec.PathItem {
+	if doc.Paths == nil {
+		return nil
+	}
+
+	return doc.Paths.Paths
+}
+
+func (c *comparison) comparePaths() {
+	newPaths := paths(c.newDoc)
+
+	for path, oldItem := range paths(c.oldDoc) {
+		newItem, ok := newPaths[path]
+		if !ok {
+			c.add(RemovedPath, path, "path removed")
+
+			continue
+		}
+
+		for _, method := range methods {
+			oldOp := operation(oldItem, method)
+			if oldOp == nil {
+				continue
+			}
+
+			location := method + " " + path
+
+			newOp := operation(newItem, method)
+			if newOp == nil {
+				c.add(RemovedOperation, location, "operation removed")
+
+				continue
+			}
+
+			c.compareParameters(location, oldOp.Parameters, newOp.Parameters)
+			c.compareResponses(location, oldOp.Responses, newOp.Responses)
+		}
+	}
+}
+
+func (c *comparison) compareParameters(location string, oldParams, newParams []spec.Parameter) {
+	old := make(map[string]spec.Parameter, len(oldParams))
+	for _, param := range oldParams {
+		old[param.In+" "+param.Name] = param
+	}
+
+	for _, newParam := range newParams {
+		name := newParam.In + " parameter " + newParam.Name
+
+		oldParam, ok := old[newParam.In+" "+newParam.Name]
+		if !ok {
+			if newParam.Required {
+				c.add(NewRequired, location, "new required %s", name)
+			}
+
+			continue
+		}
+
+		if newParam.Required && !oldParam.Required {
+			c.add(NewRequired, location, "%s is now required", name)
+		}
+
+		if newParam.In == "body" {
+			if oldParam.Schema != nil && newParam.Schema != nil {
+				c.compareSchemas(location, name, oldParam.Schema, newParam.Schema, inRequest)
+			}
+
+			continue
+		}
+
+		oldType, newType := paramType(oldParam.SimpleSchema), paramType(newParam.SimpleSchema)
+		if oldType != newType {
+			c.add(ChangedType, location, "%s changed from %s to %s", name, oldType, newType)
+
+			continue
+		}
+
+		c.compareEnums(location, name, oldParam.Enum, newParam.Enum)
+
+		if oldParam.Items != nil && newParam.Items != nil {
+			c.compareEnums(location, name+" items", oldParam.Items.Enum, newParam.Items.Enum)
+		}
+	}
+}
+
+// compareResponses compares the schemas of the responses of an operation which both documents have.
+func (c *comparison) compareResponses(location string, oldResponses, newResponses *spec.Responses) {
+	if oldResponses == nil || newResponses == nil {
+		return
+	}
+
+	if oldResponses.Default != nil && newResponses.Default != nil {
+		c.compareResponse(location, "default response", oldResponses.Default, newResponses.Default)
+	}
+
+	for code, oldResponse := range oldResponses.StatusCodeResponses {
+		if newResponse, ok := newResponses.StatusCodeResponses[code]; ok {
+			c.compareResponse(location, fmt.Sprintf("response %d", code), &oldResponse, &newResponse)
+		}
+	}
+}
+
+func (c *comparison) compareResponse(location, name string, oldResponse, newResponse *spec.Response) {
+	if oldResponse.Schema != nil && newResponse.Schema != nil {
+		c.compareSchemas(location, name, oldResponse.Schema, newResponse.Schema, inResponse)
+	}
+}
+
+func (c *comparison) compareDefinitions() {
+	for name, oldSchema := range c.oldDoc.Definitions {
+		location := "definition " + name
+
+		newSchema, ok := c.newDoc.Definitions[name]
+		if !ok {
+			c.add(RemovedDefinition, location, "definition removed")
+
+			continue
+		}
+
+		// a definition no operation refers to breaks no client
+		if use := c.usages[name]; use != 0 {
+			c.compareSchemas(location, "", &oldSchema, &newSchema, use)
+		}
+	}
+}
+
+// compareSchemas compares the schemas of a property, or of the definition itself when name is empty, as use tells
+// whether requests send it or responses return it. Referenced definitions are compared by compareDefinitions.
+func (c *comparison) compareSchemas(location, name string, oldSchema, newSchema *spec.Schema, use usage) {
+	subject := "type"
+	if name != "" {
+		subject = name
+	}
+
+	oldType, newType := schemaType(oldSchema), schemaType(newSchema)
+	if oldType != newType {
+		c.add(ChangedType, location, "%s changed from %s to %s", subject, oldType, newType)
+
+		return
+	}
+
+	if use&inRequest != 0 {
+		c.compareEnums(location, subject, oldSchema.Enum, newSchema.Enum)
+	}
+
+	if use&inResponse != 0 {
+		c.compareNewEnums(location, subject, oldSchema.Enum, newSchema.Enum)
+	}
+
+	if oldSchema.Items != nil && oldSchema.Items.Schema != nil && newSchema.Items != nil && newSchema.Items.Schema != nil {
+		c.compareSchemas(location, join(name, "[]"), oldSchema.Items.Schema, newSchema.Items.Schema, use)
+	}
+
+	if oldSchema.Ref.String() != "" {
+		return
+	}
+
+	if use&inRequest != 0 {
+		oldRequired := make(map[string]bool, len(oldSchema.Required))
+		for _, property := range oldSchema.Required {
+			oldRequired[property] = true
+		}
+
+		for _, property := range newSchema.Required {
+			if !oldRequired[property] {
+				c.add(NewRequired, location, "property %s is now required", join(name, property))
+			}
+		}
+	}
+
+	for property, oldProperty := range oldSchema.Properties {
+		newProperty, ok := newSchema.Properties[property]
+		if !ok {
+			if use&inResponse != 0 {
+				c.add(RemovedProperty, location, "property %s removed", join(name, property))
+			}
+
+			continue
+		}
+
+		c.compareSchemas(location, join(name, property), &oldProperty, &newProperty, use)
+	}
+}
+
+func (c *comparison) compareEnums(location, subject string, oldEnum, newEnum []any) {
+	if len(newEnum) == 0 {
+		return
+	}
+
+	values := make(map[string]bool, len(newEnum))
+	for _, value := range newEnum {
+		values[fmt.Sprint(value)] = true
+	}
+
+	for _, value := range oldEnum {
+		if !values[fmt.Sprint(value)] {
+			c.add(RemovedEnum, location, "enum value %v of %s removed", value, subject)
+		}
+	}
+}
+
+// compareNewEnums reports the values added to the enum of a response, clients of the old document may not handle them.
+func (c *comparison) compareNewEnums(location, subject string, oldEnum, newEnum []any) {
+	if len(oldEnum) == 0 {
+		return
+	}
+
+	values := make(map[string]bool, len(oldEnum))
+	for _, value := range oldEnum {
+		values[fmt.Sprint(value)] = true
+	}
+
+	for _, value := range newEnum {
+		if !values[fmt.Sprint(value)] {
+			c.add(NewEnum, location, "enum value %v of %s added", value, subject)
+		}
+	}
+}
+
+// definitionUsages returns whether the requests or the responses of doc refer to each definition, directly or through
+// other definitions.
+func definitionUsages(doc *spec.Swagger) map[string]usage {
+	requests := []any{doc.Parameters}
+	responses := []any{doc.Responses}
+
+	for _, item := range paths(doc) {
+		requests = append(requests, item.Parameters)
+
+		for _, method := range methods {
+			if op := operation(item, method); op != nil {
+				requests = append(requests, op.Parameters)
+				responses = append(responses, op.Responses)
+			}
+		}
+	}
+
+	usages := make(map[string]usage)
+
+	for name := range referencedDefinitions(doc, requests) {
+		usages[name] |= inRequest
+	}
+
+	for name := range referencedDefinitions(doc, responses) {
+		usages[name] |= inResponse
+	}
+
+	return usages
+}
+
+// referencedDefinitions returns the definitions of doc which roots refer to, directly or through other definitions.
+func referencedDefinitions(doc *spec.Swagger, roots any) map[string]bool {
+	referenced := make(map[string]bool)
+
+	queue := definitionRefs(roots)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		if referenced[name] {
+			continue
+		}
+
+		referenced[name] = true
+
+		if schema, ok := doc.Definitions[name]; ok {
+			queue = append(queue, definitionRefs(schema)...)
+		}
+	}
+
+	return referenced
+}
+
+// definitionRefs returns the names of the definitions v refers to.
+func definitionRefs(v any) []string {
+	// the values of a document read from JSON are always marshaled
+	b, _ := json.Marshal(v)
+
+	var value any
+	_ = json.Unmarshal(b, &value)
+
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+
+	var names []string
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for key, child := range value {
+				if ref, ok := child.(string); ok && key == "$ref" && strings.HasPrefix(ref, "#/definitions/") {
+					names = append(names, unescape.Replace(strings.TrimPrefix(ref, "#/definitions/")))
+
+					continue
+				}
+
+				walk(child)
+			}
+		case []any:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+
+	walk(value)
+
+	return names
+}
+
+func join(name, property string) string {
+	if name == "" {
+		return property
+	}
+
+	if property == "[]" {
+		return name + property
+	}
+
+	return name + "." + property
+}
+
+func paramType(schema spec.SimpleSchema) string {
+	typ := schema.Type
+	if schema.Format != "" {
+		typ += "(" + schema.Format + ")"
+	}
+
+	if schema.Items != nil {
+		typ += " of " + paramType(schema.Items.SimpleSchema)
+	}
+
+	return typ
+}
+
+// schemaType describes the type of a schema, like object, array of string or the name of a definition.
+func schemaType(schema *spec.Schema) string {
+	if ref := schema.Ref.String(); ref != "" {
+		return strings.TrimPrefix(ref, "#/definitions/")
+	}
+
+	if len(schema.AllOf) > 0 {
+		// swag wraps the definition of a property with a description in allOf
+		for i := range schema.AllOf {
+			if schema.AllOf[i].Ref.String() != "" {
+				return schemaType(&schema.AllOf[i])
+			}
+		}
+	}
+
+	typ := strings.Join(schema.Type, ",")
+	if typ == "" && len(schema.Properties) > 0 {
+		typ = "object"
+	}
+
+	if schema.Format != "" {
+		typ += "(" + schema.Format + ")"
+	}
+
+	if schema.Items != nil && schema.Items.Schema != nil {
+		typ += " of " + schemaType(schema.Items.Schema)
+	}
+
+	return typ
+}
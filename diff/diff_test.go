@@ -0,0 +1,98 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiles(t *testing.T) {
+	changes, err := Files("testdata/old.json", "testdata/new.yaml")
+	require.NoError(t, err)
+
+	var lines []string
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+
+	assert.Equal(t, []string{
+		"/stores: path removed (removed-path)",
+		"DELETE /pets/{id}: operation removed (removed-operation)",
+		"GET /pets: enum value sold of query parameter status removed (removed-enum)",
+		"GET /pets: new required header parameter tenant (new-required)",
+		"GET /pets: query parameter limit changed from integer to string (changed-type)",
+		"definition Pet: age changed from integer to integer(int64) (changed-type)",
+		"definition Pet: enum value cat of kind removed (removed-enum)",
+		"definition Pet: property age is now required (new-required)",
+		"definition Pet: property owner.email is now required (new-required)",
+	}, lines)
+
+	_, err = Files("testdata/missing.json", "testdata/new.yaml")
+	assert.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	doc := &spec.Swagger{}
+	assert.Empty(t, Compare(doc, doc))
+
+	oldDoc, err := readFile("testdata/old.json")
+	require.NoError(t, err)
+	assert.Empty(t, Compare(oldDoc, oldDoc))
+}
+
+func TestCompareResponses(t *testing.T) {
+	read := func(doc string) *spec.Swagger {
+		var swagger spec.Swagger
+		require.NoError(t, swagger.UnmarshalJSON([]byte(doc)))
+
+		return &swagger
+	}
+
+	oldDoc := read(`{
+	"paths": {
+		"/pets": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/Pet"}}}}},
+		"/stores": {"get": {"responses": {"200": {"schema": {"type": "array", "items": {"type": "string"}}}}}}
+	},
+	"definitions": {
+		"Pet": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "string"},
+				"age": {"type": "integer"},
+				"status": {"type": "string", "enum": ["available"]}
+			}
+		},
+		"Order": {"type": "object"}
+	}
+}`)
+	newDoc := read(`{
+	"paths": {
+		"/pets": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/Pet"}}}}},
+		"/stores": {"get": {"responses": {"200": {"schema": {"type": "object"}}}}}
+	},
+	"definitions": {
+		"Pet": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"type": "string"},
+				"status": {"type": "string", "enum": ["available", "sold"]}
+			}
+		}
+	}
+}`)
+
+	var lines []string
+	for _, change := range Compare(oldDoc, newDoc) {
+		lines = append(lines, change.String())
+	}
+
+	assert.Equal(t, []string{
+		"GET /stores: response 200 changed from array of string to object (changed-type)",
+		"definition Order: definition removed (removed-definition)",
+		"definition Pet: enum value sold of status added (new-enum)",
+		"definition Pet: property age removed (removed-property)",
+	}, lines)
+}
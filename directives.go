@@ -0,0 +1,127 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// routeDirective is the prefix of the single line comments which route an operation defined elsewhere:
+//
+//	//swag:route GET /users users listUsers
+//
+// declares the operation listUsers at GET /users with the tag users, comma separated, or - for none.
+const routeDirective = "//swag:route"
+
+// routeDirectiveRef is a //swag:route comment waiting for the operations to be defined.
+type routeDirectiveRef struct {
+	method      string
+	path        string
+	tags        []string
+	operationID string
+	pos         token.Pos
+	fileInfo    *AstFileInfo
+}
+
+// SetOperationDefinitions sets the operations which //swag:route comments refer to by ID, besides the
+// annotations with an @ID and without @Router.
+func SetOperationDefinitions(operations map[string]spec.Operation) func(*Parser) {
+	return func(p *Parser) {
+		if p.operationDefinitions == nil {
+			p.operationDefinitions = make(map[string]*spec.Operation, len(operations))
+		}
+
+		for id, operation := range operations {
+			operation.ID = id
+			p.operationDefinitions[id] = &operation
+		}
+	}
+}
+
+// parseRouteDirectives collects the //swag:route comments of a file, they are resolved by resolveRouteDirectives.
+func (parser *Parser) parseRouteDirectives(fileInfo *AstFileInfo) error {
+	for _, group := range fileInfo.File.Comments {
+		for _, comment := range group.List {
+			args, ok := strings.CutPrefix(comment.Text, routeDirective)
+			if !ok || args != "" && args[0] != ' ' && args[0] != '\t' {
+				continue
+			}
+
+			fields := strings.Fields(args)
+			if len(fields) != 4 {
+				return fmt.Errorf("%s in file %s: expected a method, a path, tags and an operation ID, got '%s'",
+					routeDirective, fileInfo.Path, strings.TrimSpace(args))
+			}
+
+			directive := routeDirectiveRef{
+				method:      strings.ToUpper(fields[0]),
+				path:        fields[1],
+				operationID: fields[3],
+				pos:         comment.Pos(),
+				fileInfo:    fileInfo,
+			}
+
+			if refRouteMethodOp(&spec.PathItem{}, directive.method) == nil {
+				return fmt.Errorf("%s in file %s: unsupported method %s", routeDirective, fileInfo.Path, fields[0])
+			}
+
+			if fields[2] != "-" {
+				directive.tags = strings.Split(fields[2], ",")
+			}
+
+			parser.routeDirectives = append(parser.routeDirectives, directive)
+		}
+	}
+
+	return nil
+}
+
+// addOperationDefinition keeps an operation with an ID and without route for the //swag:route comments.
+func (parser *Parser) addOperationDefinition(operation *Operation) {
+	if parser.operationDefinitions == nil {
+		parser.operationDefinitions = make(map[string]*spec.Operation)
+	}
+
+	parser.operationDefinitions[operation.ID] = &operation.Operation
+}
+
+// resolveRouteDirectives adds the operations routed by //swag:route comments to the document.
+func (parser *Parser) resolveRouteDirectives() error {
+	for _, directive := range parser.routeDirectives {
+		definition, ok := parser.operationDefinitions[directive.operationID]
+		if !ok {
+			return fmt.Errorf("%s in file %s: unknown operation %s, define it with @ID and without @Router",
+				routeDirective, directive.fileInfo.Path, directive.operationID)
+		}
+
+		operation := NewOperation(parser)
+
+		// the same definition may be routed several times
+		b, err := json.Marshal(definition)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(b, &operation.Operation); err != nil {
+			return err
+		}
+
+		operation.ID = directive.operationID
+		if directive.tags != nil {
+			operation.Tags = directive.tags
+		}
+
+		operation.RouterProperties = []RouteProperties{{HTTPMethod: directive.method, Path: directive.path}}
+
+		if err := processRouterOperation(parser, operation); err != nil {
+			return err
+		}
+
+		parser.addOperationSource(operation, directive.pos, directive.fileInfo)
+	}
+
+	return nil
+}
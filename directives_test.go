@@ -0,0 +1,94 @@
+package swag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_RouteDirectives(t *testing.T) {
+	t.Parallel()
+
+	handlers := `
+package api
+
+type User struct {
+	Name string
+}
+
+// ListUsers lists the users.
+// @Summary List users
+// @ID      listUsers
+// @Success 200 {array} User
+func ListUsers(){
+}
+`
+	wrappers := `
+// Code generated by wrapgen. DO NOT EDIT.
+
+package api
+
+//swag:route GET /users users listUsers
+func listUsersWrapper(){
+}
+
+//swag:route GET /v2/users - listUsers
+//swag:route DELETE /users/{id} users,admin deleteUser
+`
+	p := New(SetOperationDefinitions(map[string]spec.Operation{
+		"deleteUser": {OperationProps: spec.OperationProps{Summary: "Delete a user", Tags: []string{"old"}}},
+	}))
+
+	require.NoError(t, p.packages.ParseFile("api", "api/handlers.go", handlers, ParseAll))
+	require.NoError(t, p.packages.ParseFile("api", "api/wrappers.go", wrappers, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+	require.NoError(t, p.resolveRouteDirectives())
+
+	paths := p.swagger.Paths.Paths
+	require.Len(t, paths, 3)
+
+	get := paths["/users"].Get
+	require.NotNil(t, get)
+	assert.Equal(t, "listUsers", get.ID)
+	assert.Equal(t, "List users", get.Summary)
+	assert.Equal(t, []string{"users"}, get.Tags)
+	assert.Equal(t, "#/definitions/api.User", get.Responses.StatusCodeResponses[200].Schema.Items.Schema.Ref.String())
+	assert.True(t, strings.HasSuffix(p.Source("/paths/~1users/get"), "api/wrappers.go:6"))
+
+	assert.Empty(t, paths["/v2/users"].Get.Tags)
+	assert.Equal(t, "List users", paths["/v2/users"].Get.Summary)
+
+	del := paths["/users/{id}"].Delete
+	require.NotNil(t, del)
+	assert.Equal(t, "deleteUser", del.ID)
+	assert.Equal(t, "Delete a user", del.Summary)
+	assert.Equal(t, []string{"users", "admin"}, del.Tags)
+}
+
+func TestParser_RouteDirectivesErr(t *testing.T) {
+	t.Parallel()
+
+	for src, expected := range map[string]string{
+		"package api\n\n//swag:route GET /users\n":               "api/api.go: expected a method, a path, tags and an operation ID, got 'GET /users'",
+		"package api\n\n//swag:route FETCH /users users list\n":  "api/api.go: unsupported method FETCH",
+		"package api\n\n//swag:route GET /users users unknown\n": "api/api.go: unknown operation unknown, define it with @ID and without @Router",
+	} {
+		p := New()
+		require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+		err := p.packages.RangeFiles(p.ParseRouterAPIInfo)
+		if err == nil {
+			err = p.resolveRouteDirectives()
+		}
+
+		require.Error(t, err)
+		assert.True(t, strings.HasSuffix(err.Error(), expected), err.Error())
+	}
+}
@@ -0,0 +1,137 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	// CookieParametersExtension keeps cookie parameters, which Swagger 2.0 can not express.
+	CookieParametersExtension = "x-cookie-parameters"
+
+	// RequestBodyContentExtension keeps request body schemas per content type which differ
+	// from the schema of the body parameter.
+	RequestBodyContentExtension = "x-request-body-content"
+
+	// APIKeyInExtension keeps the original location of an api key downgraded to a header.
+	APIKeyInExtension = "x-in"
+
+	// APIKeyNameExtension keeps the original name of an api key downgraded to a header.
+	APIKeyNameExtension = "x-name"
+
+	// BearerSchemeExtension marks an api key in the Authorization header as a bearer token.
+	BearerSchemeExtension = "x-scheme"
+
+	// BearerFormatExtension keeps the format of a bearer token, e.g. JWT.
+	BearerFormatExtension = "x-bearer-format"
+
+	// JWTClaimsExtension documents the claims of a JWT bearer token.
+	JWTClaimsExtension = "x-jwt-claims"
+)
+
+// requestBodyPattern matches [mime types] {type} DataType [required] ["comment"].
+var requestBodyPattern = regexp.MustCompile(`^(?:([\w\-./+,]+)\s+)?\{(\w+)}\s+([^\s"]+)(?:\s+(true|false|required|optional))?(?:\s+"([^"]*)")?\s*$`)
+
+// ParseRequestBodyComment parses comment for given `requestBody` comment string, the OpenAPI 3 way
+// of describing a request body with its content types.
+// E.g. @RequestBody json,xml {object} model.Pet true "Pet to add".
+//
+// Swagger 2.0 allows a single body schema, so the first request body becomes the body parameter
+// and other schemas are kept in the x-request-body-content extension.
+func (operation *Operation) ParseRequestBodyComment(commentLine string, astFile *ast.File) error {
+	matches := requestBodyPattern.FindStringSubmatch(strings.TrimSpace(commentLine))
+	if matches == nil {
+		return fmt.Errorf("can not parse request body comment \"%s\"", commentLine)
+	}
+
+	var mimeTypes []string
+
+	if matches[1] != "" {
+		err := parseMimeTypeList(matches[1], &mimeTypes, "%v request body type can't be accepted")
+		if err != nil {
+			return err
+		}
+	}
+
+	schema, err := operation.parseAPIObjectSchema(commentLine, matches[2], matches[3], astFile)
+	if err != nil {
+		return err
+	}
+
+	required := matches[4] == "true" || matches[4] == requiredLabel
+
+	for i := range operation.Parameters {
+		body := &operation.Parameters[i]
+		if body.In != "body" {
+			continue
+		}
+
+		if reflect.DeepEqual(body.Schema, schema) {
+			operation.addConsumes(mimeTypes)
+
+			return nil
+		}
+
+		if len(mimeTypes) == 0 {
+			return fmt.Errorf("request body \"%s\" needs a content type as the operation already has a body", commentLine)
+		}
+
+		content, _ := operation.Extensions[RequestBodyContentExtension].(map[string]*spec.Schema)
+		if content == nil {
+			content = make(map[string]*spec.Schema)
+		}
+
+		for _, mimeType := range mimeTypes {
+			content[mimeType] = schema
+		}
+
+		operation.AddExtension(RequestBodyContentExtension, content)
+		operation.parser.debug.Printf("warning: Swagger 2.0 allows a single request body schema, %s of %s is kept in %s",
+			matches[3], strings.Join(mimeTypes, ","), RequestBodyContentExtension)
+
+		return nil
+	}
+
+	param := createParameter("body", matches[5], "body", matches[2], matches[3], "", required, nil, "")
+	param.Schema = schema
+
+	operation.Parameters = append(operation.Parameters, param)
+	operation.addConsumes(mimeTypes)
+
+	return nil
+}
+
+// addConsumes adds mime types which are not consumed yet.
+func (operation *Operation) addConsumes(mimeTypes []string) {
+	for _, mimeType := range mimeTypes {
+		if !findInSlice(operation.Consumes, mimeType) {
+			operation.Consumes = append(operation.Consumes, mimeType)
+		}
+	}
+}
+
+// downgradeCookieParameter keeps a cookie parameter in the x-cookie-parameters extension,
+// since Swagger 2.0 has no cookie parameters.
+func (operation *Operation) downgradeCookieParameter(param spec.Parameter) {
+	params, _ := operation.Extensions[CookieParametersExtension].([]spec.Parameter)
+	operation.AddExtension(CookieParametersExtension, append(params, param))
+
+	operation.parser.debug.Printf("warning: Swagger 2.0 has no cookie parameters, %s is kept in %s",
+		param.Name, CookieParametersExtension)
+}
+
+// downgradeCookieAPIKey turns an api key sent as cookie into the Cookie header,
+// the original location and name are kept in the x-in and x-name extensions.
+func (parser *Parser) downgradeCookieAPIKey(name string, scheme *spec.SecurityScheme) {
+	scheme.AddExtension(APIKeyInExtension, scheme.In)
+	scheme.AddExtension(APIKeyNameExtension, scheme.Name)
+
+	scheme.In, scheme.Name = "header", "Cookie"
+
+	parser.debug.Printf("warning: Swagger 2.0 has no api keys in cookies, %s is sent in the Cookie header", name)
+}
@@ -0,0 +1,103 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequestBodyComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	operation.parser.addTestType("model.Pet")
+	operation.parser.addTestType("model.Upload")
+
+	assert.NoError(t, operation.ParseComment(`@RequestBody json,xml {object} model.Pet true "Pet to add"`, nil))
+	assert.NoError(t, operation.ParseComment(`@RequestBody json-api {object} model.Pet true "Pet to add"`, nil))
+	assert.NoError(t, operation.ParseComment(`@RequestBody octet-stream {object} model.Upload`, nil))
+
+	assert.Equal(t, []string{"application/json", "text/xml", "application/vnd.api+json"}, operation.Consumes)
+
+	b, _ := json.MarshalIndent(operation.Parameters, "", "    ")
+	expected := `[
+    {
+        "description": "Pet to add",
+        "name": "body",
+        "in": "body",
+        "required": true,
+        "schema": {
+            "$ref": "#/definitions/model.Pet"
+        }
+    }
+]`
+	assert.Equal(t, expected, string(b))
+
+	b, _ = json.MarshalIndent(operation.Extensions, "", "    ")
+	expected = `{
+    "x-request-body-content": {
+        "application/octet-stream": {
+            "$ref": "#/definitions/model.Upload"
+        }
+    }
+}`
+	assert.Equal(t, expected, string(b))
+
+	assert.Error(t, operation.ParseComment(`@RequestBody {string} string`, nil))
+	assert.Error(t, operation.ParseComment(`@RequestBody unknown {object} model.Pet`, nil))
+	assert.Error(t, operation.ParseComment(`@RequestBody json model.Pet`, nil))
+}
+
+func TestParseParamCommentByCookie(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	assert.NoError(t, operation.ParseComment(`@Param session cookie string true "Session ID"`, nil))
+	assert.Empty(t, operation.Parameters)
+
+	b, _ := json.MarshalIndent(operation.Extensions, "", "    ")
+	expected := `{
+    "x-cookie-parameters": [
+        {
+            "type": "string",
+            "description": "Session ID",
+            "name": "session",
+            "in": "cookie",
+            "required": true
+        }
+    ]
+}`
+	assert.Equal(t, expected, string(b))
+}
+
+func TestParser_ParseGeneralAPISecurityCookie(t *testing.T) {
+	t.Parallel()
+
+	parser := New()
+	err := parseGeneralAPIInfo(parser, []string{
+		"@securitydefinitions.apikey CookieAuth",
+		"@in cookie",
+		"@name session",
+	})
+	assert.NoError(t, err)
+
+	b, _ := json.MarshalIndent(parser.GetSwagger().SecurityDefinitions, "", "    ")
+	expected := `{
+    "CookieAuth": {
+        "type": "apiKey",
+        "name": "Cookie",
+        "in": "header",
+        "x-in": "cookie",
+        "x-name": "session"
+    }
+}`
+	assert.Equal(t, expected, string(b))
+
+	assert.Error(t, parseGeneralAPIInfo(parser, []string{
+		"@securitydefinitions.apikey ApiKey",
+		"@in body",
+		"@name session",
+	}))
+}
@@ -0,0 +1,333 @@
+package swag
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// VisibilityExtension is the operation extension filtered by FilterOptions.Visibility, e.g. @x-visibility "internal".
+const VisibilityExtension = "x-visibility"
+
+// defaultVisibility is the visibility of operations without VisibilityExtension.
+const defaultVisibility = "public"
+
+// FilterOptions selects the operations of a document produced by Emit. Empty options select all of them.
+type FilterOptions struct {
+	// Tags comma separated tags of the operations to keep, a tag prefixed with ! excludes its operations, like SetTags
+	Tags string
+
+	// Extension keeps only the operations which have the x-<Extension> extension, like SetParseExtension
+	Extension string
+
+	// Visibility keeps only the operations whose x-visibility extension is one of these values,
+	// operations without the extension are public
+	Visibility []string
+}
+
+// ParseOnce parses the sources the first time it is called and returns the same result afterwards,
+// so that a single parse can be shared by several Emit calls.
+func (parser *Parser) ParseOnce(ctx context.Context, searchDirs []string, mainAPIFile string, parseDepth int) error {
+	parser.parseOnce.Do(func() {
+		parser.parseOnceErr = parser.ParseAPIMultiSearchDirContext(ctx, searchDirs, mainAPIFile, parseDepth)
+	})
+
+	return parser.parseOnceErr
+}
+
+// Emit returns a copy of the parsed document with the operations selected by filter. Definitions which
+// are not referenced anymore and tags without operations are removed. The parsed document is left unchanged.
+func (parser *Parser) Emit(filter FilterOptions) (*spec.Swagger, error) {
+	return FilterDocument(parser.swagger, filter)
+}
+
+// FilterDocument returns a copy of swagger with the operations selected by filter, like Emit.
+func FilterDocument(swagger *spec.Swagger, filter FilterOptions) (*spec.Swagger, error) {
+	b, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc spec.Swagger
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	if filter.Tags == "" && filter.Extension == "" && len(filter.Visibility) == 0 {
+		return &doc, nil
+	}
+
+	tags := parseTagFilter(filter.Tags)
+	usedTags := make(map[string]bool)
+
+	if doc.Paths != nil {
+		for path, item := range doc.Paths.Paths {
+			empty := true
+
+			for _, method := range sortedMethods() {
+				op := refRouteMethodOp(&item, method)
+				if *op == nil {
+					continue
+				}
+
+				if !matchOperation(*op, tags, filter) {
+					*op = nil
+
+					continue
+				}
+
+				empty = false
+
+				for _, tag := range (*op).Tags {
+					usedTags[tag] = true
+				}
+			}
+
+			if empty {
+				delete(doc.Paths.Paths, path)
+			} else {
+				doc.Paths.Paths[path] = item
+			}
+		}
+	}
+
+	if len(doc.Tags) > 0 {
+		kept := doc.Tags[:0]
+
+		for _, tag := range doc.Tags {
+			if usedTags[tag.Name] {
+				kept = append(kept, tag)
+			}
+		}
+
+		doc.Tags = kept
+	}
+
+	if err := pruneDefinitions(&doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// SplitByTag returns a copy of swagger for each tag of its operations, with the operations of the tag, like
+// FilterDocument with the tag but in a single pass over the operations. The documents share the operations and
+// schemas of the copy, which must not be changed.
+func SplitByTag(swagger *spec.Swagger) (map[string]*spec.Swagger, error) {
+	source, err := FilterDocument(swagger, FilterOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]map[string]spec.PathItem)
+
+	if source.Paths != nil {
+		for path, item := range source.Paths.Paths {
+			for _, method := range sortedMethods() {
+				op := *refRouteMethodOp(&item, method)
+				if op == nil {
+					continue
+				}
+
+				for _, tag := range op.Tags {
+					if paths[tag] == nil {
+						paths[tag] = make(map[string]spec.PathItem)
+					}
+
+					tagItem, ok := paths[tag][path]
+					if !ok {
+						// the parameters and extensions of the path item, without its operations
+						tagItem = item
+						for _, method := range sortedMethods() {
+							*refRouteMethodOp(&tagItem, method) = nil
+						}
+					}
+
+					*refRouteMethodOp(&tagItem, method) = op
+					paths[tag][path] = tagItem
+				}
+			}
+		}
+	}
+
+	docs := make(map[string]*spec.Swagger, len(paths))
+
+	for tag, tagPaths := range paths {
+		doc := *source
+		doc.Paths = &spec.Paths{VendorExtensible: source.Paths.VendorExtensible, Paths: tagPaths}
+		doc.Definitions = maps.Clone(source.Definitions)
+		doc.Tags = nil
+
+		for _, sourceTag := range source.Tags {
+			if sourceTag.Name == tag {
+				doc.Tags = append(doc.Tags, sourceTag)
+			}
+		}
+
+		if err := pruneDefinitions(&doc); err != nil {
+			return nil, err
+		}
+
+		docs[tag] = &doc
+	}
+
+	return docs, nil
+}
+
+// matchOperation reports whether filter selects op, tags is the parsed filter.Tags.
+func matchOperation(op *spec.Operation, tags map[string]struct{}, filter FilterOptions) bool {
+	if !matchTagFilter(tags, op.Tags) {
+		return false
+	}
+
+	if filter.Extension != "" {
+		if _, ok := op.Extensions[strings.ToLower("x-"+filter.Extension)]; !ok {
+			return false
+		}
+	}
+
+	if len(filter.Visibility) > 0 {
+		visibility := defaultVisibility
+		if value, ok := op.Extensions.GetString(VisibilityExtension); ok {
+			visibility = value
+		}
+
+		for _, allowed := range filter.Visibility {
+			if strings.EqualFold(allowed, visibility) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// parseTagFilter parses comma separated tags like SetTags.
+func parseTagFilter(include string) map[string]struct{} {
+	tags := make(map[string]struct{})
+
+	for _, tag := range strings.Split(include, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags[tag] = struct{}{}
+		}
+	}
+
+	return tags
+}
+
+// pruneDefinitions removes the definitions which are not referenced, directly or through other definitions,
+// by the paths, parameters and responses of doc.
+func pruneDefinitions(doc *spec.Swagger) error {
+	unused, err := unusedDefinitions(doc)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range unused {
+		delete(doc.Definitions, name)
+	}
+
+	return nil
+}
+
+// unusedDefinitions returns the sorted names of the definitions which are not referenced, directly or through other
+// definitions, by the paths, parameters and responses of doc.
+func unusedDefinitions(doc *spec.Swagger) ([]string, error) {
+	if len(doc.Definitions) == 0 {
+		return nil, nil
+	}
+
+	used, err := referencedDefinitions(doc, []any{doc.Paths, doc.Parameters, doc.Responses})
+	if err != nil {
+		return nil, err
+	}
+
+	var unused []string
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+
+	return unused, nil
+}
+
+// referencedDefinitions returns the names of the definitions referenced by roots, directly or through other
+// definitions of doc.
+func referencedDefinitions(doc *spec.Swagger, roots any) (map[string]bool, error) {
+	b, err := json.Marshal(roots)
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	queue := collectDefinitionRefs(b)
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		if used[name] {
+			continue
+		}
+
+		used[name] = true
+
+		schema, ok := doc.Definitions[name]
+		if !ok {
+			continue
+		}
+
+		b, err := json.Marshal(schema)
+		if err != nil {
+			return nil, err
+		}
+
+		queue = append(queue, collectDefinitionRefs(b)...)
+	}
+
+	return used, nil
+}
+
+// collectDefinitionRefs returns the names of the definitions referenced by a JSON document.
+func collectDefinitionRefs(document []byte) []string {
+	var value any
+	if json.Unmarshal(document, &value) != nil {
+		return nil
+	}
+
+	var names []string
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for key, child := range value {
+				if ref, ok := child.(string); ok && key == "$ref" && strings.HasPrefix(ref, "#/definitions/") {
+					names = append(names, unescapePointer(strings.TrimPrefix(ref, "#/definitions/")))
+				}
+
+				walk(child)
+			}
+		case []any:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+
+	walk(value)
+
+	return names
+}
+
+func unescapePointer(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
@@ -0,0 +1,108 @@
+package swag
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Emit(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	require.NoError(t, p.ParseOnce(context.Background(), []string{"testdata/emit"}, mainAPIFile, defaultParseDepth))
+
+	parsed, err := json.Marshal(p.swagger)
+	require.NoError(t, err)
+
+	all, err := p.Emit(FilterOptions{})
+	require.NoError(t, err)
+	assert.Len(t, all.Paths.Paths, 2)
+	assert.Len(t, all.Definitions, 3)
+	assert.Len(t, all.Tags, 2)
+
+	pets, err := p.Emit(FilterOptions{Tags: "pets"})
+	require.NoError(t, err)
+	assert.NotNil(t, pets.Paths.Paths["/pets"].Get)
+	assert.NotNil(t, pets.Paths.Paths["/pets"].Post)
+	assert.NotContains(t, pets.Paths.Paths, "/admin/audit")
+	assert.Contains(t, pets.Definitions, "main.Pet")
+	assert.Contains(t, pets.Definitions, "main.Owner")
+	assert.NotContains(t, pets.Definitions, "main.Audit")
+	require.Len(t, pets.Tags, 1)
+	assert.Equal(t, "pets", pets.Tags[0].Name)
+
+	notAdmin, err := p.Emit(FilterOptions{Tags: "!admin"})
+	require.NoError(t, err)
+	assert.Equal(t, pets.Paths, notAdmin.Paths)
+
+	audited, err := p.Emit(FilterOptions{Extension: "audit"})
+	require.NoError(t, err)
+	assert.Len(t, audited.Paths.Paths, 1)
+	assert.Contains(t, audited.Paths.Paths, "/admin/audit")
+
+	public, err := p.Emit(FilterOptions{Visibility: []string{"public"}})
+	require.NoError(t, err)
+	assert.NotNil(t, public.Paths.Paths["/pets"].Get)
+	assert.Nil(t, public.Paths.Paths["/pets"].Post)
+	assert.NotContains(t, public.Paths.Paths, "/admin/audit")
+
+	partners, err := p.Emit(FilterOptions{Visibility: []string{"public", "Partner"}})
+	require.NoError(t, err)
+	assert.NotNil(t, partners.Paths.Paths["/pets"].Post)
+	assert.NotContains(t, partners.Definitions, "main.Audit")
+
+	unchanged, err := json.Marshal(p.swagger)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(parsed), string(unchanged))
+}
+
+func TestSplitByTag(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	require.NoError(t, p.ParseOnce(context.Background(), []string{"testdata/emit"}, mainAPIFile, defaultParseDepth))
+
+	docs, err := SplitByTag(p.swagger)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	for tag, doc := range docs {
+		filtered, err := FilterDocument(p.swagger, FilterOptions{Tags: tag})
+		require.NoError(t, err)
+
+		expected, err := json.Marshal(filtered)
+		require.NoError(t, err)
+
+		actual, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, string(expected), string(actual), tag)
+	}
+}
+
+func TestParser_ParseOnce(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	require.NoError(t, p.ParseOnce(context.Background(), []string{"testdata/emit"}, mainAPIFile, defaultParseDepth))
+
+	// the second call does not parse again, which would report the routes as declared multiple times
+	p.Strict = true
+	assert.NoError(t, p.ParseOnce(context.Background(), []string{"testdata/emit"}, mainAPIFile, defaultParseDepth))
+
+	p = New()
+	err := p.ParseOnce(context.Background(), []string{"testdata/missing"}, mainAPIFile, defaultParseDepth)
+	assert.Error(t, err)
+	assert.Equal(t, err, p.ParseOnce(context.Background(), []string{"testdata/emit"}, mainAPIFile, defaultParseDepth))
+}
+
+func TestCollectDefinitionRefs(t *testing.T) {
+	t.Parallel()
+
+	refs := collectDefinitionRefs([]byte(`{"a": {"$ref": "#/definitions/web.Pet"}, "b": [{"$ref": "#/definitions/a~1b"}], "c": {"$ref": "#/parameters/x"}}`))
+	assert.ElementsMatch(t, []string{"web.Pet", "a/b"}, refs)
+}
@@ -0,0 +1,81 @@
+package swag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Extensions of the encoding of the parts of multipart forms, which Swagger 2.0 can not express, the encoding object
+// of the multipart/form-data request body in OpenAPI 3.0.
+const (
+	// EncodingContentTypeExtension the content types of a part, e.g. image/png, of the mime struct tag or the
+	// mime(...) attribute of a formData parameter.
+	EncodingContentTypeExtension = "x-encoding-content-type"
+
+	// EncodingHeadersExtension the names of the headers of a part, of the mimeHeaders struct tag.
+	EncodingHeadersExtension = "x-encoding-headers"
+)
+
+// Struct tags of the encoding of the part of a field in a multipart form.
+const (
+	mimeTag        = "mime"
+	mimeHeadersTag = "mimeHeaders"
+)
+
+// encodingContentType returns the content types of a part, separated by commas, e.g. png,image/jpeg, joined like
+// the contentType of an encoding object.
+func encodingContentType(mimeTypes string) (string, error) {
+	var contentTypes []string
+
+	err := parseMimeTypeList(strings.ReplaceAll(mimeTypes, " ", ""), &contentTypes, "%v is no content type of a part")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(contentTypes, ", "), nil
+}
+
+// complementEncoding adds the extensions of the mime and mimeHeaders tags, the encoding of the part of the field
+// when its struct is a multipart form.
+func (ps *tagBaseFieldParser) complementEncoding(schema *spec.Schema) error {
+	if mimeTypes := strings.TrimSpace(ps.tag.Get(mimeTag)); mimeTypes != "" {
+		contentType, err := encodingContentType(mimeTypes)
+		if err != nil {
+			return err
+		}
+
+		schema.AddExtension(EncodingContentTypeExtension, contentType)
+	}
+
+	if headers := strings.TrimSpace(ps.tag.Get(mimeHeadersTag)); headers != "" {
+		var names []string
+
+		for _, name := range strings.Split(headers, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+
+		schema.AddExtension(EncodingHeadersExtension, names)
+	}
+
+	return nil
+}
+
+// setEncodingParam sets the content types of the part of a formData parameter, of its mime(...) attribute.
+func setEncodingParam(param *spec.Parameter, paramType, mimeTypes string) error {
+	if paramType != "formData" {
+		return fmt.Errorf("%s(%s) only applies to formData parameters, got %s", mimeTag, mimeTypes, paramType)
+	}
+
+	contentType, err := encodingContentType(mimeTypes)
+	if err != nil {
+		return err
+	}
+
+	param.AddExtension(EncodingContentTypeExtension, contentType)
+
+	return nil
+}
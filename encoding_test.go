@@ -0,0 +1,60 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_EncodingTags(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Upload struct {
+	Name     string ` + "`form:\"name\"`" + `
+	Metadata string ` + "`form:\"metadata\" mime:\"json\" mimeHeaders:\"X-Checksum, X-Trace-Id\"`" + `
+}
+
+// @Param   upload formData Upload true "the upload"
+// @Param   photo  formData file   true "the photo" mime(png,image/jpeg)
+// @Success 204
+// @Router  /uploads [post]
+func PostUpload(){
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "testdata/encoding/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	params := make(map[string]spec.Parameter)
+	for _, param := range p.swagger.Paths.Paths["/uploads"].Post.Parameters {
+		params[param.Name] = param
+	}
+
+	assert.NotContains(t, params["name"].Extensions, EncodingContentTypeExtension)
+	assert.Equal(t, "application/json", params["metadata"].Extensions[EncodingContentTypeExtension])
+	assert.Equal(t, []string{"X-Checksum", "X-Trace-Id"}, params["metadata"].Extensions[EncodingHeadersExtension])
+	assert.Equal(t, "image/png, image/jpeg", params["photo"].Extensions[EncodingContentTypeExtension])
+}
+
+func TestParseParamCommentMime_errors(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	assert.ErrorContains(t, operation.ParseComment(`@Param photo query string true "photo" mime(png)`, nil),
+		"mime(png) only applies to formData parameters, got query")
+	assert.ErrorContains(t, operation.ParseComment(`@Param photo formData file true "photo" mime(picture)`, nil),
+		"picture is no content type of a part")
+
+	require.NoError(t, operation.ParseComment(`@Param photo formData file true "photo" mime(png) extensions(x-a=b)`, nil))
+	assert.Equal(t, spec.Extensions{"x-a": "b", EncodingContentTypeExtension: "image/png"}, operation.Parameters[0].Extensions)
+}
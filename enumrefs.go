@@ -0,0 +1,108 @@
+package swag
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-openapi/spec"
+)
+
+// liftEnums turns the inline enums of the document, e.g. of the enums struct tag, into definitions named after
+// where they are, e.g. main.PetStatus for the status property of main.Pet, which the fields refer to. Identical
+// enums share a definition, so that client generators produce a single type for them.
+func (parser *Parser) liftEnums() {
+	doc := parser.swagger
+	if doc.Definitions == nil {
+		doc.Definitions = make(spec.Definitions)
+	}
+
+	lifted := make(map[string]string)
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		definition := doc.Definitions[name]
+		liftChildEnums(doc, &definition, name, lifted)
+		doc.Definitions[name] = definition
+	}
+
+	for _, documentSchema := range documentSchemas(doc) {
+		liftChildEnums(doc, documentSchema.schema, documentSchema.name, lifted)
+	}
+}
+
+// liftChildEnums lifts the inline enums nested in schema, named after name.
+func liftChildEnums(doc *spec.Swagger, schema *spec.Schema, name string, lifted map[string]string) {
+	_ = eachChildSchema(schema, func(child *spec.Schema, suffix string, allOf bool) error {
+		switch {
+		case allOf:
+			// an enum next to a reference restricts the referenced type, it stays inline
+			liftChildEnums(doc, child, name, lifted)
+		case suffix == "Item":
+			// the items of a field are the values of the field, they are named after it
+			liftEnum(doc, child, name, lifted)
+		default:
+			liftEnum(doc, child, name+suffix, lifted)
+		}
+
+		return nil
+	})
+}
+
+// liftEnum moves the enum of schema into a definition named name, which schema refers to. The other properties
+// of schema, like its description, stay next to the reference.
+func liftEnum(doc *spec.Swagger, schema *spec.Schema, name string, lifted map[string]string) {
+	if len(schema.Enum) == 0 || schema.Ref.String() != "" || schema.Type.Contains(OBJECT) || schema.Type.Contains(ARRAY) {
+		liftChildEnums(doc, schema, name, lifted)
+
+		return
+	}
+
+	enum := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:   schema.Type,
+			Format: schema.Format,
+			Enum:   schema.Enum,
+		},
+	}
+
+	for _, extension := range []string{enumVarNamesExtension, enumCommentsExtension, enumDescriptionsExtension} {
+		if value, ok := schema.Extensions[extension]; ok {
+			enum.AddExtension(extension, value)
+			delete(schema.Extensions, extension)
+		}
+	}
+
+	b, _ := json.Marshal(enum)
+
+	definition, ok := lifted[string(b)]
+	if !ok {
+		definition = name
+		for i := 2; ; i++ {
+			if _, exists := doc.Definitions[definition]; !exists {
+				break
+			}
+
+			definition = name + strconv.Itoa(i)
+		}
+
+		doc.Definitions[definition] = enum
+		lifted[string(b)] = definition
+	}
+
+	schema.Type = nil
+	schema.Format = ""
+	schema.Enum = nil
+
+	if len(schema.Extensions) == 0 {
+		schema.Extensions = nil
+	}
+
+	ref := spec.RefSchema("#/definitions/" + escapePointer(definition))
+
+	// siblings of $ref are ignored, the remaining properties go next to an allOf
+	remaining, _ := json.Marshal(schema)
+	if string(remaining) == "{}" {
+		*schema = *ref
+	} else {
+		schema.AllOf = []spec.Schema{*ref}
+	}
+}
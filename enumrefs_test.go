@@ -0,0 +1,63 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_EnumsAsRefs(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Pet struct {
+	// the state of the pet
+	Status string   ` + "`json:\"status\" enums:\"available,sold\"`" + `
+	Moods  []string ` + "`json:\"moods\" enums:\"happy,sad\"`" + `
+}
+
+type Order struct {
+	PetStatus string ` + "`json:\"petStatus\" enums:\"available,sold\"`" + `
+}
+
+// @Success 200 {object} Pet
+// @Success 201 {object} Order
+// @Router  /pets [get]
+func Pets(){
+}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	p.liftEnums()
+
+	// identical enums share the definition of the first field, in the order of the definitions
+	b, err := json.Marshal(p.swagger.Definitions)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"api.Order": {
+			"type": "object",
+			"properties": {"petStatus": {"$ref": "#/definitions/api.OrderPetStatus"}}
+		},
+		"api.Pet": {
+			"type": "object",
+			"properties": {
+				"moods": {"type": "array", "items": {"$ref": "#/definitions/api.PetMoods"}},
+				"status": {"description": "the state of the pet", "allOf": [{"$ref": "#/definitions/api.OrderPetStatus"}]}
+			}
+		},
+		"api.OrderPetStatus": {"type": "string", "enum": ["available", "sold"]},
+		"api.PetMoods": {"type": "string", "enum": ["happy", "sad"]}
+	}`, string(b))
+}
@@ -0,0 +1,125 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// ParseSuccessExampleComment parses the JSON example file of a response declared before, relative to the annotated
+// file: @successExample 200 ./examples/user_ok.json.
+func (operation *Operation) ParseSuccessExampleComment(commentLine string, astFile *ast.File) error {
+	fields := FieldsByAnySpace(commentLine, 2)
+	if len(fields) != 2 {
+		return fmt.Errorf("can not parse success example comment \"%s\", expected code and file", commentLine)
+	}
+
+	codeStr, fileName := fields[0], fields[1]
+
+	value, err := operation.readExampleFile(fileName, astFile)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(codeStr, defaultTag) {
+		if operation.Responses.Default == nil {
+			return fmt.Errorf("success example of %s has no response, declare it before with @Success or @Failure", codeStr)
+		}
+
+		setJSONExample(operation.Responses.Default, value)
+
+		return nil
+	}
+
+	code, err := strconv.Atoi(codeStr)
+	if err != nil {
+		return fmt.Errorf("can not parse success example comment \"%s\"", commentLine)
+	}
+
+	response, ok := operation.Responses.StatusCodeResponses[code]
+	if !ok {
+		return fmt.Errorf("success example of %s has no response, declare it before with @Success or @Failure", codeStr)
+	}
+
+	setJSONExample(&response, value)
+	operation.Responses.StatusCodeResponses[code] = response
+
+	return nil
+}
+
+// setJSONExample sets the JSON example of response.
+// exampleFilePath returns the path of the example file name, relative to the directory of file, the Go file which
+// declares it, unless absolute. The exampleFile tags and the @Example, @successExample and @requestExample
+// annotations share this rule.
+func (parser *Parser) exampleFilePath(name string, file *ast.File) string {
+	if filepath.IsAbs(name) || parser == nil || parser.packages == nil {
+		return name
+	}
+
+	if info, ok := parser.packages.files[file]; ok {
+		return filepath.Join(filepath.Dir(info.Path), name)
+	}
+
+	return name
+}
+
+func setJSONExample(response *spec.Response, value any) {
+	if response.Examples == nil {
+		response.Examples = make(map[string]any)
+	}
+
+	response.Examples[mimeJSON] = value
+}
+
+// ParseRequestExampleComment parses the JSON example file of the body declared before, relative to the annotated
+// file: @requestExample ./examples/create_user.json. The example is set on the schema of the body parameter.
+func (operation *Operation) ParseRequestExampleComment(commentLine string, astFile *ast.File) error {
+	fileName := strings.TrimSpace(commentLine)
+	if fileName == "" {
+		return fmt.Errorf("can not parse request example comment \"%s\", expected a file", commentLine)
+	}
+
+	value, err := operation.readExampleFile(fileName, astFile)
+	if err != nil {
+		return err
+	}
+
+	for i := range operation.Parameters {
+		body := &operation.Parameters[i]
+		if body.In != "body" || body.Schema == nil {
+			continue
+		}
+
+		// the siblings of a $ref are ignored
+		if body.Schema.Ref.String() != "" {
+			body.Schema = spec.ComposedSchema(*body.Schema)
+		}
+
+		body.Schema.Example = value
+
+		return nil
+	}
+
+	return fmt.Errorf("request example %s has no body, declare it before with @Param or @RequestBody", fileName)
+}
+
+// readExampleFile returns the value of the JSON file fileName, relative to the directory of astFile unless absolute.
+func (operation *Operation) readExampleFile(fileName string, astFile *ast.File) (any, error) {
+	b, err := os.ReadFile(operation.parser.exampleFilePath(fileName, astFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read example file %s: %w", fileName, err)
+	}
+
+	var value any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, fmt.Errorf("example file %s is not valid JSON: %w", fileName, err)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,65 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ExampleFiles(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	ID   int    ` + "`json:\"id\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+
+// @Param          user  body  User  true  "the user"
+// @requestExample ./examples/create_user.json
+// @Success        200  {object}  User
+// @successExample 200  ./examples/user_ok.json
+// @Router         /users [post]
+func CreateUser(){
+}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("api", "testdata/examplefiles/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	op := p.swagger.Paths.Paths["/users"].Post
+
+	body := op.Parameters[0].Schema
+	assert.Equal(t, []spec.Schema{*spec.RefSchema("#/definitions/api.User")}, body.AllOf)
+	assert.Equal(t, map[string]any{"name": "Ada Lovelace"}, body.Example)
+
+	assert.Equal(t, map[string]any{"application/json": map[string]any{"id": float64(1), "name": "Ada Lovelace"}},
+		op.Responses.StatusCodeResponses[200].Examples)
+}
+
+func TestParseExampleFileComments(t *testing.T) {
+	t.Parallel()
+
+	for comment, message := range map[string]string{
+		"@successExample 200": `can not parse success example comment "200", expected code and file`,
+		"@successExample 201 testdata/examplefiles/examples/user_ok.json": "success example of 201 has no response, declare it before with @Success or @Failure",
+		"@successExample 200 testdata/examplefiles/examples/broken.json":  "example file testdata/examplefiles/examples/broken.json is not valid JSON",
+		"@successExample 200 testdata/examplefiles/missing.json":          "failed to read example file testdata/examplefiles/missing.json",
+		"@requestExample testdata/examplefiles/examples/create_user.json": "request example testdata/examplefiles/examples/create_user.json has no body, declare it before with @Param or @RequestBody",
+	} {
+		operation := NewOperation(New())
+		require.NoError(t, operation.ParseComment(`@Success 200 {string} string "ok"`, nil))
+
+		err := operation.ParseComment(comment, nil)
+		assert.ErrorContains(t, err, message, comment)
+	}
+}
@@ -0,0 +1,336 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// maxExampleRefDepth stops resolving refs of recursive definitions.
+const maxExampleRefDepth = 32
+
+// ValidationIssue describes an element of the generated document which violates its schema.
+type ValidationIssue struct {
+	// Location the JSON pointer of the element in the document
+	Location string
+
+	// Source the file and line of the annotation which produced the element, if known
+	Source string
+
+	// Message what is wrong with the element
+	Message string
+}
+
+// String formats the issue as source: location: message.
+func (issue ValidationIssue) String() string {
+	if issue.Source == "" {
+		return fmt.Sprintf("%s: %s", issue.Location, issue.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", issue.Source, issue.Location, issue.Message)
+}
+
+// ValidateExamples checks that every example of the document, for schemas, parameters
+// and responses, validates against its schema.
+func ValidateExamples(swagger *spec.Swagger) []ValidationIssue {
+	validator := exampleValidator{definitions: swagger.Definitions}
+
+	for _, name := range sortedKeys(swagger.Definitions) {
+		schema := swagger.Definitions[name]
+		validator.walkSchema("/definitions/"+escapePointer(name), &schema)
+	}
+
+	if swagger.Paths == nil {
+		return validator.issues
+	}
+
+	for _, path := range sortedKeys(swagger.Paths.Paths) {
+		item := swagger.Paths.Paths[path]
+
+		for _, method := range sortedMethods() {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil {
+				continue
+			}
+
+			pointer := "/paths/" + escapePointer(path) + "/" + strings.ToLower(method)
+
+			for i, param := range op.Parameters {
+				paramPointer := pointer + "/parameters/" + strconv.Itoa(i)
+
+				if param.Schema != nil {
+					validator.walkSchema(paramPointer+"/schema", param.Schema)
+
+					continue
+				}
+
+				if param.Example != nil {
+					validator.validate(paramPointer+"/example", "", param.Example, parameterSchema(&param), 0)
+				}
+			}
+
+			if op.Responses == nil {
+				continue
+			}
+
+			if op.Responses.Default != nil {
+				validator.walkResponse(pointer+"/responses/default", op.Responses.Default)
+			}
+
+			codes := make([]int, 0, len(op.Responses.StatusCodeResponses))
+			for code := range op.Responses.StatusCodeResponses {
+				codes = append(codes, code)
+			}
+
+			sort.Ints(codes)
+
+			for _, code := range codes {
+				response := op.Responses.StatusCodeResponses[code]
+				validator.walkResponse(pointer+"/responses/"+strconv.Itoa(code), &response)
+			}
+		}
+	}
+
+	return validator.issues
+}
+
+// ValidateExamples checks the examples of the parsed document, issues refer to the annotations which produced them.
+func (parser *Parser) ValidateExamples() []ValidationIssue {
+	issues := ValidateExamples(parser.swagger)
+	for i := range issues {
+		issues[i].Source = parser.Source(issues[i].Location)
+	}
+
+	return issues
+}
+
+type exampleValidator struct {
+	definitions spec.Definitions
+	issues      []ValidationIssue
+}
+
+func (v *exampleValidator) addIssue(pointer, path, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if path != "" {
+		message = path + ": " + message
+	}
+
+	v.issues = append(v.issues, ValidationIssue{Location: pointer, Message: message})
+}
+
+// walkSchema validates the examples of schema and of its properties and items.
+func (v *exampleValidator) walkSchema(pointer string, schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Example != nil {
+		v.validate(pointer+"/example", "", schema.Example, schema, 0)
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		v.walkSchema(pointer+"/properties/"+escapePointer(name), &property)
+	}
+
+	if schema.Items != nil {
+		v.walkSchema(pointer+"/items", schema.Items.Schema)
+	}
+
+	for i := range schema.AllOf {
+		v.walkSchema(pointer+"/allOf/"+strconv.Itoa(i), &schema.AllOf[i])
+	}
+
+	if schema.AdditionalProperties != nil {
+		v.walkSchema(pointer+"/additionalProperties", schema.AdditionalProperties.Schema)
+	}
+}
+
+// walkResponse validates the examples of a response, only JSON examples can be checked.
+func (v *exampleValidator) walkResponse(pointer string, response *spec.Response) {
+	for _, mime := range sortedKeys(response.Examples) {
+		if strings.Contains(mime, "json") && response.Schema != nil {
+			v.validate(pointer+"/examples/"+escapePointer(mime), "", response.Examples[mime], response.Schema, 0)
+		}
+	}
+
+	if response.Schema != nil {
+		examples := namedExamples(response)
+		for _, name := range sortedKeys(examples) {
+			v.validate(pointer+"/"+NamedExamplesExtension+"/"+escapePointer(name)+"/value", "", examples[name].Value,
+				response.Schema, 0)
+		}
+	}
+
+	v.walkSchema(pointer+"/schema", response.Schema)
+}
+
+// validate checks value against schema, path is the location of the value inside the example.
+func (v *exampleValidator) validate(pointer, path string, value any, schema *spec.Schema, depth int) {
+	if depth == 0 {
+		value = normalizeJSON(value)
+	}
+
+	if ref := schema.Ref.String(); ref != "" {
+		definition, ok := v.definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		if ok && depth < maxExampleRefDepth {
+			v.validate(pointer, path, value, &definition, depth+1)
+		}
+
+		return
+	}
+
+	for i := range schema.AllOf {
+		v.validate(pointer, path, value, &schema.AllOf[i], depth+1)
+	}
+
+	if value == nil {
+		return
+	}
+
+	if len(schema.Type) > 0 && !matchesType(value, schema.Type) {
+		v.addIssue(pointer, path, "%s is not of type %s", formatValue(value), strings.Join(schema.Type, ","))
+
+		return
+	}
+
+	validations := schemaValidations(schema)
+	if issue := checkValue(value, &validations); issue != "" {
+		v.addIssue(pointer, path, "%s %s", formatValue(value), issue)
+	}
+
+	switch value := value.(type) {
+	case []any:
+		if schema.MinItems != nil && int64(len(value)) < *schema.MinItems {
+			v.addIssue(pointer, path, "has less than %d items", *schema.MinItems)
+		}
+
+		if schema.MaxItems != nil && int64(len(value)) > *schema.MaxItems {
+			v.addIssue(pointer, path, "has more than %d items", *schema.MaxItems)
+		}
+
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for i, item := range value {
+				v.validate(pointer, path+"/"+strconv.Itoa(i), item, schema.Items.Schema, depth+1)
+			}
+		}
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				v.addIssue(pointer, path, "misses the required property %s", name)
+			}
+		}
+
+		for _, name := range sortedKeys(value) {
+			if property, ok := schema.Properties[name]; ok {
+				v.validate(pointer, path+"/"+escapePointer(name), value[name], &property, depth+1)
+			} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+				v.validate(pointer, path+"/"+escapePointer(name), value[name], schema.AdditionalProperties.Schema, depth+1)
+			}
+		}
+	}
+}
+
+// parameterSchema returns the schema of a non body parameter.
+func parameterSchema(param *spec.Parameter) *spec.Schema {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:             spec.StringOrArray{param.Type},
+			Format:           param.Format,
+			Maximum:          param.Maximum,
+			ExclusiveMaximum: param.ExclusiveMaximum,
+			Minimum:          param.Minimum,
+			ExclusiveMinimum: param.ExclusiveMinimum,
+			MaxLength:        param.MaxLength,
+			MinLength:        param.MinLength,
+			Pattern:          param.Pattern,
+			Enum:             param.Enum,
+		},
+	}
+
+	if param.Type == "file" || param.Type == "" {
+		schema.Type = nil
+	}
+
+	return schema
+}
+
+// matchesType reports whether a JSON value is of one of the types.
+func matchesType(value any, types spec.StringOrArray) bool {
+	for _, typ := range types {
+		switch typ {
+		case STRING:
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case INTEGER:
+			if number, ok := value.(float64); ok && number == math.Trunc(number) {
+				return true
+			}
+		case NUMBER:
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case BOOLEAN:
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case ARRAY:
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case OBJECT:
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeJSON turns a value into its generic JSON representation.
+func normalizeJSON(value any) any {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var result any
+	if json.Unmarshal(b, &result) != nil {
+		return value
+	}
+
+	return result
+}
+
+func formatValue(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(b)
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
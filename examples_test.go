@@ -0,0 +1,90 @@
+package swag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExamples(t *testing.T) {
+	t.Parallel()
+
+	id := spec.PathParam("id").Typed(INTEGER, "")
+	id.Example = 1.5
+
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Definitions: spec.Definitions{
+				"Pet": *spec.MapProperty(nil).
+					WithRequired("name").
+					SetProperty("name", *spec.StringProperty().WithMinLength(2)).
+					SetProperty("tags", *spec.ArrayProperty(spec.StringProperty())).
+					WithExample(map[string]any{"name": "x", "tags": []any{"a", 1}}),
+			},
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+				"/pets/{id}": {PathItemProps: spec.PathItemProps{Get: &spec.Operation{OperationProps: spec.OperationProps{
+					Parameters: []spec.Parameter{*id},
+					Responses: &spec.Responses{ResponsesProps: spec.ResponsesProps{StatusCodeResponses: map[int]spec.Response{
+						200: *spec.NewResponse().WithSchema(spec.RefSchema("#/definitions/Pet")).
+							AddExample("application/json", map[string]any{"tags": []any{}}),
+					}}},
+				}}}},
+			}},
+		},
+	}
+
+	responses := swagger.Paths.Paths["/pets/{id}"].Get.Responses.StatusCodeResponses
+	response := responses[200]
+	response.AddExtension(NamedExamplesExtension, map[string]NamedExample{"short": {Value: map[string]any{"name": "y"}}})
+	responses[200] = response
+
+	var messages []string
+	for _, issue := range ValidateExamples(swagger) {
+		messages = append(messages, issue.String())
+	}
+
+	assert.Equal(t, []string{
+		`/definitions/Pet/example: /name: "x" is shorter than the minimum length 2`,
+		`/definitions/Pet/example: /tags/1: 1 is not of type string`,
+		`/paths/~1pets~1{id}/get/parameters/0/example: 1.5 is not of type integer`,
+		`/paths/~1pets~1{id}/get/responses/200/examples/application~1json: misses the required property name`,
+		`/paths/~1pets~1{id}/get/responses/200/x-examples/short/value: /name: "y" is shorter than the minimum length 2`,
+	}, messages)
+}
+
+func TestParser_ValidateExamples(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package test
+
+type Pet struct {
+	Name string ` + "`" + `json:"name" minLength:"2" example:"x"` + "`" + `
+}
+
+// @Param id path int true "id" example(abc)
+// @Success 200 {object} Pet
+// @Router /pets/{id} [get]
+func Test(){
+}
+`
+	p := New()
+
+	err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	issues := p.ValidateExamples()
+	assert.Len(t, issues, 1)
+	assert.True(t, strings.HasSuffix(issues[0].Source, "api/api.go:4"), issues[0].Source)
+	assert.Equal(t, "/definitions/test.Pet/properties/name/example", issues[0].Location)
+
+	assert.True(t, strings.HasSuffix(p.Source("/paths/~1pets~1{id}/get/parameters/0"), "api/api.go:8"))
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unicode"
 
 	"github.com/go-openapi/spec"
@@ -19,21 +20,46 @@ const (
 	requiredLabel    = "required"
 	optionalLabel    = "optional"
 	omitEmptyLabel   = "omitempty"
+	omitZeroLabel    = "omitzero"
 	swaggerTypeTag   = "swaggertype"
 	swaggerIgnoreTag = "swaggerignore"
+	swaggerFormatTag = "swaggerformat"
+)
+
+// omitEmptyExtension tells client generators whether a property is omitted from JSON when empty.
+const omitEmptyExtension = "x-omitempty"
+
+// WriteOnlyExtension marks the properties of the writeonly:"true" fields, sent in requests but never returned, like
+// passwords. Swagger 2.0 has no writeOnly, OpenAPI 3.0 documents have the real one.
+const WriteOnlyExtension = "x-writeOnly"
+
+// Extensions of the unit and currency tags, the measurement metadata of numbers like durations and prices.
+const (
+	unitExtension     = "x-unit"
+	currencyExtension = "x-currency"
 )
 
 type tagBaseFieldParser struct {
 	p     *Parser
 	field *ast.Field
 	tag   reflect.StructTag
+
+	// nameTag the struct tag naming the property, json unless the parser uses another one for the package
+	nameTag string
+
+	// file the file of the struct of the field, which its fixture examples and union types are relative to
+	file *ast.File
 }
 
 func newTagBaseFieldParser(p *Parser, field *ast.Field) FieldParser {
 	fieldParser := tagBaseFieldParser{
-		p:     p,
-		field: field,
-		tag:   "",
+		p:       p,
+		field:   field,
+		tag:     "",
+		nameTag: jsonTag,
+	}
+	if p != nil && p.propertyTag != "" {
+		fieldParser.nameTag = p.propertyTag
 	}
 	if fieldParser.field.Tag != nil {
 		fieldParser.tag = reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", ""))
@@ -58,7 +84,7 @@ func (ps *tagBaseFieldParser) ShouldSkip() bool {
 	}
 
 	// json:"tag,hoge"
-	name := strings.TrimSpace(strings.Split(ps.tag.Get(jsonTag), ",")[0])
+	name := strings.TrimSpace(strings.Split(ps.tag.Get(ps.nameTag), ",")[0])
 	if name == "-" {
 		return true
 	}
@@ -71,7 +97,7 @@ func (ps *tagBaseFieldParser) FieldNames() ([]string, error) {
 		// if embedded but with a json/form name ??
 		if ps.field.Tag != nil {
 			// json:"tag,hoge"
-			name := strings.TrimSpace(strings.Split(ps.tag.Get(jsonTag), ",")[0])
+			name := strings.TrimSpace(strings.Split(ps.tag.Get(ps.nameTag), ",")[0])
 			if name != "" {
 				return []string{name}, nil
 			}
@@ -169,7 +195,16 @@ func (ps *tagBaseFieldParser) CustomSchema() (*spec.Schema, error) {
 		return BuildCustomSchema(strings.Split(typeTag, ","))
 	}
 
-	return nil, nil
+	if format := strings.TrimSpace(ps.tag.Get(swaggerFormatTag)); format != "" {
+		schema, ok := timeFieldSchema(ps.field.Type, format)
+		if !ok {
+			return nil, fmt.Errorf("the %s tag only applies to %s fields", swaggerFormatTag, timeType)
+		}
+
+		return schema, nil
+	}
+
+	return unionTagSchema(ps.p, ps.file, ps.tag.Get(oneOfTag), ps.tag.Get(anyOfTag))
 }
 
 type structField struct {
@@ -179,6 +214,8 @@ type structField struct {
 	formatType   string
 	maximum      *float64
 	minimum      *float64
+	exclusiveMax bool
+	exclusiveMin bool
 	multipleOf   *float64
 	maxLength    *int64
 	minLength    *int64
@@ -206,8 +243,18 @@ func splitNotWrapped(s string, sep rune) []string {
 		openChar  rune
 	)
 
+	escaped := false
+
 	for _, char := range s {
 		switch {
+		case escaped:
+			escaped = false
+
+			current.WriteRune(char)
+		case char == '\\':
+			escaped = true
+
+			current.WriteRune(char)
 		case openChar == 0 && openCloseMap[char] != 0:
 			openChar = char
 
@@ -273,6 +320,8 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 			schema.Description = strings.TrimSpace(ps.field.Comment.Text())
 		}
 
+		ps.complementOmitEmpty(schema)
+
 		return nil
 	}
 
@@ -286,6 +335,14 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		field.arrayType = types[1]
 	}
 
+	// the format of a time.Time documented by TimeFormat or the swaggerformat tag is kept without format tag
+	if _, ok := timeFieldSchema(ps.field.Type, ""); ok && field.formatType == "" {
+		field.formatType = schema.Format
+		if schema.Items != nil && schema.Items.Schema != nil {
+			field.formatType = schema.Items.Schema.Format
+		}
+	}
+
 	jsonTagValue := ps.tag.Get(jsonTag)
 
 	bindingTagValue := ps.tag.Get(bindingTag)
@@ -313,7 +370,7 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		}
 
 		if maximum != nil {
-			field.maximum = maximum
+			field.maximum, field.exclusiveMax = maximum, false
 		}
 
 		minimum, err := getFloatTag(ps.tag, minimumTag)
@@ -322,7 +379,7 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		}
 
 		if minimum != nil {
-			field.minimum = minimum
+			field.minimum, field.exclusiveMin = minimum, false
 		}
 
 		multipleOf, err := getFloatTag(ps.tag, multipleOfTag)
@@ -357,7 +414,28 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 
 	// json:"name,string" or json:",string"
 	exampleTagValue, ok := ps.tag.Lookup(exampleTag)
-	if ok {
+	if fixture, isFixture := ps.tag.Lookup(exampleFileTag); isFixture {
+		if ok {
+			return fmt.Errorf("a field can not have both the %s and the %s tags", exampleTag, exampleFileTag)
+		}
+
+		example, err := ps.p.fixtureExample(fixture, ps.file)
+		if err != nil {
+			return err
+		}
+
+		if strings.Contains(jsonTagValue, ",string") {
+			err = checkExampleType(example, STRING)
+		} else {
+			err = checkExampleType(example, field.schemaType)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", exampleFileTag, fixture, err)
+		}
+
+		field.exampleValue = example
+	} else if ok {
 		field.exampleValue = exampleTagValue
 
 		if !strings.Contains(jsonTagValue, ",string") {
@@ -407,7 +485,7 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 
 	defaultTagValue, ok := ps.tag.Lookup(defaultTag)
 	if ok {
-		value, err := defineType(field.schemaType, defaultTagValue)
+		value, err := ps.defaultValue(field, defaultTagValue)
 		if err != nil {
 			return err
 		}
@@ -427,6 +505,35 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 		schema.Extensions = setExtensionParam(extensionsTagValue)
 	}
 
+	if ps.tag.Get(writeOnlyTag) == "true" {
+		if schema.ReadOnly {
+			return fmt.Errorf("a field can not be both %s and %s", readOnlyTag, writeOnlyTag)
+		}
+
+		if schema.Extensions == nil {
+			schema.Extensions = make(spec.Extensions)
+		}
+
+		schema.Extensions[WriteOnlyExtension] = true
+	}
+
+	err := ps.complementMeasurement(schema)
+	if err != nil {
+		return err
+	}
+
+	err = ps.complementEncoding(schema)
+	if err != nil {
+		return err
+	}
+
+	err = ps.complementDeprecation(schema)
+	if err != nil {
+		return err
+	}
+
+	ps.complementOmitEmpty(schema)
+
 	varNamesTag := ps.tag.Get("x-enum-varnames")
 	if varNamesTag != "" {
 		varNames := strings.Split(varNamesTag, ",")
@@ -472,6 +579,8 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 
 	eleSchema.Maximum = field.maximum
 	eleSchema.Minimum = field.minimum
+	eleSchema.ExclusiveMaximum = field.exclusiveMax
+	eleSchema.ExclusiveMinimum = field.exclusiveMin
 	eleSchema.MultipleOf = field.multipleOf
 	eleSchema.MaxLength = field.maxLength
 	eleSchema.MinLength = field.minLength
@@ -480,6 +589,122 @@ func (ps *tagBaseFieldParser) complementSchema(schema *spec.Schema, types []stri
 	return nil
 }
 
+// defaultValue returns the value of the default tag of a field, written like the defaults of the env and config
+// loaders: the comma separated items of an array, e.g. default:"a,b", or a duration, e.g. default:"5s", which is
+// documented in nanoseconds like time.Duration is marshaled.
+func (ps *tagBaseFieldParser) defaultValue(field *structField, value string) (any, error) {
+	if field.schemaType == ARRAY {
+		items := make([]any, 0)
+
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+
+			v, err := defineType(field.arrayType, strings.ReplaceAll(item, utf8HexComma, ","))
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, v)
+		}
+
+		return items, nil
+	}
+
+	if field.schemaType == INTEGER && isDurationType(ps.field.Type) {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return int64(duration), nil
+		}
+	}
+
+	return defineType(field.schemaType, value)
+}
+
+// isDurationType reports whether the type of a field is time.Duration.
+func isDurationType(expr ast.Expr) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Duration" {
+		return false
+	}
+
+	pkg, ok := selector.X.(*ast.Ident)
+
+	return ok && pkg.Name == "time"
+}
+
+// complementMeasurement adds the x-unit and x-currency extensions of the unit and currency tags, e.g. unit:"ms"
+// or currency:"USD", and appends them to the description, since portals rarely show extensions.
+func (ps *tagBaseFieldParser) complementMeasurement(schema *spec.Schema) error {
+	var notes []string
+
+	if unit := strings.TrimSpace(ps.tag.Get(unitTag)); unit != "" {
+		schema.AddExtension(unitExtension, unit)
+		notes = append(notes, "unit: "+unit)
+	}
+
+	if currency := strings.TrimSpace(ps.tag.Get(currencyTag)); currency != "" {
+		if !isCurrencyCode(currency) {
+			return fmt.Errorf("invalid currency %q, expected an ISO 4217 code like USD", currency)
+		}
+
+		schema.AddExtension(currencyExtension, currency)
+		notes = append(notes, "currency: "+currency)
+	}
+
+	if len(notes) == 0 {
+		return nil
+	}
+
+	note := strings.Join(notes, ", ")
+	if schema.Description == "" {
+		schema.Description = note
+	} else {
+		schema.Description += " (" + note + ")"
+	}
+
+	return nil
+}
+
+// isCurrencyCode reports whether code looks like an ISO 4217 currency code, three upper case letters.
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// complementOmitEmpty adds x-omitempty, whether the field is omitted from JSON when empty, if the parser emits it.
+// An x-omitempty set by the extensions tag is kept.
+func (ps *tagBaseFieldParser) complementOmitEmpty(schema *spec.Schema) {
+	if !ps.p.OmitEmptyExtension {
+		return
+	}
+
+	if _, ok := schema.Extensions[omitEmptyExtension]; ok {
+		return
+	}
+
+	omitEmpty := false
+
+	if ps.field.Tag != nil {
+		for _, val := range strings.Split(ps.tag.Get(ps.nameTag), ",")[1:] {
+			if val == omitEmptyLabel || val == omitZeroLabel {
+				omitEmpty = true
+			}
+		}
+	}
+
+	schema.AddExtension(omitEmptyExtension, omitEmpty)
+}
+
 func getFloatTag(structTag reflect.StructTag, tagName string) (*float64, error) {
 	strValue := structTag.Get(tagName)
 	if strValue == "" {
@@ -537,9 +762,9 @@ func (ps *tagBaseFieldParser) IsRequired() (bool, error) {
 		}
 	}
 
-	jsonTag := ps.tag.Get(jsonTag)
-	if jsonTag != "" {
-		for _, val := range strings.Split(jsonTag, ",") {
+	nameTag := ps.tag.Get(ps.nameTag)
+	if nameTag != "" {
+		for _, val := range strings.Split(nameTag, ",") {
 			if val == omitEmptyLabel {
 				return false, nil
 			}
@@ -571,6 +796,15 @@ func parseValidTags(validTag string, sf *structField) {
 			sf.setMax(valValue)
 		case "min", "gte":
 			sf.setMin(valValue)
+		case "len":
+			sf.setMin(valValue)
+			sf.setMax(valValue)
+		case "lt":
+			sf.setExclusiveMax(valValue)
+		case "gt":
+			sf.setExclusiveMin(valValue)
+		case "datetime":
+			sf.setFormat(datetimeFormats[valValue])
 		case "oneof":
 			sf.setOneOf(valValue)
 		case "unique":
@@ -581,11 +815,39 @@ func parseValidTags(validTag string, sf *structField) {
 			// ignore dive
 			return
 		default:
-			continue
+			sf.setFormat(validatorFormats[keyVal[0]])
 		}
 	}
 }
 
+// validatorFormats the formats of the string validators of go-playground/validator.
+var validatorFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"http_url":         "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"ipv4":             "ipv4",
+	"ip4_addr":         "ipv4",
+	"ipv6":             "ipv6",
+	"ip6_addr":         "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"base64":           "byte",
+}
+
+// datetimeFormats the formats of the layouts of the datetime validator, e.g. datetime=2006-01-02.
+var datetimeFormats = map[string]string{
+	"2006-01-02":                "date",
+	"2006-01-02T15:04:05Z":      "date-time",
+	"2006-01-02T15:04:05Z07:00": "date-time",
+}
+
 func parseEnumTags(enumTag string, field *structField) error {
 	enumType := field.schemaType
 	if field.schemaType == ARRAY {
@@ -645,6 +907,51 @@ func (sf *structField) setMin(valValue string) {
 	}
 }
 
+// setFormat sets the format of a string field validated as one, unless the format tag sets it.
+func (sf *structField) setFormat(format string) {
+	if format != "" && sf.formatType == "" && sf.schemaType == STRING {
+		sf.formatType = format
+	}
+}
+
+// setExclusiveMin sets the exclusive minimum of gt=, or the minimum length or count above it.
+func (sf *structField) setExclusiveMin(valValue string) {
+	value, err := strconv.ParseFloat(valValue, 64)
+	if err != nil {
+		return
+	}
+
+	switch sf.schemaType {
+	case INTEGER, NUMBER:
+		sf.minimum, sf.exclusiveMin = &value, true
+	case STRING:
+		intValue := int64(value) + 1
+		sf.minLength = &intValue
+	case ARRAY:
+		intValue := int64(value) + 1
+		sf.minItems = &intValue
+	}
+}
+
+// setExclusiveMax sets the exclusive maximum of lt=, or the maximum length or count below it.
+func (sf *structField) setExclusiveMax(valValue string) {
+	value, err := strconv.ParseFloat(valValue, 64)
+	if err != nil {
+		return
+	}
+
+	switch sf.schemaType {
+	case INTEGER, NUMBER:
+		sf.maximum, sf.exclusiveMax = &value, true
+	case STRING:
+		intValue := int64(value) - 1
+		sf.maxLength = &intValue
+	case ARRAY:
+		intValue := int64(value) - 1
+		sf.maxItems = &intValue
+	}
+}
+
 func (sf *structField) setMax(valValue string) {
 	value, err := strconv.ParseFloat(valValue, 64)
 	if err != nil {
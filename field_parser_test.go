@@ -243,6 +243,33 @@ func TestDefaultFieldParser(t *testing.T) {
 		assert.Equal(t, spec.Extensions{}, schema.Extensions)
 	})
 
+	t.Run("Writeonly tag", func(t *testing.T) {
+		t.Parallel()
+
+		schema := spec.Schema{}
+		schema.Type = []string{"string"}
+		err := newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"password" writeonly:"true" extensions:"x-order=1"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, true, schema.Extensions[WriteOnlyExtension])
+		assert.Equal(t, "1", schema.Extensions["x-order"])
+		assert.False(t, schema.ReadOnly)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"string"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"password" readonly:"true" writeonly:"true"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.EqualError(t, err, "a field can not be both readonly and writeonly")
+	})
+
 	t.Run("Default tag", func(t *testing.T) {
 		t.Parallel()
 
@@ -266,6 +293,43 @@ func TestDefaultFieldParser(t *testing.T) {
 			}},
 		).ComplementSchema(&schema)
 		assert.Error(t, err)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"array"}
+		schema.Items = &spec.SchemaOrArray{Schema: spec.Int64Property()}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{
+				Value: `json:"test" default:"1, 2,3"`,
+			}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{1, 2, 3}, schema.Default)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"integer"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{
+				Type: &ast.SelectorExpr{X: &ast.Ident{Name: "time"}, Sel: &ast.Ident{Name: "Duration"}},
+				Tag: &ast.BasicLit{
+					Value: `json:"test" default:"1m30s"`,
+				}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(90e9), schema.Default)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"integer"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{
+				Type: &ast.Ident{Name: "int"},
+				Tag: &ast.BasicLit{
+					Value: `json:"test" default:"1m30s"`,
+				}},
+		).ComplementSchema(&schema)
+		assert.Error(t, err)
 	})
 
 	t.Run("Numeric value", func(t *testing.T) {
@@ -423,6 +487,86 @@ func TestDefaultFieldParser(t *testing.T) {
 		assert.Equal(t, true, schema.ReadOnly)
 	})
 
+	t.Run("Omitempty extension", func(t *testing.T) {
+		t.Parallel()
+
+		for tag, expected := range map[string]any{
+			`json:"test,omitempty"`:                      true,
+			`json:"test,omitzero"`:                       true,
+			`json:"test"`:                                false,
+			`json:"omitempty"`:                           false,
+			`json:"test" extensions:"x-omitempty=false"`: nil,
+		} {
+			schema := spec.Schema{}
+			schema.Type = []string{"string"}
+			err := newTagBaseFieldParser(
+				&Parser{OmitEmptyExtension: true},
+				&ast.Field{Tag: &ast.BasicLit{Value: tag}},
+			).ComplementSchema(&schema)
+			assert.NoError(t, err)
+
+			if expected == nil {
+				assert.Equal(t, "false", schema.Extensions["x-omitempty"], tag)
+			} else {
+				assert.Equal(t, expected, schema.Extensions["x-omitempty"], tag)
+			}
+		}
+
+		schema := spec.Schema{}
+		schema.Type = []string{"string"}
+		err := newTagBaseFieldParser(
+			&Parser{OmitEmptyExtension: true},
+			&ast.Field{},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, false, schema.Extensions["x-omitempty"])
+
+		schema = spec.Schema{}
+		schema.Type = []string{"string"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{Value: `json:"test,omitempty"`}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.NotContains(t, schema.Extensions, "x-omitempty")
+	})
+
+	t.Run("Unit and currency tags", func(t *testing.T) {
+		t.Parallel()
+
+		schema := spec.Schema{}
+		schema.Type = []string{"integer"}
+		err := newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{
+				Doc: &ast.CommentGroup{List: []*ast.Comment{{Text: "// Latency of the request"}}},
+				Tag: &ast.BasicLit{Value: `json:"latency" unit:"ms"`},
+			},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, "ms", schema.Extensions["x-unit"])
+		assert.Equal(t, "Latency of the request (unit: ms)", schema.Description)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"number"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{Value: `json:"price" unit:"cents" currency:"USD"`}},
+		).ComplementSchema(&schema)
+		assert.NoError(t, err)
+		assert.Equal(t, "cents", schema.Extensions["x-unit"])
+		assert.Equal(t, "USD", schema.Extensions["x-currency"])
+		assert.Equal(t, "unit: cents, currency: USD", schema.Description)
+
+		schema = spec.Schema{}
+		schema.Type = []string{"number"}
+		err = newTagBaseFieldParser(
+			&Parser{},
+			&ast.Field{Tag: &ast.BasicLit{Value: `json:"price" currency:"dollar"`}},
+		).ComplementSchema(&schema)
+		assert.EqualError(t, err, `invalid currency "dollar", expected an ISO 4217 code like USD`)
+	})
+
 	t.Run("Invalid tag", func(t *testing.T) {
 		t.Parallel()
 
@@ -697,6 +841,48 @@ func TestValidTags(t *testing.T) {
 		assert.Empty(t, schema.Enum)
 	})
 
+	t.Run("Formats, len and exclusive bounds", func(t *testing.T) {
+		t.Parallel()
+
+		complement := func(schemaType, tag string) spec.Schema {
+			schema := spec.Schema{}
+			schema.Type = []string{schemaType}
+			err := newTagBaseFieldParser(
+				&Parser{},
+				&ast.Field{Tag: &ast.BasicLit{Value: "`" + tag + "`"}},
+			).ComplementSchema(&schema)
+			assert.NoError(t, err)
+
+			return schema
+		}
+
+		assert.Equal(t, "email", complement("string", `json:"test" validate:"required,email"`).Format)
+		assert.Equal(t, "uuid", complement("string", `json:"test" binding:"uuid4"`).Format)
+		assert.Equal(t, "date", complement("string", `json:"test" validate:"datetime=2006-01-02"`).Format)
+		assert.Equal(t, "hostname", complement("string", `json:"test" validate:"email" format:"hostname"`).Format)
+		assert.Empty(t, complement("integer", `json:"test" validate:"email"`).Format)
+
+		schema := complement("string", `json:"test" validate:"len=8"`)
+		length := int64(8)
+		assert.Equal(t, &length, schema.MinLength)
+		assert.Equal(t, &length, schema.MaxLength)
+
+		schema = complement("number", `json:"test" validate:"gt=0,lt=100"`)
+		zero, hundred := float64(0), float64(100)
+		assert.Equal(t, &zero, schema.Minimum)
+		assert.True(t, schema.ExclusiveMinimum)
+		assert.Equal(t, &hundred, schema.Maximum)
+		assert.True(t, schema.ExclusiveMaximum)
+
+		schema = complement("number", `json:"test" validate:"gt=0" minimum:"1"`)
+		assert.False(t, schema.ExclusiveMinimum)
+
+		schema = complement("string", `json:"test" validate:"gt=2,lt=10"`)
+		three, nine := int64(3), int64(9)
+		assert.Equal(t, &three, schema.MinLength)
+		assert.Equal(t, &nine, schema.MaxLength)
+	})
+
 	t.Run("Form Filed Name", func(t *testing.T) {
 		t.Parallel()
 
@@ -0,0 +1,169 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// fileRefs the schemas of spec files referred to by the annotations, e.g. ./common.yaml#/components/schemas/Error.
+type fileRefs struct {
+	// documents the read spec files by path
+	documents map[string]any
+
+	// names the names of the definitions of the schemas by location, and their locations by name
+	names     map[string]string
+	locations map[string]string
+}
+
+// isFileRef reports whether the type name refers to a schema of a spec file relative to the annotated file, e.g.
+// ./common.yaml#/components/schemas/Error.
+func isFileRef(typeName string) bool {
+	return strings.HasPrefix(typeName, "./") || strings.HasPrefix(typeName, "../")
+}
+
+// parseFileRef returns the reference to the definition holding the schema of a spec file referred to by ref, a path
+// relative to the directory of astFile followed by an optional JSON pointer. The schema and the ones it refers to
+// are added to the definitions, named after the file and the last token of the pointer, e.g. common.Error.
+func (parser *Parser) parseFileRef(ref string, astFile *ast.File) (*spec.Schema, error) {
+	if parser == nil || parser.packages == nil {
+		return nil, fmt.Errorf("file reference %s needs a parser", ref)
+	}
+
+	path, pointer, _ := strings.Cut(ref, "#")
+
+	if info, ok := parser.packages.files[astFile]; ok {
+		path = filepath.Join(filepath.Dir(info.Path), path)
+	}
+
+	name, err := parser.addFileRef(filepath.Clean(path), pointer)
+	if err != nil {
+		return nil, fmt.Errorf("file reference %s: %w", ref, err)
+	}
+
+	return spec.RefSchema("#/definitions/" + name), nil
+}
+
+// addFileRef adds the definition of the schema of the spec file path at pointer, once, and returns its name.
+func (parser *Parser) addFileRef(path, pointer string) (string, error) {
+	if parser.fileRefs == nil {
+		parser.fileRefs = &fileRefs{
+			documents: make(map[string]any),
+			names:     make(map[string]string),
+			locations: make(map[string]string),
+		}
+	}
+
+	refs := parser.fileRefs
+	location := path + "#" + pointer
+
+	if name, ok := refs.names[location]; ok {
+		return name, nil
+	}
+
+	document, ok := refs.documents[path]
+	if !ok {
+		if err := ReadYAMLOrJSON(path, &document); err != nil {
+			return "", err
+		}
+
+		refs.documents[path] = document
+	}
+
+	value, ok := resolvePointer(document, pointer)
+	if !ok {
+		return "", fmt.Errorf("%s has no value at %s", path, pointer)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if tokens := strings.Split(pointer, "/"); pointer != "" {
+		name += "." + unescapePointer(tokens[len(tokens)-1])
+	}
+
+	if other, ok := refs.locations[name]; ok {
+		return "", fmt.Errorf("the schemas %s and %s have the same name %s", other, location, name)
+	}
+
+	if _, ok := parser.swagger.Definitions[name]; ok {
+		return "", fmt.Errorf("the schema %s has the name of the definition %s", location, name)
+	}
+
+	// the name is reserved before the nested references, which may be recursive
+	refs.names[location], refs.locations[name] = name, location
+
+	value, err := parser.rewriteFileRefs(value, path)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	var schema spec.Schema
+	if err := json.Unmarshal(b, &schema); err != nil {
+		return "", fmt.Errorf("%s is not a schema: %w", location, err)
+	}
+
+	parser.swagger.Definitions[name] = schema
+
+	return name, nil
+}
+
+// rewriteFileRefs returns value whose references, local to the spec file path or relative to its directory, refer to
+// the definitions of their schemas. The references to URLs are kept.
+func (parser *Parser) rewriteFileRefs(value any, path string) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		rewritten := make(map[string]any, len(v))
+
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" && !isRemoteRef(ref) {
+				target, pointer, _ := strings.Cut(ref, "#")
+				if target == "" {
+					target = path
+				} else {
+					target = filepath.Join(filepath.Dir(path), target)
+				}
+
+				name, err := parser.addFileRef(filepath.Clean(target), pointer)
+				if err != nil {
+					return nil, err
+				}
+
+				rewritten[key] = "#/definitions/" + name
+
+				continue
+			}
+
+			child, err := parser.rewriteFileRefs(child, path)
+			if err != nil {
+				return nil, err
+			}
+
+			rewritten[key] = child
+		}
+
+		return rewritten, nil
+	case []any:
+		rewritten := make([]any, len(v))
+
+		for i, child := range v {
+			child, err := parser.rewriteFileRefs(child, path)
+			if err != nil {
+				return nil, err
+			}
+
+			rewritten[i] = child
+		}
+
+		return rewritten, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,75 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_FileRefs(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Failure 400 {object} ./common.yaml#/components/schemas/Error "invalid user"
+// @Failure 404 {object} ./common.yaml#/components/schemas/Error "no user"
+// @Success 200 {object} ./shared/location.json
+// @Router  /users [get]
+func GetUser(){
+}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("api", "testdata/fileref/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	responses := p.swagger.Paths.Paths["/users"].Get.Responses.StatusCodeResponses
+	assert.Equal(t, spec.RefSchema("#/definitions/common.Error"), responses[400].Schema)
+	assert.Equal(t, spec.RefSchema("#/definitions/common.Error"), responses[404].Schema)
+	assert.Equal(t, spec.RefSchema("#/definitions/location"), responses[200].Schema)
+
+	definitions := p.swagger.Definitions
+	assert.Equal(t, "#/definitions/common.Detail", definitions["common.Error"].Properties["details"].Items.Schema.Ref.String())
+
+	location := definitions["common.Detail"].Properties["location"]
+	assert.Equal(t, "#/definitions/location", location.Ref.String())
+	assert.Equal(t, spec.StringOrArray{"integer"}, definitions["location"].Properties["line"].Type)
+}
+
+func TestParser_FileRefErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		ref string
+		err string
+	}{
+		{"./missing.yaml", "testdata/fileref/missing.yaml: no such file or directory"},
+		{"./common.yaml#/components/schemas/User", "testdata/fileref/common.yaml has no value at /components/schemas/User"},
+	} {
+		src := `
+package api
+
+// @Success 200 {object} ` + tc.ref + `
+// @Router  /users [get]
+func GetUser(){
+}
+`
+		p := New()
+
+		require.NoError(t, p.packages.ParseFile("api", "testdata/fileref/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+		assert.ErrorContains(t, err, "file reference "+tc.ref+": ")
+		assert.ErrorContains(t, err, tc.err)
+	}
+}
@@ -0,0 +1,106 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// exampleFileTag refers to the value of a JSON fixture file which is the example of a field, e.g.
+// exampleFile:"testdata/user.json#/name", so that the examples are the fixtures of the tests.
+const exampleFileTag = "exampleFile"
+
+// fixtureExample returns the value of a fixture file referred to by ref, a path relative to the directory of file,
+// the file of the struct of the field, followed by a JSON pointer, e.g. testdata/user.json#/emails/0. The whole file
+// is the value without pointer.
+func (parser *Parser) fixtureExample(ref string, file *ast.File) (any, error) {
+	path, pointer, _ := strings.Cut(ref, "#")
+	path = parser.exampleFilePath(path, file)
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", exampleFileTag, ref, err)
+	}
+
+	var value any
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, fmt.Errorf("%s %s: cannot read %s: %w", exampleFileTag, ref, path, err)
+	}
+
+	value, ok := resolvePointer(value, pointer)
+	if !ok {
+		return nil, fmt.Errorf("%s %s: %s has no value at %s", exampleFileTag, ref, path, pointer)
+	}
+
+	return value, nil
+}
+
+// checkExampleType checks that the JSON value of a fixture example is of schemaType, null being the example of any
+// type.
+func checkExampleType(value any, schemaType string) error {
+	var ok bool
+
+	switch schemaType {
+	case STRING:
+		_, ok = value.(string)
+	case INTEGER:
+		number, isNumber := value.(float64)
+		ok = isNumber && number == math.Trunc(number)
+	case NUMBER:
+		_, ok = value.(float64)
+	case BOOLEAN:
+		_, ok = value.(bool)
+	case ARRAY:
+		_, ok = value.([]any)
+	case OBJECT:
+		_, ok = value.(map[string]any)
+	default:
+		return nil
+	}
+
+	if !ok && value != nil {
+		return fmt.Errorf("the example %v is not of type %s", value, schemaType)
+	}
+
+	return nil
+}
+
+// resolvePointer returns the value of a JSON document at a JSON pointer like /emails/0.
+func resolvePointer(value any, pointer string) (any, bool) {
+	if pointer == "" {
+		return value, true
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescapePointer(token)
+
+		switch v := value.(type) {
+		case map[string]any:
+			child, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+
+			value = child
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+
+			value = v[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
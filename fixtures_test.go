@@ -0,0 +1,113 @@
+package swag
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_FixtureExamples(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name    string   ` + "`json:\"name\" exampleFile:\"user.json#/name\"`" + `
+	Age     int      ` + "`json:\"age\" exampleFile:\"user.json#/age\"`" + `
+	Email   string   ` + "`json:\"email\" exampleFile:\"user.json#/emails/1\"`" + `
+	Emails  []string ` + "`json:\"emails\" exampleFile:\"user.json#/emails\"`" + `
+	Address any      ` + "`json:\"address\" exampleFile:\"user.json#/address\"`" + `
+	Link    string   ` + "`json:\"link\" example:\"file:///home/ada\"`" + `
+}
+
+// @Success 200 {object} User
+// @Router  /users [get]
+func Users(){
+}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("api", "testdata/fixtures/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	properties := p.swagger.Definitions["api.User"].Properties
+	assert.Equal(t, "Ada Lovelace", properties["name"].Example)
+	assert.Equal(t, float64(36), properties["age"].Example)
+	assert.Equal(t, "countess@example.com", properties["email"].Example)
+	assert.Equal(t, []any{"ada@example.com", "countess@example.com"}, properties["emails"].Example)
+	assert.Equal(t, map[string]any{"city": "London"}, properties["address"].Example)
+	assert.Equal(t, "file:///home/ada", properties["link"].Example)
+}
+
+func TestParser_FixtureExampleErrors(t *testing.T) {
+	t.Parallel()
+
+	for tag, messages := range map[string][]string{
+		`exampleFile:"user.json#/phone"`: {
+			"[name]: exampleFile user.json#/phone: ",
+			filepath.Join("testdata", "fixtures", "user.json") + " has no value at /phone",
+		},
+		`exampleFile:"missing.json#/name"`: {
+			"[name]: exampleFile missing.json#/name: ",
+			filepath.Join("testdata", "fixtures", "missing.json") + ": no such file or directory",
+		},
+		`exampleFile:"user.json#/age"`: {
+			"[name]: exampleFile user.json#/age: the example 36 is not of type string",
+		},
+		`example:"Ada" exampleFile:"user.json#/name"`: {
+			"[name]: a field can not have both the example and the exampleFile tags",
+		},
+	} {
+		src := `
+package api
+
+type User struct {
+	Name string ` + "`json:\"name\" " + tag + "`" + `
+}
+
+// @Success 200 {object} User
+// @Router  /users [get]
+func Users(){
+}
+`
+		p := New()
+
+		require.NoError(t, p.packages.ParseFile("api", "testdata/fixtures/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+		require.Error(t, err, tag)
+
+		for _, message := range messages {
+			assert.Contains(t, err.Error(), message, tag)
+		}
+	}
+}
+
+func TestResolvePointer(t *testing.T) {
+	t.Parallel()
+
+	document := map[string]any{"a/b": []any{"x", map[string]any{"c~d": 1}}}
+
+	value, ok := resolvePointer(document, "/a~1b/1/c~0d")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	value, ok = resolvePointer(document, "")
+	assert.True(t, ok)
+	assert.Equal(t, document, value)
+
+	for _, pointer := range []string{"a", "/a~1b/2", "/a~1b/x", "/a~1b/0/c"} {
+		_, ok = resolvePointer(document, pointer)
+		assert.False(t, ok, pointer)
+	}
+}
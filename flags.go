@@ -0,0 +1,124 @@
+package swag
+
+import (
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// flagsDirective marks an integer type whose constants are bits combined with bitwise OR:
+//
+//	//swag:flags
+//	type Permission int
+//
+// Its schema is an array of the single bit constants, with the BitmaskExtension.
+const flagsDirective = "//swag:flags"
+
+// BitmaskExtension marks the arrays of the //swag:flags types, whose values are ORed into a single integer.
+const BitmaskExtension = "x-bitmask"
+
+// isFlagsType reports whether the type of typeSpecDef is marked by a //swag:flags comment.
+func isFlagsType(typeSpecDef *TypeSpecDef) bool {
+	groups := []*ast.CommentGroup{typeSpecDef.TypeSpec.Doc, typeSpecDef.TypeSpec.Comment}
+
+	// the comment of a type declared alone belongs to its declaration
+	if typeSpecDef.File != nil {
+		for _, decl := range typeSpecDef.File.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && !genDecl.Lparen.IsValid() &&
+				len(genDecl.Specs) == 1 && genDecl.Specs[0] == typeSpecDef.TypeSpec {
+				groups = append(groups, genDecl.Doc)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, comment := range group.List {
+			if strings.TrimSpace(comment.Text) == flagsDirective {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// flagsSchema turns the enum of the integer definition of a //swag:flags type into an array of its single bit
+// constants. The other constants are combinations of these, they are left out.
+func flagsSchema(definition *spec.Schema) *spec.Schema {
+	items := spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:   definition.Type,
+			Format: definition.Format,
+		},
+	}
+
+	varNames, _ := definition.Extensions[enumVarNamesExtension].([]string)
+	comments, _ := definition.Extensions[enumCommentsExtension].(map[string]string)
+
+	var (
+		itemVarNames     []string
+		itemComments     = make(map[string]string)
+		itemDescriptions []string
+	)
+
+	for i, value := range definition.Enum {
+		if !isSingleBit(value) {
+			continue
+		}
+
+		items.Enum = append(items.Enum, value)
+
+		if i < len(varNames) {
+			itemVarNames = append(itemVarNames, varNames[i])
+
+			if comment, ok := comments[varNames[i]]; ok {
+				itemComments[varNames[i]] = comment
+			}
+
+			itemDescriptions = append(itemDescriptions, comments[varNames[i]])
+		}
+	}
+
+	if len(itemVarNames) > 0 {
+		items.AddExtension(enumVarNamesExtension, itemVarNames)
+	}
+
+	if len(itemComments) > 0 {
+		items.AddExtension(enumCommentsExtension, itemComments)
+		items.AddExtension(enumDescriptionsExtension, itemDescriptions)
+	}
+
+	schema := spec.ArrayProperty(&items)
+	schema.Description = definition.Description
+	schema.AddExtension(BitmaskExtension, true)
+
+	return schema
+}
+
+// isSingleBit reports whether value is an integer with a single bit set.
+func isSingleBit(value any) bool {
+	v := reflect.ValueOf(value)
+
+	var bits uint64
+
+	switch {
+	case v.CanInt():
+		if v.Int() <= 0 {
+			return false
+		}
+
+		bits = uint64(v.Int())
+	case v.CanUint():
+		bits = v.Uint()
+	default:
+		return false
+	}
+
+	return bits != 0 && bits&(bits-1) == 0
+}
@@ -0,0 +1,73 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_FlagsType(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// Permission the permissions of a user.
+//
+//swag:flags
+type Permission int
+
+const (
+	Read    Permission = 1 << iota // may read
+	Write
+	Delete
+	ReadWrite = Read | Write
+)
+
+// Level is not a flags type.
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+type User struct {
+	Permissions Permission ` + "`json:\"permissions\"`" + `
+	Level       Level      ` + "`json:\"level\"`" + `
+}
+
+// @Success 200 {object} User
+// @Router  /users [get]
+func Users(){
+}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	b, err := json.Marshal(p.swagger.Definitions["api.Permission"])
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"type": "array",
+		"items": {
+			"type": "integer",
+			"enum": [1, 2, 4],
+			"x-enum-comments": {"Read": "may read"},
+			"x-enum-descriptions": ["may read", "", ""],
+			"x-enum-varnames": ["Read", "Write", "Delete"]
+		},
+		"x-bitmask": true
+	}`, string(b))
+
+	assert.Equal(t, "integer", p.swagger.Definitions["api.Level"].Type[0])
+	assert.NotContains(t, p.swagger.Definitions["api.Level"].Extensions, BitmaskExtension)
+}
@@ -0,0 +1,463 @@
+package swag
+
+import (
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// FuzzCase a request of a fuzz corpus: a valid request of an operation but for one value, set at a boundary of
+// its schema, of a wrong type, or left out.
+type FuzzCase struct {
+	// Operation the method and path of the operation, e.g. PUT /pets/{id}
+	Operation string `json:"operation"`
+
+	// OperationID the ID of the operation, if any
+	OperationID string `json:"operationId,omitempty"`
+
+	// Name what the case changes, e.g. body.name maxLength+1
+	Name string `json:"name"`
+
+	// Valid whether the request conforms to the document, boundaries are valid when they are inside the limits
+	Valid bool `json:"valid"`
+
+	// Parameters the values of the parameters by location, path, query, header or formData, then by name
+	Parameters map[string]map[string]any `json:"parameters,omitempty"`
+
+	// Body the body of the request
+	Body any `json:"body,omitempty"`
+}
+
+// fuzzValue a change of a value of a request.
+type fuzzValue struct {
+	name   string
+	valid  bool
+	value  any
+	remove bool
+}
+
+// FuzzCorpus returns the cases of a fuzz corpus of the operations of doc, in the order of their paths and methods:
+// the boundary values of the lengths, sizes, ranges and enums of the parameters and of the properties of the
+// bodies, values of wrong types and missing required values.
+func FuzzCorpus(doc *spec.Swagger) []FuzzCase {
+	var cases []FuzzCase
+
+	if doc.Paths == nil {
+		return nil
+	}
+
+	for _, path := range sortedKeys(doc.Paths.Paths) {
+		item := doc.Paths.Paths[path]
+
+		for _, method := range sortedMethods() {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil {
+				continue
+			}
+
+			cases = append(cases, operationFuzzCases(doc, method+" "+path, op,
+				append(append([]spec.Parameter{}, item.Parameters...), op.Parameters...))...)
+		}
+	}
+
+	return cases
+}
+
+// operationFuzzCases returns the cases of an operation, changing one parameter or property at a time.
+func operationFuzzCases(doc *spec.Swagger, operation string, op *spec.Operation, parameters []spec.Parameter) []FuzzCase {
+	var (
+		cases   []FuzzCase
+		params  []spec.Parameter
+		body    any
+		schemas = make(map[string]*spec.Schema)
+	)
+
+	for _, param := range parameters {
+		if ref := param.Ref.String(); ref != "" {
+			resolved, ok := doc.Parameters[strings.TrimPrefix(ref, "#/parameters/")]
+			if !ok {
+				continue
+			}
+
+			param = resolved
+		}
+
+		if param.In == "formData" && isFileParameter(&param) {
+			continue
+		}
+
+		params = append(params, param)
+	}
+
+	baseline := func() (map[string]map[string]any, any) {
+		values := make(map[string]map[string]any)
+
+		for _, param := range params {
+			if param.In == "body" {
+				continue
+			}
+
+			if values[param.In] == nil {
+				values[param.In] = make(map[string]any)
+			}
+
+			values[param.In][param.Name] = schemaSample(doc.Definitions, fuzzParameterSchema(&param), 0)
+		}
+
+		return values, copyValue(body)
+	}
+
+	for _, param := range params {
+		schemas[param.In+"."+param.Name] = fuzzParameterSchema(&param)
+
+		if param.In == "body" && param.Schema != nil {
+			body = schemaSample(doc.Definitions, param.Schema, 0)
+		}
+	}
+
+	add := func(name string, change fuzzValue, set func(values map[string]map[string]any, body any) any) {
+		values, body := baseline()
+		body = set(values, body)
+
+		cases = append(cases, FuzzCase{
+			Operation:   operation,
+			OperationID: op.ID,
+			Name:        strings.TrimSpace(name + " " + change.name),
+			Valid:       change.valid,
+			Parameters:  values,
+			Body:        body,
+		})
+	}
+
+	for _, param := range params {
+		if param.In == "body" {
+			if param.Schema == nil {
+				continue
+			}
+
+			for _, change := range bodyFuzzValues(doc.Definitions, param.Schema, nil, 0) {
+				add("body"+change.path, change.fuzzValue, func(_ map[string]map[string]any, body any) any {
+					return setValue(body, change.pointer, change.fuzzValue)
+				})
+			}
+
+			continue
+		}
+
+		changes := schemaFuzzValues(doc.Definitions, schemas[param.In+"."+param.Name])
+		if param.Required {
+			changes = append(changes, fuzzValue{name: "missing", remove: true})
+		}
+
+		for _, change := range changes {
+			add(param.In+"."+param.Name, change, func(values map[string]map[string]any, body any) any {
+				if change.remove {
+					delete(values[param.In], param.Name)
+				} else {
+					values[param.In][param.Name] = change.value
+				}
+
+				return body
+			})
+		}
+	}
+
+	return cases
+}
+
+// bodyFuzzValue a change of a value inside a body, at a path of property names.
+type bodyFuzzValue struct {
+	fuzzValue
+	path    string
+	pointer []string
+}
+
+// bodyFuzzValues returns the changes of the value of schema and of its properties, at pointer.
+func bodyFuzzValues(definitions spec.Definitions, schema *spec.Schema, pointer []string, depth int) []bodyFuzzValue {
+	schema = resolveSchema(definitions, schema)
+	if schema == nil || depth >= maxSampleDepth {
+		return nil
+	}
+
+	path := ""
+	for _, name := range pointer {
+		path += "." + name
+	}
+
+	var changes []bodyFuzzValue
+
+	for _, change := range schemaFuzzValues(definitions, schema) {
+		changes = append(changes, bodyFuzzValue{fuzzValue: change, path: path, pointer: pointer})
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		child := append(append([]string{}, pointer...), name)
+
+		if containsString(schema.Required, name) {
+			changes = append(changes, bodyFuzzValue{
+				fuzzValue: fuzzValue{name: "missing", remove: true},
+				path:      path + "." + name,
+				pointer:   child,
+			})
+		}
+
+		changes = append(changes, bodyFuzzValues(definitions, &property, child, depth+1)...)
+	}
+
+	return changes
+}
+
+// schemaFuzzValues returns the boundary values of schema and a value of a wrong type.
+func schemaFuzzValues(definitions spec.Definitions, schema *spec.Schema) []fuzzValue {
+	schema = resolveSchema(definitions, schema)
+	if schema == nil {
+		return nil
+	}
+
+	var changes []fuzzValue
+
+	if len(schema.Enum) > 0 {
+		changes = append(changes,
+			fuzzValue{name: "enum first", valid: true, value: schema.Enum[0]},
+			fuzzValue{name: "enum last", valid: true, value: schema.Enum[len(schema.Enum)-1]},
+			fuzzValue{name: "not in enum", value: notInEnum(schema)},
+		)
+	}
+
+	switch {
+	case schema.Type.Contains(STRING):
+		if schema.MinLength != nil {
+			changes = append(changes, fuzzValue{name: "minLength", valid: true, value: strings.Repeat("a", int(*schema.MinLength))})
+			if *schema.MinLength > 0 {
+				changes = append(changes, fuzzValue{name: "minLength-1", value: strings.Repeat("a", int(*schema.MinLength)-1)})
+			}
+		}
+
+		if schema.MaxLength != nil {
+			changes = append(changes,
+				fuzzValue{name: "maxLength", valid: true, value: strings.Repeat("a", int(*schema.MaxLength))},
+				fuzzValue{name: "maxLength+1", value: strings.Repeat("a", int(*schema.MaxLength)+1)},
+			)
+		}
+
+		changes = append(changes, fuzzValue{name: "wrong type", value: 0})
+	case schema.Type.Contains(INTEGER), schema.Type.Contains(NUMBER):
+		step := 1.0
+		if schema.Type.Contains(NUMBER) && !schema.Type.Contains(INTEGER) {
+			step = 0.5
+		}
+
+		if schema.Minimum != nil {
+			changes = append(changes,
+				fuzzValue{name: "minimum", valid: !schema.ExclusiveMinimum, value: *schema.Minimum},
+				fuzzValue{name: "minimum-" + formatValue(step), value: *schema.Minimum - step},
+			)
+		}
+
+		if schema.Maximum != nil {
+			changes = append(changes,
+				fuzzValue{name: "maximum", valid: !schema.ExclusiveMaximum, value: *schema.Maximum},
+				fuzzValue{name: "maximum+" + formatValue(step), value: *schema.Maximum + step},
+			)
+		}
+
+		if schema.Type.Contains(INTEGER) {
+			changes = append(changes, fuzzValue{name: "not an integer", value: 0.5})
+		}
+
+		changes = append(changes, fuzzValue{name: "wrong type", value: "a"})
+	case schema.Type.Contains(BOOLEAN):
+		changes = append(changes, fuzzValue{name: "wrong type", value: "a"})
+	case schema.Type.Contains(ARRAY):
+		var item any = STRING
+		if schema.Items != nil && schema.Items.Schema != nil {
+			item = schemaSample(definitions, schema.Items.Schema, 0)
+		}
+
+		items := func(n int64) []any {
+			values := make([]any, n)
+			for i := range values {
+				values[i] = copyValue(item)
+			}
+
+			return values
+		}
+
+		if schema.MinItems != nil {
+			changes = append(changes, fuzzValue{name: "minItems", valid: true, value: items(*schema.MinItems)})
+			if *schema.MinItems > 0 {
+				changes = append(changes, fuzzValue{name: "minItems-1", value: items(*schema.MinItems - 1)})
+			}
+		}
+
+		if schema.MaxItems != nil {
+			changes = append(changes,
+				fuzzValue{name: "maxItems", valid: true, value: items(*schema.MaxItems)},
+				fuzzValue{name: "maxItems+1", value: items(*schema.MaxItems + 1)},
+			)
+		}
+
+		changes = append(changes, fuzzValue{name: "wrong type", value: map[string]any{}})
+	case schema.Type.Contains(OBJECT) || len(schema.Properties) > 0:
+		changes = append(changes, fuzzValue{name: "wrong type", value: []any{}})
+	}
+
+	return changes
+}
+
+// notInEnum returns a value of the type of schema which is not one of its enum values.
+func notInEnum(schema *spec.Schema) any {
+	if schema.Type.Contains(INTEGER) || schema.Type.Contains(NUMBER) {
+		biggest := 0.0
+
+		for _, value := range schema.Enum {
+			if number, ok := normalizeJSON(value).(float64); ok && number > biggest {
+				biggest = number
+			}
+		}
+
+		return biggest + 1
+	}
+
+	value := "not_in_enum"
+	for containsValue(schema.Enum, value) {
+		value += "_"
+	}
+
+	return value
+}
+
+// containsValue reports whether values has value.
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsString reports whether values has value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveSchema returns the definition schema refers to, or schema.
+func resolveSchema(definitions spec.Definitions, schema *spec.Schema) *spec.Schema {
+	for depth := 0; schema != nil && depth < maxSampleDepth; depth++ {
+		name, ok := definitionName(schema)
+		if !ok {
+			return schema
+		}
+
+		definition, found := definitions[name]
+		if !found {
+			return nil
+		}
+
+		schema = &definition
+	}
+
+	return schema
+}
+
+// fuzzParameterSchema returns the schema of a parameter, built from its simple schema, items and examples but for
+// the body.
+func fuzzParameterSchema(param *spec.Parameter) *spec.Schema {
+	if param.Schema != nil {
+		return param.Schema
+	}
+
+	return simpleSchema(&param.SimpleSchema, &param.CommonValidations)
+}
+
+// simpleSchema returns the schema of the type and validations of a non body parameter or of its items.
+func simpleSchema(simple *spec.SimpleSchema, validations *spec.CommonValidations) *spec.Schema {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Format:           simple.Format,
+			Default:          simple.Default,
+			Maximum:          validations.Maximum,
+			ExclusiveMaximum: validations.ExclusiveMaximum,
+			Minimum:          validations.Minimum,
+			ExclusiveMinimum: validations.ExclusiveMinimum,
+			MaxLength:        validations.MaxLength,
+			MinLength:        validations.MinLength,
+			MaxItems:         validations.MaxItems,
+			MinItems:         validations.MinItems,
+			Enum:             validations.Enum,
+		},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{Example: simple.Example},
+	}
+
+	if simple.Type != "" {
+		schema.Type = spec.StringOrArray{simple.Type}
+	}
+
+	if simple.Items != nil {
+		schema.Items = &spec.SchemaOrArray{Schema: simpleSchema(&simple.Items.SimpleSchema, &simple.Items.CommonValidations)}
+	}
+
+	return schema
+}
+
+// setValue returns body with the change applied at pointer, body is a copy of the baseline.
+func setValue(body any, pointer []string, change fuzzValue) any {
+	if len(pointer) == 0 {
+		return change.value
+	}
+
+	object, ok := body.(map[string]any)
+	if !ok {
+		return body
+	}
+
+	if len(pointer) == 1 {
+		if change.remove {
+			delete(object, pointer[0])
+		} else {
+			object[pointer[0]] = change.value
+		}
+
+		return object
+	}
+
+	child, ok := object[pointer[0]]
+	if !ok {
+		child = map[string]any{}
+	}
+
+	object[pointer[0]] = setValue(child, pointer[1:], change)
+
+	return object
+}
+
+// copyValue returns a deep copy of a JSON value.
+func copyValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(value))
+		for name, v := range value {
+			copied[name] = copyValue(v)
+		}
+
+		return copied
+	case []any:
+		copied := make([]any, len(value))
+		for i, v := range value {
+			copied[i] = copyValue(v)
+		}
+
+		return copied
+	default:
+		return value
+	}
+}
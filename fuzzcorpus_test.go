@@ -0,0 +1,88 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzCorpus(t *testing.T) {
+	t.Parallel()
+
+	var doc spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+    "swagger": "2.0",
+    "paths": {
+        "/pets/{id}": {
+            "put": {
+                "operationId": "updatePet",
+                "parameters": [
+                    {"name": "id", "in": "path", "required": true, "type": "integer", "minimum": 1},
+                    {"name": "status", "in": "query", "type": "string", "enum": ["available", "sold"]},
+                    {"name": "photo", "in": "formData", "type": "file"},
+                    {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "Pet": {
+            "type": "object",
+            "required": ["name"],
+            "properties": {
+                "name": {"type": "string", "maxLength": 3, "example": "rex"},
+                "tags": {"type": "array", "maxItems": 1, "items": {"type": "string"}}
+            }
+        }
+    }
+}`), &doc))
+
+	cases := FuzzCorpus(&doc)
+
+	names := make(map[string]bool)
+	for _, fuzzCase := range cases {
+		assert.Equal(t, "PUT /pets/{id}", fuzzCase.Operation)
+		assert.Equal(t, "updatePet", fuzzCase.OperationID)
+
+		names[fuzzCase.Name] = fuzzCase.Valid
+	}
+
+	assert.Equal(t, map[string]bool{
+		"body wrong type":          false,
+		"body.name missing":        false,
+		"body.name maxLength":      true,
+		"body.name maxLength+1":    false,
+		"body.name wrong type":     false,
+		"body.tags maxItems":       true,
+		"body.tags maxItems+1":     false,
+		"body.tags wrong type":     false,
+		"path.id minimum":          true,
+		"path.id minimum-1":        false,
+		"path.id not an integer":   false,
+		"path.id wrong type":       false,
+		"path.id missing":          false,
+		"query.status enum first":  true,
+		"query.status enum last":   true,
+		"query.status not in enum": false,
+		"query.status wrong type":  false,
+	}, names)
+
+	for _, fuzzCase := range cases {
+		switch fuzzCase.Name {
+		case "body.name maxLength+1":
+			assert.Equal(t, map[string]any{"name": "aaaa", "tags": []any{STRING}}, fuzzCase.Body)
+			assert.Equal(t, map[string]map[string]any{"path": {"id": float64(1)}, "query": {"status": "available"}},
+				fuzzCase.Parameters)
+		case "body.name missing":
+			assert.Equal(t, map[string]any{"tags": []any{STRING}}, fuzzCase.Body)
+		case "path.id missing":
+			assert.Equal(t, map[string]map[string]any{"path": {}, "query": {"status": "available"}}, fuzzCase.Parameters)
+			assert.Equal(t, map[string]any{"name": "rex", "tags": []any{STRING}}, fuzzCase.Body)
+		case "query.status not in enum":
+			assert.Equal(t, "not_in_enum", fuzzCase.Parameters["query"]["status"])
+		}
+	}
+}
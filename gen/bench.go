@@ -0,0 +1,69 @@
+package gen
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// BenchResult is the time and memory a parse of the sources took, measured by Bench.
+type BenchResult struct {
+	Runs int
+	Min  time.Duration
+	Max  time.Duration
+	Mean time.Duration
+
+	// BytesPerRun and AllocsPerRun are the mean memory allocated by a run
+	BytesPerRun  uint64
+	AllocsPerRun uint64
+}
+
+// String returns the result in a single line.
+func (r *BenchResult) String() string {
+	return fmt.Sprintf("%d runs, mean %s, min %s, max %s, %d B/run, %d allocs/run",
+		r.Runs, r.Mean, r.Min, r.Max, r.BytesPerRun, r.AllocsPerRun)
+}
+
+// Bench parses the sources of config runs times like Build, without writing any file, and measures the runs.
+func (g *Gen) Bench(ctx context.Context, config *Config, runs int) (*BenchResult, error) {
+	if runs < 1 {
+		return nil, fmt.Errorf("runs must be positive, got %d", runs)
+	}
+
+	result := BenchResult{Runs: runs}
+
+	var total time.Duration
+
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+
+		if _, err := g.parse(ctx, config); err != nil {
+			return nil, err
+		}
+
+		elapsed := time.Since(start)
+		total += elapsed
+
+		if i == 0 || elapsed < result.Min {
+			result.Min = elapsed
+		}
+
+		if elapsed > result.Max {
+			result.Max = elapsed
+		}
+	}
+
+	runtime.ReadMemStats(&after)
+
+	result.Mean = total / time.Duration(runs)
+	result.BytesPerRun = (after.TotalAlloc - before.TotalAlloc) / uint64(runs)
+	result.AllocsPerRun = (after.Mallocs - before.Mallocs) / uint64(runs)
+
+	return &result, nil
+}
@@ -0,0 +1,72 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag/openapi3"
+)
+
+// DefaultConfigMapName the name of the ConfigMap of the configmap output type when Config.ConfigMapName is empty.
+const DefaultConfigMapName = "swagger-docs"
+
+func (g *Gen) writeConfigMapSwagger(config *Config, swagger *spec.Swagger) error {
+	return g.writeConfigMap(config, "swagger.json", swagger)
+}
+
+func (g *Gen) writeConfigMapOpenAPI(config *Config, swagger *spec.Swagger) error {
+	doc, err := openapi3.NewConverter().Convert(swagger)
+	if err != nil {
+		return err
+	}
+
+	return g.writeConfigMap(config, "openapi.json", doc)
+}
+
+// writeConfigMap writes configmap.yaml, a Kubernetes ConfigMap holding document under the key name, e.g. to serve
+// the document from a sidecar.
+func (g *Gen) writeConfigMap(config *Config, name string, document any) error {
+	b, err := g.marshalDocument(config, document)
+	if err != nil {
+		return err
+	}
+
+	configMapName := config.ConfigMapName
+	if configMapName == "" {
+		configMapName = DefaultConfigMapName
+	}
+
+	// the strings of JSON are strings of YAML
+	quote := func(s string) string {
+		quoted, _ := json.Marshal(s)
+
+		return string(quoted)
+	}
+
+	var manifest bytes.Buffer
+
+	manifest.WriteString("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + quote(configMapName) + "\n")
+
+	if config.ConfigMapNamespace != "" {
+		manifest.WriteString("  namespace: " + quote(config.ConfigMapNamespace) + "\n")
+	}
+
+	manifest.WriteString("data:\n  " + name + ": |\n")
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n") {
+		manifest.WriteString("    " + line + "\n")
+	}
+
+	configMapFileName := path.Join(config.OutputDir, outputFileName(config, "configmap.yaml"))
+
+	if err := g.writeFile(config, manifest.Bytes(), configMapFileName); err != nil {
+		return err
+	}
+
+	g.debug.Printf("create configmap.yaml at %+v", configMapFileName)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/openapi3"
+)
+
+// Versions of the documents converted by Convert.
+const (
+	Swagger2Version = "swagger2"
+	OpenAPI3Version = "openapi3"
+)
+
+// Convert reads the document of version from in inputFile, in JSON or YAML, and writes it in version to, with
+// the same mapping as the generated documents. It writes to outputFile, in YAML when its extension is .yaml or
+// .yml, or in JSON to the standard output when outputFile is empty.
+func (g *Gen) Convert(from, to, inputFile, outputFile string) error {
+	if from != Swagger2Version || to != OpenAPI3Version {
+		return fmt.Errorf("unsupported conversion from %q to %q, expected from %s to %s",
+			from, to, Swagger2Version, OpenAPI3Version)
+	}
+
+	swagger, err := readSwagger(inputFile)
+	if err != nil {
+		return err
+	}
+
+	doc, err := openapi3.NewConverter().Convert(swagger)
+	if err != nil {
+		return err
+	}
+
+	return g.writeDocumentFile(doc, outputFile)
+}
+
+// readSwagger reads the Swagger 2.0 document of a JSON or YAML file.
+func readSwagger(inputFile string) (*spec.Swagger, error) {
+	b, err := os.ReadFile(inputFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSwagger(b, inputFile)
+}
+
+// parseSwagger parses the Swagger 2.0 document b, in JSON or YAML, of inputFile.
+func parseSwagger(b []byte, inputFile string) (*spec.Swagger, error) {
+	var swagger spec.Swagger
+	if err := swag.UnmarshalYAMLOrJSON(b, inputFile, &swagger); err != nil {
+		return nil, err
+	}
+
+	if swagger.Swagger != "2.0" {
+		return nil, fmt.Errorf("%s is not a Swagger 2.0 document", inputFile)
+	}
+
+	return &swagger, nil
+}
+
+// writeDocumentFile writes doc to outputFile, in YAML when its extension is .yaml or .yml, or in JSON to the
+// standard output when outputFile is empty.
+func (g *Gen) writeDocumentFile(doc any, outputFile string) error {
+	b, err := g.jsonIndent(doc)
+	if err != nil {
+		return err
+	}
+
+	config := &Config{}
+	if outputFile == "" {
+		config.Output = os.Stdout
+	}
+
+	if strings.HasSuffix(outputFile, ".yaml") || strings.HasSuffix(outputFile, ".yml") {
+		b, err = g.jsonToYAML(b)
+		if err != nil {
+			return fmt.Errorf("cannot convert json to yaml: %w", err)
+		}
+	}
+
+	if err := g.writeFile(config, b, outputFile); err != nil {
+		return err
+	}
+
+	if outputFile != "" {
+		g.debug.Printf("create %s", outputFile)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package gen
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// CRD parses the sources of config and returns the structural schema of the type typeName, e.g. v1.CronTabSpec,
+// in YAML, for the openAPIV3Schema of a Kubernetes custom resource definition.
+func (g *Gen) CRD(config *Config, typeName string) ([]byte, error) {
+	p, err := g.parse(context.Background(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := p.CRDSchema(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.jsonToYAML(b)
+}
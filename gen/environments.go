@@ -0,0 +1,130 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// Environment a deployment of the API, e.g. dev or prod, whose document differs from the generated one only by its
+// host, base path and schemes.
+type Environment struct {
+	// Name the name of the environment in the names of its files, e.g. swagger.dev.json
+	Name string `json:"name"`
+
+	// Host the host of the environment, the generated one when empty
+	Host string `json:"host"`
+
+	// BasePath the base path of the environment, the generated one when empty
+	BasePath string `json:"basePath"`
+
+	// Schemes the schemes of the environment, the generated ones when empty
+	Schemes []string `json:"schemes"`
+}
+
+// environmentDocuments returns the documents of the environments of config, for each of documents: the deep copies
+// of their swagger with the host, base path and schemes of the environment, and configs writing them to their files.
+func environmentDocuments(config *Config, documents []instanceDocument) ([]instanceDocument, error) {
+	result := make([]instanceDocument, 0, len(config.Environments)*len(documents))
+
+	for _, environment := range config.Environments {
+		for _, document := range documents {
+			doc, err := copySwagger(document.swagger)
+			if err != nil {
+				return nil, err
+			}
+
+			if environment.Host != "" {
+				doc.Host = environment.Host
+			}
+
+			if environment.BasePath != "" {
+				doc.BasePath = environment.BasePath
+			}
+
+			if len(environment.Schemes) > 0 {
+				doc.Schemes = environment.Schemes
+			}
+
+			environmentConfig := *document.config
+			environmentConfig.environment = environment.Name
+
+			result = append(result, instanceDocument{config: &environmentConfig, swagger: doc})
+		}
+	}
+
+	return result, nil
+}
+
+// copySwagger returns a deep copy of swagger, which shares no schema, path or extension with it.
+func copySwagger(swagger *spec.Swagger) (*spec.Swagger, error) {
+	b, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc spec.Swagger
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// environmentFileName inserts the environment of config before the extension of name, e.g. swagger.dev.json.
+func environmentFileName(config *Config, name string) string {
+	if config.environment == "" {
+		return name
+	}
+
+	extension := filepath.Ext(name)
+
+	return strings.TrimSuffix(name, extension) + "." + config.environment + extension
+}
+
+// withEnvironmentsFile returns config with the environments of its EnvironmentsFile added to its Environments,
+// leaving config unchanged, after checking their names.
+func withEnvironmentsFile(config *Config) (*Config, error) {
+	if config.EnvironmentsFile != "" {
+		environments, err := readEnvironments(config.EnvironmentsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		copied := *config
+		copied.Environments = append(append([]Environment{}, config.Environments...), environments...)
+		config = &copied
+	}
+
+	names := make(map[string]bool)
+
+	for _, environment := range config.Environments {
+		if environment.Name == "" || environment.Name != safeFileName(environment.Name) ||
+			strings.Contains(environment.Name, ".") {
+			return nil, fmt.Errorf("environment name %q can not be used in file names", environment.Name)
+		}
+
+		if names[environment.Name] {
+			return nil, fmt.Errorf("environment name %s is used several times", environment.Name)
+		}
+
+		names[environment.Name] = true
+	}
+
+	return config, nil
+}
+
+// readEnvironments reads the environments of a JSON or YAML file, a list of objects with a name, a host, a basePath
+// and schemes.
+func readEnvironments(name string) ([]Environment, error) {
+	var environments []Environment
+	if err := swag.ReadYAMLOrJSON(name, &environments); err != nil {
+		return nil, err
+	}
+
+	return environments, nil
+}
@@ -0,0 +1,53 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// FuzzCorpus reads the Swagger 2.0 document of inputFile, in JSON or YAML, and writes the cases of its fuzz
+// corpus, see swag.FuzzCorpus, to outputDir: a directory by operation, named by its ID or its method and path,
+// holding a JSON file by case, e.g. updatePet/003_body.name_maxLength_1.json.
+func (g *Gen) FuzzCorpus(inputFile, outputDir string) error {
+	swagger, err := readSwagger(inputFile)
+	if err != nil {
+		return err
+	}
+
+	cases := swag.FuzzCorpus(swagger)
+	counts := make(map[string]int)
+
+	for _, fuzzCase := range cases {
+		name := fuzzCase.OperationID
+		if name == "" {
+			name = strings.ToLower(strings.Replace(fuzzCase.Operation, " /", "_", 1))
+		}
+
+		dir := filepath.Join(outputDir, safeFileName(name))
+		if counts[dir] == 0 {
+			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+				return err
+			}
+		}
+
+		counts[dir]++
+
+		b, err := g.jsonIndent(fuzzCase)
+		if err != nil {
+			return err
+		}
+
+		fileName := filepath.Join(dir, fmt.Sprintf("%03d_%s.json", counts[dir], safeFileName(fuzzCase.Name)))
+		if err := g.writeFile(&Config{}, b, fileName); err != nil {
+			return err
+		}
+	}
+
+	g.debug.Printf("create %d fuzz cases of %d operations in %s", len(cases), len(counts), outputDir)
+
+	return nil
+}
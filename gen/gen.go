@@ -3,7 +3,11 @@ package gen
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/format"
 	"io"
@@ -11,12 +15,16 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/go-openapi/spec"
 	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/openapi3"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"sigs.k8s.io/yaml"
@@ -31,11 +39,12 @@ type genTypeWriter func(*Config, *spec.Swagger) error
 
 // Gen presents a generate tool for swag.
 type Gen struct {
-	json          func(data any) ([]byte, error)
-	jsonIndent    func(data any) ([]byte, error)
-	jsonToYAML    func(data []byte) ([]byte, error)
-	outputTypeMap map[string]genTypeWriter
-	debug         Debugger
+	json           func(data any) ([]byte, error)
+	jsonIndent     func(data any) ([]byte, error)
+	jsonToYAML     func(data []byte) ([]byte, error)
+	outputTypeMap  map[string]genTypeWriter
+	openAPITypeMap map[string]genTypeWriter
+	debug          Debugger
 }
 
 // Debugger is the interface that wraps the basic Printf method.
@@ -55,10 +64,29 @@ func New() *Gen {
 	}
 
 	gen.outputTypeMap = map[string]genTypeWriter{
-		"go":   gen.writeDocSwagger,
-		"json": gen.writeJSONSwagger,
-		"yaml": gen.writeYAMLSwagger,
-		"yml":  gen.writeYAMLSwagger,
+		"go":        gen.writeDocSwagger,
+		"json":      gen.writeJSONSwagger,
+		"yaml":      gen.writeYAMLSwagger,
+		"yml":       gen.writeYAMLSwagger,
+		"html":      gen.writeHTMLSwagger,
+		"schemas":   gen.writeJSONSchemas,
+		"k6":        gen.writeK6,
+		"http":      gen.writeHTTPFile,
+		"terraform": gen.writeTerraform,
+		"configmap": gen.writeConfigMapSwagger,
+	}
+
+	gen.openAPITypeMap = map[string]genTypeWriter{
+		"go":        gen.writeDocOpenAPI,
+		"json":      gen.writeJSONOpenAPI,
+		"yaml":      gen.writeYAMLOpenAPI,
+		"yml":       gen.writeYAMLOpenAPI,
+		"html":      gen.writeHTMLOpenAPI,
+		"schemas":   gen.writeJSONSchemas,
+		"k6":        gen.writeK6,
+		"http":      gen.writeHTTPFile,
+		"terraform": gen.writeTerraform,
+		"configmap": gen.writeConfigMapOpenAPI,
 	}
 
 	return &gen
@@ -89,12 +117,22 @@ type Config struct {
 	// PropNamingStrategy represents property naming strategy like snake case,camel case,pascal case
 	PropNamingStrategy string
 
+	// PropertyTags the struct tags naming properties instead of json, comma separated, e.g. bson for all
+	// packages or github.com/acme/store/models=bson for the packages with that import path prefix
+	PropertyTags string
+
 	// MarkdownFilesDir used to find markdown files, which can be used for tag descriptions
 	MarkdownFilesDir string
 
+	// MarkdownBaseURL is prepended to relative links and images found in markdown files
+	MarkdownBaseURL string
+
 	// CodeExampleFilesDir used to find code example files, which can be used for x-codeSamples
 	CodeExampleFilesDir string
 
+	// ExtensionFilesDir used to find files loaded by @x-name file(name.json) extensions
+	ExtensionFilesDir string
+
 	// InstanceName is used to get distinct names for different swagger documents in the
 	// same project. The default value is "swagger".
 	InstanceName string
@@ -120,18 +158,64 @@ type Config struct {
 	// GeneratedTime whether swag should generate the timestamp at the top of docs.go
 	GeneratedTime bool
 
+	// CompressDoc embeds the template of docs.go compressed by gzip and encoded in base64, which keeps the binaries of
+	// large APIs smaller. It is decompressed once, when docs.go registers it at init.
+	CompressDoc bool
+
+	// StaticDoc embeds the final document in docs.go instead of a template executed by ReadDoc, e.g. when the host
+	// and base path never change at runtime. SwaggerInfo changes are ignored then.
+	StaticDoc bool
+
 	// RequiredByDefault set validation required for all fields by default
 	RequiredByDefault bool
 
+	// SecurityAlternatives whether || separates the alternative requirements of @Security instead of combining schemes
+	SecurityAlternatives bool
+
+	// TimeFormat how time.Time is documented: date-time, date or another string format, or unix and unixmilli for
+	// integer timestamps, a string without format when empty
+	TimeFormat string
+
+	// RawMessageType how json.RawMessage is documented: swag.RawMessageObject, a free-form object, by default, or
+	// swag.RawMessageString
+	RawMessageType string
+
+	// RequiredInResponses makes the fields without omitempty or omitzero required in the definitions only responses
+	// refer to
+	RequiredInResponses bool
+
 	// OverridesFile defines global type overrides.
 	OverridesFile string
 
+	// OperationsFile a JSON or YAML file of Swagger 2.0 operations by ID, which //swag:route comments refer to
+	OperationsFile string
+
+	// TagsFile a JSON or YAML list of the tags of the document in their order, or in the order of their order
+	// field, which replace the @tag annotations of the same name
+	TagsFile string
+
+	// ExtensionsFile a JSON or YAML file of extensions of the document, and of its info under the info key,
+	// which replace the extensions of the annotations with the same name
+	ExtensionsFile string
+
+	// OperationTemplatesFile a JSON or YAML file of operation templates by name, lists of annotation blocks
+	// which @crud annotations expand
+	OperationTemplatesFile string
+
 	// ParseGoList whether swag use go list to parse dependency
 	ParseGoList bool
 
 	// include only tags mentioned when searching, comma separated
 	Tags string
 
+	// OnlyPackages regenerates only the operations of these directories, comma separated, and merges them
+	// into the existing document of OutputDir, whose operations not declared in the other directories are removed
+	OnlyPackages string
+
+	// OnlyRoutes regenerates only the operations of the routes matching these patterns, comma separated, and
+	// merges them into the existing document of OutputDir
+	OnlyRoutes string
+
 	// LeftTemplateDelim defines the left delimiter for the template generation
 	LeftTemplateDelim string
 
@@ -153,12 +237,454 @@ type Config struct {
 	// ParseFuncBody whether swag should parse api info inside of funcs
 	ParseFuncBody bool
 
+	// IncludeGenerated parses the operations of files with a "Code generated ... DO NOT EDIT." header too
+	IncludeGenerated bool
+
 	// ParseGoPackages whether swag use golang.org/x/tools/go/packages to parse source.
 	ParseGoPackages bool
+
+	// InferInfoFromModule fills missing license, contact and version from LICENSE, CODEOWNERS, go.mod and git tags
+	InferInfoFromModule bool
+
+	// AuthResponses documents 401 and 403 responses with the WWW-Authenticate header for every secured operation
+	AuthResponses bool
+
+	// NullablePointers marks the pointer fields of structs with x-nullable, nullable in OpenAPI 3.0, so that clients
+	// tell an absent value from a null one
+	NullablePointers bool
+
+	// InheritMimeTypes copies the @Accept and @Produce of general API info into the operations which declare none,
+	// instead of leaving their consumes and produces empty
+	InheritMimeTypes bool
+
+	// DefaultSuccess the policy of operations without @Success: 200:none (default) leaves them without success
+	// response, 200:empty documents an empty 200 response, and error makes them an error
+	DefaultSuccess string
+
+	// PruneUnused removes the security definitions which are never required and the tags without operations,
+	// reporting each removal
+	PruneUnused bool
+
+	// PruneUnusedDefinitions removes the definitions which no path refers to, directly or through other
+	// definitions, e.g. the models of dependencies which are parsed but never used, reporting each removal
+	PruneUnusedDefinitions bool
+
+	// CodeSamples the tools of the x-codeSamples synthesized for the operations without code samples, curl and
+	// httpie, from their parameters, examples and security
+	CodeSamples []string
+
+	// EnumsAsRefs turns the inline enums, e.g. of the enums struct tag, into definitions which the fields refer
+	// to, so that client generators produce shared enum types
+	EnumsAsRefs bool
+
+	// StringerEnums documents the string forms of the integer enums whose type has a String method, which are marshaled
+	// as strings: swag.StringerEnumsVarNames in x-enum-varnames, or swag.StringerEnumsString as string enums
+	StringerEnums string
+
+	// RefStrategy rewrites how the schemas refer to each other: flatten inlines the definitions where they are
+	// referenced, except the recursive ones, and bundle extracts the inline objects into named definitions.
+	// The schemas are left as parsed when empty.
+	RefStrategy string
+
+	// VendorRemoteRefs copies the remote schemas referred to by the annotations, e.g.
+	// {object} https://schemas.example.com/user.json, into definitions named after their URLs, e.g.
+	// schemas.example.com.user, so that the document is self-contained. RefStrategy flatten inlines them.
+	VendorRemoteRefs bool
+
+	// RemoteRefsCacheDir the folder of the downloaded remote schemas, reused by the next builds, swag/remote-refs
+	// of the user cache folder by default
+	RemoteRefsCacheDir string
+
+	// Sort sorts the lists whose order comes from the order of the annotations, e.g. the tags, the parameters and
+	// the required properties, see swag.SortDocument. The paths, definitions and properties are always sorted.
+	Sort bool
+
+	// ModelFilters decide how the fields of structs are documented, e.g. swag.GormFilter{}
+	ModelFilters []swag.ModelFilter
+
+	// OmitEmptyExtension adds x-omitempty to the properties of structs, true when the json tag has omitempty
+	OmitEmptyExtension bool
+
+	// Variables replace {{.Name}} placeholders in general API info, e.g. @version {{.BuildVersion}}
+	Variables map[string]string
+
+	// OpenAPIVersion the versions of the generated documents, comma separated: 2.0 (default), 3.0 or 2.0,3.0
+	OpenAPIVersion string
+
+	// MaxSchemaDepth limits the nesting of definitions, 0 for no limit
+	MaxSchemaDepth int
+
+	// MaxDefinitions limits the number of definitions, 0 for no limit
+	MaxDefinitions int
+
+	// MaxGenericInstantiations limits the distinct instantiations of generic types, 0 for no limit
+	MaxGenericInstantiations int
+
+	// PackageCache shares the parsed packages between builds of one process, e.g. swag.SharedPackages()
+	PackageCache *swag.PackageCache
+
+	// Jobs the number of files parsed and written concurrently, the number of CPUs when 0.
+	// The generated files are the same for every number of jobs.
+	Jobs int
+
+	// HTMLRenderer renders index.html of the html output type: redoc (default) or swagger-ui
+	HTMLRenderer string
+
+	// ConfigMapName and ConfigMapNamespace name the ConfigMap of the configmap output type, swagger-docs and no
+	// namespace by default
+	ConfigMapName      string
+	ConfigMapNamespace string
+
+	// Compact writes the JSON documents without indentation, and embeds the document of docs.go without it
+	Compact bool
+
+	// SplitByTag writes the document of each tag too, e.g. users.swagger.json next to swagger.json, in the json and
+	// yaml output types. A tag document has the operations of the tag and the definitions they refer to.
+	SplitByTag bool
+
+	// Instances the variants of the document registered by docs.go besides InstanceName, e.g. an internal one,
+	// each written to its own files in the json and yaml output types
+	Instances []Instance
+
+	// InstancesFile a JSON or YAML file of Instances, e.g. instances.yaml
+	InstancesFile string
+
+	// Environments the deployments of the API whose documents are written too, e.g. swagger.prod.json next to
+	// swagger.json, in the json and yaml output types. They differ by their host, base path and schemes.
+	Environments []Environment
+
+	// EnvironmentsFile a JSON or YAML file of Environments, e.g. environments.yaml
+	EnvironmentsFile string
+
+	// environment the name of the environment whose files are written, see Environments
+	environment string
+
+	// PostHooks the commands run for each written file once the build succeeds, e.g. to upload the docs, by the
+	// shell of the platform. {file} is replaced by the quoted path of the file.
+	PostHooks []string
+
+	// emitted the files written by the build, for PostHooks
+	emitted *emittedFiles
+
+	// Output receives the generated document instead of a file in OutputDir, e.g. os.Stdout to use swag in a
+	// pipeline. It needs a single output type and OpenAPI version.
+	Output io.Writer
 }
 
 // Build builds swagger json file  for given searchDir and mainAPIFile. Returns json.
 func (g *Gen) Build(config *Config) error {
+	return g.BuildContext(context.Background(), config)
+}
+
+// BuildContext is like Build but stops with the error of ctx when it is cancelled, before writing any file.
+func (g *Gen) BuildContext(ctx context.Context, config *Config) error {
+	swagger2, openAPI3, err := openAPIVersions(config.OpenAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	p, err := g.parse(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	swagger := p.GetSwagger()
+
+	// the mounted paths are part of the parsed document, e.g. for the selective merge
+	if mounts := p.Mounts(); len(mounts) > 0 {
+		swagger, err = g.mountDocuments(ctx, swagger, mounts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.Selective() {
+		swagger, err = g.mergeIntoExisting(config, p)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.VendorRemoteRefs {
+		cacheDir := config.RemoteRefsCacheDir
+		if cacheDir == "" {
+			cacheDir, err = defaultRemoteRefsCacheDir()
+			if err != nil {
+				return err
+			}
+		}
+
+		swagger, err = g.vendorRemoteRefs(ctx, swagger, cacheDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.RefStrategy != "" {
+		if err := swag.ApplyRefStrategy(swagger, config.RefStrategy); err != nil {
+			return err
+		}
+	}
+
+	if config.Sort {
+		swag.SortDocument(swagger)
+	}
+
+	config, err = withInstancesFile(config)
+	if err != nil {
+		return err
+	}
+
+	config, err = withEnvironmentsFile(config)
+	if err != nil {
+		return err
+	}
+
+	config = withPostHooks(config)
+
+	documents, err := instanceDocuments(config, swagger)
+	if err != nil {
+		return err
+	}
+
+	// the environments of the main document and of the instances
+	environments, err := environmentDocuments(config,
+		append([]instanceDocument{{config: config, swagger: swagger}}, documents...))
+	if err != nil {
+		return err
+	}
+
+	documents = append(documents, environments...)
+
+	if config.Output == nil {
+		if err := os.MkdirAll(config.OutputDir, os.ModePerm); err != nil {
+			return err
+		}
+	} else if err := checkSingleOutput(config, swagger2, openAPI3); err != nil {
+		return err
+	}
+
+	// the files are written from the same document, concurrently
+	var group errgroup.Group
+	group.SetLimit(jobs(config))
+
+	written := make(map[string]bool)
+
+	for _, outputType := range config.OutputTypes {
+		outputType = strings.ToLower(strings.TrimSpace(outputType))
+		if outputType == "yml" {
+			outputType = "yaml"
+		}
+
+		// two writers of the same file would race
+		if written[outputType] {
+			continue
+		}
+
+		if typeWriter, ok := g.outputTypeMap[outputType]; ok {
+			written[outputType] = true
+
+			if swagger2 {
+				group.Go(func() error {
+					return typeWriter(config, swagger)
+				})
+			}
+
+			// docs.go and index.html show a single document, the 2.0 one when both are generated, and both
+			// versions have the same schemas
+			if openAPI3 && (!singleDocumentTypes[outputType] || !swagger2) {
+				group.Go(func() error {
+					return g.openAPITypeMap[outputType](config, swagger)
+				})
+			}
+
+			// docs.go registers the instances itself, and has a single environment
+			if singleDocumentTypes[outputType] {
+				continue
+			}
+
+			for _, document := range documents {
+				if swagger2 {
+					group.Go(func() error {
+						return typeWriter(document.config, document.swagger)
+					})
+				}
+
+				if openAPI3 {
+					group.Go(func() error {
+						return g.openAPITypeMap[outputType](document.config, document.swagger)
+					})
+				}
+			}
+		} else {
+			log.Printf("output type '%s' not supported", outputType)
+		}
+	}
+
+	if config.SplitByTag {
+		if err := g.writeSplitByTag(&group, config, swagger, swagger2, openAPI3); err != nil {
+			_ = group.Wait()
+
+			return err
+		}
+	}
+
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	return g.runPostHooks(ctx, config)
+}
+
+// singleDocumentTypes the output types which are written once when both OpenAPI versions are generated.
+var singleDocumentTypes = map[string]bool{
+	"go": true, "html": true, "schemas": true, "k6": true, "http": true, "terraform": true, "configmap": true,
+}
+
+// checkSingleOutput checks that config generates a single document, since Config.Output can not hold several.
+func checkSingleOutput(config *Config, swagger2, openAPI3 bool) error {
+	outputTypes := make(map[string]bool)
+
+	for _, outputType := range config.OutputTypes {
+		outputType = strings.ToLower(strings.TrimSpace(outputType))
+		if outputType == "yml" {
+			outputType = "yaml"
+		}
+
+		outputTypes[outputType] = true
+	}
+
+	if config.SplitByTag {
+		return errors.New("writing to an output can not split the document by tag")
+	}
+
+	if len(config.Instances) > 0 && !outputTypes["go"] {
+		return errors.New("writing to an output can not hold the documents of several instances")
+	}
+
+	if len(config.Environments) > 0 && !outputTypes["go"] && !outputTypes["html"] {
+		return errors.New("writing to an output can not hold the documents of several environments")
+	}
+
+	if outputTypes["schemas"] {
+		return errors.New("writing to an output can not hold the schemas of several definitions")
+	}
+
+	if len(outputTypes) != 1 {
+		return fmt.Errorf("writing to an output needs a single output type, got %s", strings.Join(config.OutputTypes, ","))
+	}
+
+	// docs.go and index.html show the 2.0 document only
+	if swagger2 && openAPI3 && !outputTypes["go"] && !outputTypes["html"] {
+		return fmt.Errorf("writing to an output needs a single OpenAPI version, got %s", config.OpenAPIVersion)
+	}
+
+	return nil
+}
+
+// jobs returns the number of concurrent jobs of config.
+func jobs(config *Config) int {
+	if config.Jobs < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+
+	return config.Jobs
+}
+
+// openAPIVersions parses the comma separated versions of Config.OpenAPIVersion.
+func openAPIVersions(versions string) (swagger2, openAPI3 bool, err error) {
+	if strings.TrimSpace(versions) == "" {
+		return true, false, nil
+	}
+
+	for _, version := range strings.Split(versions, ",") {
+		switch strings.TrimSpace(version) {
+		case "2", "2.0":
+			swagger2 = true
+		case "3", "3.0":
+			openAPI3 = true
+		default:
+			return false, false, fmt.Errorf("unsupported OpenAPI version %q, expected 2.0 or 3.0", version)
+		}
+	}
+
+	return swagger2, openAPI3, nil
+}
+
+// Validate parses the sources like Build without writing any file and returns the elements of the documents
+// which violate the Swagger 2.0 or OpenAPI 3.0 schema, and the examples which do not validate against their schemas.
+func (g *Gen) Validate(config *Config) ([]swag.ValidationIssue, error) {
+	swagger2, openAPI3, err := openAPIVersions(config.OpenAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := g.parse(context.Background(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []swag.ValidationIssue
+
+	if swagger2 {
+		documentIssues, err := p.ValidateDocument()
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, documentIssues...)
+	}
+
+	if openAPI3 {
+		doc, err := openapi3.NewConverter().Convert(p.GetSwagger())
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		documentIssues, err := p.ValidateDocumentOf(b)
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, documentIssues...)
+	}
+
+	return append(issues, p.ValidateExamples()...), nil
+}
+
+// ValidateFile validates a generated Swagger 2.0 or OpenAPI 3.0 document, in JSON or YAML, against the schema
+// of its version. The sources of the issues are unknown.
+func (g *Gen) ValidateFile(name string) ([]swag.ValidationIssue, error) {
+	var b json.RawMessage
+	if err := swag.ReadYAMLOrJSON(name, &b); err != nil {
+		return nil, err
+	}
+
+	issues, err := swag.ValidateDocument(b)
+	if err != nil {
+		return nil, fmt.Errorf("cannot validate %s: %w", name, err)
+	}
+
+	return issues, nil
+}
+
+// Spec parses the sources like Build and returns the document in memory without writing any file.
+func (g *Gen) Spec(config *Config) (*spec.Swagger, error) {
+	p, err := g.parse(context.Background(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetSwagger(), nil
+}
+
+// parse parses the sources of config into a swag.Parser.
+func (g *Gen) parse(ctx context.Context, config *Config) (*swag.Parser, error) {
 	if config.Debugger != nil {
 		g.debug = config.Debugger
 	}
@@ -170,7 +696,7 @@ func (g *Gen) Build(config *Config) error {
 	if !config.ParseGoPackages { // packages.Load support pattern like ./...
 		for _, searchDir := range searchDirs {
 			if _, err := os.Stat(searchDir); os.IsNotExist(err) {
-				return fmt.Errorf("dir: %s does not exist", searchDir)
+				return nil, fmt.Errorf("dir: %s does not exist", searchDir)
 			}
 		}
 	}
@@ -190,79 +716,292 @@ func (g *Gen) Build(config *Config) error {
 		if err != nil {
 			// Don't bother reporting if the default file is missing; assume there are no overrides
 			if !(config.OverridesFile == DefaultOverridesFile && os.IsNotExist(err)) {
-				return fmt.Errorf("could not open overrides file: %w", err)
+				return nil, fmt.Errorf("could not open overrides file: %w", err)
 			}
 		} else {
 			g.debug.Printf("Using overrides from %s", config.OverridesFile)
 
 			overrides, err = parseOverrides(overridesFile)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
 
+	var operations map[string]spec.Operation
+
+	if config.OperationsFile != "" {
+		var err error
+
+		operations, err = readOperations(config.OperationsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var templates map[string][]string
+
+	if config.OperationTemplatesFile != "" {
+		var err error
+
+		templates, err = readOperationTemplates(config.OperationTemplatesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var documentExtensions, infoExtensions map[string]any
+
+	if config.ExtensionsFile != "" {
+		var err error
+
+		documentExtensions, infoExtensions, err = readExtensions(config.ExtensionsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch config.DefaultSuccess {
+	case "", swag.DefaultSuccessNone, swag.DefaultSuccessEmpty, swag.DefaultSuccessError:
+	default:
+		return nil, fmt.Errorf("unsupported default success %q, expected %s, %s or %s", config.DefaultSuccess,
+			swag.DefaultSuccessNone, swag.DefaultSuccessEmpty, swag.DefaultSuccessError)
+	}
+
+	switch config.StringerEnums {
+	case "", swag.StringerEnumsVarNames, swag.StringerEnumsString:
+	default:
+		return nil, fmt.Errorf("unsupported stringer enums %q, expected %s or %s", config.StringerEnums,
+			swag.StringerEnumsVarNames, swag.StringerEnumsString)
+	}
+
+	switch config.RawMessageType {
+	case "", swag.RawMessageObject, swag.RawMessageString:
+	default:
+		return nil, fmt.Errorf("unsupported raw message type %q, expected %s or %s", config.RawMessageType,
+			swag.RawMessageObject, swag.RawMessageString)
+	}
+
+	switch config.RefStrategy {
+	case "", swag.RefStrategyFlatten, swag.RefStrategyBundle:
+	default:
+		return nil, fmt.Errorf("unsupported ref strategy %q, expected %s or %s", config.RefStrategy,
+			swag.RefStrategyFlatten, swag.RefStrategyBundle)
+	}
+
+	for _, tool := range config.CodeSamples {
+		if tool != swag.CodeSampleCurl && tool != swag.CodeSampleHTTPie {
+			return nil, fmt.Errorf("unsupported code sample %q, expected %s or %s", tool,
+				swag.CodeSampleCurl, swag.CodeSampleHTTPie)
+		}
+	}
+
+	var tags []spec.Tag
+
+	if config.TagsFile != "" {
+		var err error
+
+		tags, err = readTags(config.TagsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	g.debug.Printf("Generate swagger docs....")
 
 	p := swag.New(
 		swag.SetParseDependency(config.ParseDependency),
 		swag.SetUseStructName(config.UseStructNames),
 		swag.SetMarkdownFileDirectory(config.MarkdownFilesDir),
+		swag.SetMarkdownBaseURL(config.MarkdownBaseURL),
 		swag.SetDebugger(config.Debugger),
 		swag.SetExcludedDirsAndFiles(config.Excludes),
 		swag.SetParseExtension(config.ParseExtension),
 		swag.SetCodeExamplesDirectory(config.CodeExampleFilesDir),
+		swag.SetExtensionFilesDirectory(config.ExtensionFilesDir),
 		swag.SetStrict(config.Strict),
 		swag.SetOverrides(overrides),
+		swag.SetOperationDefinitions(operations),
+		swag.SetOperationTemplates(templates),
+		swag.SetDocumentExtensions(documentExtensions, infoExtensions),
+		swag.SetTagDeclarations(tags),
+		swag.SetDefaultSuccess(config.DefaultSuccess),
+		swag.SetCodeSamples(config.CodeSamples),
 		swag.ParseUsingGoList(config.ParseGoList),
 		swag.SetTags(config.Tags),
+		swag.SetOnlyPackages(config.OnlyPackages),
+		swag.SetOnlyRoutes(config.OnlyRoutes),
 		swag.SetCollectionFormat(config.CollectionFormat),
 		swag.SetPackagePrefix(config.PackagePrefix),
+		swag.SetPropertyTags(config.PropertyTags),
+		swag.SetModelFilters(config.ModelFilters...),
+		swag.SetVariables(config.Variables),
+		swag.SetPackageCache(config.PackageCache),
+		swag.SetJobs(jobs(config)),
+		swag.SetLimits(swag.Limits{
+			MaxSchemaDepth:           config.MaxSchemaDepth,
+			MaxDefinitions:           config.MaxDefinitions,
+			MaxGenericInstantiations: config.MaxGenericInstantiations,
+		}),
 	)
 
 	p.PropNamingStrategy = config.PropNamingStrategy
 	p.ParseVendor = config.ParseVendor
 	p.ParseInternal = config.ParseInternal
 	p.RequiredByDefault = config.RequiredByDefault
+	p.SecurityAlternatives = config.SecurityAlternatives
+	p.RequiredInResponses = config.RequiredInResponses
+	p.TimeFormat = config.TimeFormat
+	p.RawMessageType = config.RawMessageType
 	p.HostState = config.State
 	p.ParseFuncBody = config.ParseFuncBody
+	p.IncludeGenerated = config.IncludeGenerated
 	p.ParseGoPackages = config.ParseGoPackages
+	p.InferInfoFromModule = config.InferInfoFromModule
+	p.AuthResponses = config.AuthResponses
+	p.InheritMimeTypes = config.InheritMimeTypes
+	p.NullablePointers = config.NullablePointers
+	p.PruneUnused = config.PruneUnused
+	p.PruneUnusedDefinitions = config.PruneUnusedDefinitions
+	p.EnumsAsRefs = config.EnumsAsRefs
+	p.StringerEnums = config.StringerEnums
+	p.OmitEmptyExtension = config.OmitEmptyExtension
+
+	if err := p.ParseAPIMultiSearchDirContext(ctx, searchDirs, config.MainAPIFile, config.ParseDepth); err != nil {
+		return nil, err
+	}
 
-	if err := p.ParseAPIMultiSearchDir(searchDirs, config.MainAPIFile, config.ParseDepth); err != nil {
-		return err
+	return p, nil
+}
+
+// mergeIntoExisting merges the operations and definitions of a selective parse into the document
+// previously generated in OutputDir, the rest of the document is left untouched. The existing operations
+// of the selected routes are replaced, so that removed operations disappear.
+func (g *Gen) mergeIntoExisting(config *Config, p *swag.Parser) (*spec.Swagger, error) {
+	existing, err := g.readExisting(config)
+	if err != nil {
+		return nil, err
 	}
 
-	swagger := p.GetSwagger()
+	generated := p.GetSwagger()
 
-	if err := os.MkdirAll(config.OutputDir, os.ModePerm); err != nil {
-		return err
+	if existing.Paths == nil {
+		existing.Paths = &spec.Paths{}
 	}
 
-	for _, outputType := range config.OutputTypes {
-		outputType = strings.ToLower(strings.TrimSpace(outputType))
-		if typeWriter, ok := g.outputTypeMap[outputType]; ok {
-			if err := typeWriter(config, swagger); err != nil {
-				return err
+	if existing.Paths.Paths == nil {
+		existing.Paths.Paths = make(map[string]spec.PathItem)
+	}
+
+	if config.OnlyRoutes != "" {
+		for route := range existing.Paths.Paths {
+			if p.MatchRoute(route) {
+				delete(existing.Paths.Paths, route)
 			}
-		} else {
-			log.Printf("output type '%s' not supported", outputType)
 		}
 	}
 
-	return nil
+	// the operations of the parsed packages are replaced, including those removed since
+	if config.OnlyPackages != "" {
+		for route, item := range existing.Paths.Paths {
+			kept := 0
+
+			for _, op := range pathOperations(&item) {
+				if p.DeclaredOutside(op.method, route) {
+					kept++
+				} else {
+					*op.ref(&item) = nil
+				}
+			}
+
+			if kept == 0 {
+				delete(existing.Paths.Paths, route)
+			} else {
+				existing.Paths.Paths[route] = item
+			}
+		}
+	}
+
+	for route, item := range generated.Paths.Paths {
+		merged := existing.Paths.Paths[route]
+		for _, op := range []struct{ from, to **spec.Operation }{
+			{&item.Get, &merged.Get},
+			{&item.Put, &merged.Put},
+			{&item.Post, &merged.Post},
+			{&item.Delete, &merged.Delete},
+			{&item.Options, &merged.Options},
+			{&item.Head, &merged.Head},
+			{&item.Patch, &merged.Patch},
+		} {
+			if *op.from != nil {
+				*op.to = *op.from
+			}
+		}
+
+		existing.Paths.Paths[route] = merged
+	}
+
+	if len(generated.Definitions) > 0 && existing.Definitions == nil {
+		existing.Definitions = make(spec.Definitions)
+	}
+
+	for name, schema := range generated.Definitions {
+		existing.Definitions[name] = schema
+	}
+
+	g.debug.Printf("Merged %d paths into the existing document", len(generated.Paths.Paths))
+
+	return existing, nil
 }
 
-func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "docs.go"
+// readExisting reads the document previously generated in OutputDir, as JSON or YAML.
+func (g *Gen) readExisting(config *Config) (*spec.Swagger, error) {
+	for _, name := range []string{"swagger.json", "swagger.yaml"} {
+		var existing spec.Swagger
+
+		err := swag.ReadYAMLOrJSON(path.Join(config.OutputDir, outputFileName(config, name)), &existing)
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read the existing document: %w", err)
+		}
+
+		return &existing, nil
+	}
+
+	return nil, fmt.Errorf("selective generation needs a document generated before in %s", config.OutputDir)
+}
+
+// outputFileName prefixes the name of a generated file with the state and the instance name, and inserts the
+// environment before its extension.
+func outputFileName(config *Config, name string) string {
+	name = environmentFileName(config, name)
 
 	if config.State != "" {
-		filename = config.State + "_" + filename
+		name = config.State + "_" + name
 	}
 
 	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
+		name = config.InstanceName + "_" + name
 	}
 
+	return name
+}
+
+func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
+	return g.writeDoc(config, swagger, g.writeGoDoc)
+}
+
+func (g *Gen) writeDocOpenAPI(config *Config, swagger *spec.Swagger) error {
+	return g.writeDoc(config, swagger, g.writeGoDocOpenAPI)
+}
+
+func (g *Gen) writeDoc(config *Config, swagger *spec.Swagger,
+	writeGoDoc func(packageName string, output io.Writer, swagger *spec.Swagger, config *Config) error) error {
+	filename := outputFileName(config, "docs.go")
+
 	docFileName := path.Join(config.OutputDir, filename)
 
 	absOutputDir, err := filepath.Abs(config.OutputDir)
@@ -278,14 +1017,14 @@ func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
 		packageName = strings.ReplaceAll(packageName, "-", "_")
 	}
 
-	docs, err := os.Create(docFileName)
+	docs, err := g.create(config, docFileName)
 	if err != nil {
 		return err
 	}
 	defer docs.Close()
 
 	// Write doc
-	err = g.writeGoDoc(packageName, docs, swagger, config)
+	err = writeGoDoc(packageName, docs, swagger, config)
 	if err != nil {
 		return err
 	}
@@ -296,47 +1035,82 @@ func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
 }
 
 func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "swagger.json"
+	filename := outputFileName(config, "swagger.json")
 
-	if config.State != "" {
-		filename = config.State + "_" + filename
+	jsonFileName := path.Join(config.OutputDir, filename)
+
+	b, err := g.marshalDocument(config, swagger)
+	if err != nil {
+		return err
 	}
 
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
+	err = g.writeFile(config, b, jsonFileName)
+	if err != nil {
+		return err
 	}
 
-	jsonFileName := path.Join(config.OutputDir, filename)
+	g.debug.Printf("create swagger.json at %+v", jsonFileName)
+
+	return nil
+}
+
+func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
+	filename := outputFileName(config, "swagger.yaml")
 
-	b, err := g.jsonIndent(swagger)
+	yamlFileName := path.Join(config.OutputDir, filename)
+
+	b, err := g.json(swagger)
 	if err != nil {
 		return err
 	}
 
-	err = g.writeFile(b, jsonFileName)
+	y, err := g.jsonToYAML(b)
+	if err != nil {
+		return fmt.Errorf("cannot covert json to yaml error: %s", err)
+	}
+
+	err = g.writeFile(config, y, yamlFileName)
 	if err != nil {
 		return err
 	}
 
-	g.debug.Printf("create swagger.json at %+v", jsonFileName)
+	g.debug.Printf("create swagger.yaml at %+v", yamlFileName)
 
 	return nil
 }
 
-func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "swagger.yaml"
+func (g *Gen) writeJSONOpenAPI(config *Config, swagger *spec.Swagger) error {
+	jsonFileName := path.Join(config.OutputDir, outputFileName(config, "openapi.json"))
 
-	if config.State != "" {
-		filename = config.State + "_" + filename
+	doc, err := openapi3.NewConverter().Convert(swagger)
+	if err != nil {
+		return err
 	}
 
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
+	b, err := g.marshalDocument(config, doc)
+	if err != nil {
+		return err
 	}
 
-	yamlFileName := path.Join(config.OutputDir, filename)
+	err = g.writeFile(config, b, jsonFileName)
+	if err != nil {
+		return err
+	}
 
-	b, err := g.json(swagger)
+	g.debug.Printf("create openapi.json at %+v", jsonFileName)
+
+	return nil
+}
+
+func (g *Gen) writeYAMLOpenAPI(config *Config, swagger *spec.Swagger) error {
+	yamlFileName := path.Join(config.OutputDir, outputFileName(config, "openapi.yaml"))
+
+	doc, err := openapi3.NewConverter().Convert(swagger)
+	if err != nil {
+		return err
+	}
+
+	b, err := g.json(doc)
 	if err != nil {
 		return err
 	}
@@ -346,18 +1120,48 @@ func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
 		return fmt.Errorf("cannot covert json to yaml error: %s", err)
 	}
 
-	err = g.writeFile(y, yamlFileName)
+	err = g.writeFile(config, y, yamlFileName)
 	if err != nil {
 		return err
 	}
 
-	g.debug.Printf("create swagger.yaml at %+v", yamlFileName)
+	g.debug.Printf("create openapi.yaml at %+v", yamlFileName)
+
+	return nil
+}
+
+// marshalDocument encodes a JSON document, indented unless Config.Compact is set.
+func (g *Gen) marshalDocument(config *Config, document any) ([]byte, error) {
+	if config.Compact {
+		return g.json(document)
+	}
+
+	return g.jsonIndent(document)
+}
+
+// create creates file, or returns Config.Output when it is set.
+func (g *Gen) create(config *Config, file string) (io.WriteCloser, error) {
+	if config.Output != nil {
+		return nopWriteCloser{config.Output}, nil
+	}
 
+	if config.emitted != nil {
+		config.emitted.add(file)
+	}
+
+	return os.Create(file)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
 	return nil
 }
 
-func (g *Gen) writeFile(b []byte, file string) error {
-	f, err := os.Create(file)
+func (g *Gen) writeFile(config *Config, b []byte, file string) error {
+	f, err := g.create(config, file)
 	if err != nil {
 		return err
 	}
@@ -378,6 +1182,125 @@ func (g *Gen) formatSource(src []byte) []byte {
 	return code
 }
 
+// compressTemplate compresses the template of docs.go by gzip and encodes it in base64, see swag.Spec.
+func compressTemplate(doc string) (string, error) {
+	var buffer bytes.Buffer
+
+	encoder := base64.NewEncoder(base64.StdEncoding, &buffer)
+
+	zw, err := gzip.NewWriterLevel(encoder, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := zw.Write([]byte(doc)); err != nil {
+		return "", err
+	}
+
+	// both flush their last bytes on close
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// readOperations reads the operations by ID of a JSON or YAML file.
+func readOperations(name string) (map[string]spec.Operation, error) {
+	var operations map[string]spec.Operation
+	if err := swag.ReadYAMLOrJSON(name, &operations); err != nil {
+		return nil, err
+	}
+
+	return operations, nil
+}
+
+// readOperationTemplates reads the operation templates by name of a JSON or YAML file.
+func readOperationTemplates(name string) (map[string][]string, error) {
+	var templates map[string][]string
+	if err := swag.ReadYAMLOrJSON(name, &templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// readTags reads the tags of a JSON or YAML file, sorted by their order field. The tags without order follow
+// in the order of the file.
+func readTags(name string) ([]spec.Tag, error) {
+	var b json.RawMessage
+	if err := swag.ReadYAMLOrJSON(name, &b); err != nil {
+		return nil, err
+	}
+
+	var declarations []struct {
+		Order *int `json:"order"`
+	}
+
+	if err := json.Unmarshal(b, &declarations); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	var tags []spec.Tag
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	for i, tag := range tags {
+		if tag.Name == "" {
+			return nil, fmt.Errorf("%s: tag %d has no name", name, i+1)
+		}
+	}
+
+	indexes := make([]int, len(tags))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := declarations[indexes[i]].Order, declarations[indexes[j]].Order
+
+		return a != nil && (b == nil || *a < *b)
+	})
+
+	sorted := make([]spec.Tag, len(tags))
+	for i, index := range indexes {
+		sorted[i] = tags[index]
+	}
+
+	return sorted, nil
+}
+
+// readExtensions reads the extensions of the document, and those of its info under the info key, of a JSON or
+// YAML file.
+func readExtensions(name string) (document, info map[string]any, err error) {
+	if err := swag.ReadYAMLOrJSON(name, &document); err != nil {
+		return nil, nil, err
+	}
+
+	if value, ok := document["info"]; ok {
+		if info, ok = value.(map[string]any); !ok {
+			return nil, nil, fmt.Errorf("%s: info must be an object of extensions", name)
+		}
+
+		delete(document, "info")
+	}
+
+	for _, extensions := range []map[string]any{document, info} {
+		for key := range extensions {
+			if !strings.HasPrefix(strings.ToLower(key), "x-") {
+				return nil, nil, fmt.Errorf("%s: %s is not an extension, the names must start with x-", name, key)
+			}
+		}
+	}
+
+	return document, info, nil
+}
+
 // Read and parse the overrides file.
 func parseOverrides(r io.Reader) (map[string]string, error) {
 	overrides := make(map[string]string)
@@ -424,10 +1347,18 @@ func parseOverrides(r io.Reader) (map[string]string, error) {
 }
 
 func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swagger, config *Config) error {
+	return g.executeGoDoc(packageName, output, swagger, config, false)
+}
+
+// writeGoDocOpenAPI writes a docs.go registering the OpenAPI 3.0 document. Its servers are fixed at
+// generation time, only the title, description and version can be changed at runtime.
+func (g *Gen) writeGoDocOpenAPI(packageName string, output io.Writer, swagger *spec.Swagger, config *Config) error {
+	return g.executeGoDoc(packageName, output, swagger, config, true)
+}
+
+func (g *Gen) executeGoDoc(packageName string, output io.Writer, swagger *spec.Swagger, config *Config, openAPI3 bool) error {
 	generator, err := template.New("swagger_info").Funcs(template.FuncMap{
 		"printDoc": func(v string) string {
-			// Add schemes
-			v = "{\n    \"schemes\": " + config.LeftTemplateDelim + " marshal .Schemes " + config.RightTemplateDelim + "," + v[1:]
 			// Sanitize backticks
 			return strings.Replace(v, "`", "`+\"`\"+`", -1)
 		},
@@ -436,6 +1367,72 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 		return err
 	}
 
+	data, err := g.goDocData(swagger, config, openAPI3)
+	if err != nil {
+		return err
+	}
+
+	data.PackageName = packageName
+
+	buffer := &bytes.Buffer{}
+
+	err = generator.Execute(buffer, data)
+	if err != nil {
+		return err
+	}
+
+	// the other instances are registered by the same package
+	instances, err := instanceDocuments(config, swagger)
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		data, err := g.goDocData(instance.swagger, instance.config, openAPI3)
+		if err != nil {
+			return err
+		}
+
+		buffer.WriteString("\n")
+
+		err = generator.ExecuteTemplate(buffer, "instance", data)
+		if err != nil {
+			return err
+		}
+	}
+
+	code := g.formatSource(buffer.Bytes())
+
+	// write
+	_, err = output.Write(code)
+
+	return err
+}
+
+// goDocData the values of the docs.go template for the document of an instance.
+type goDocData struct {
+	Timestamp          time.Time
+	Doc                string
+	CompressedDoc      string
+	Host               string
+	PackageName        string
+	BasePath           string
+	Title              string
+	Description        string
+	Version            string
+	State              string
+	InstanceName       string
+	Schemes            []string
+	GeneratedTime      bool
+	Static             bool
+	LeftTemplateDelim  string
+	RightTemplateDelim string
+}
+
+// goDocData builds the values of the docs.go template for swagger, the document of config.InstanceName.
+func (g *Gen) goDocData(swagger *spec.Swagger, config *Config, openAPI3 bool) (*goDocData, error) {
+	var err error
+
 	swaggerSpec := &spec.Swagger{
 		VendorExtensible: swagger.VendorExtensible,
 		SwaggerProps: spec.SwaggerProps{
@@ -467,10 +1464,51 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 		},
 	}
 
+	var document any = swaggerSpec
+
+	switch {
+	case config.StaticDoc && openAPI3:
+		document, err = openapi3.NewConverter().Convert(swagger)
+		if err != nil {
+			return nil, err
+		}
+	case config.StaticDoc:
+		document = swagger
+	case openAPI3:
+		swaggerSpec.Host = swagger.Host
+		swaggerSpec.BasePath = swagger.BasePath
+		swaggerSpec.Schemes = swagger.Schemes
+
+		document, err = openapi3.NewConverter().Convert(swaggerSpec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// crafted docs.json
-	buf, err := g.jsonIndent(swaggerSpec)
+	buf, err := g.marshalDocument(config, document)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	doc := string(buf)
+
+	// Add schemes, which are part of the servers in OpenAPI 3.0
+	if !openAPI3 && !config.StaticDoc {
+		schemes := "{\n    \"schemes\": "
+		if config.Compact {
+			schemes = "{\"schemes\":"
+		}
+
+		doc = schemes + config.LeftTemplateDelim + " marshal .Schemes " + config.RightTemplateDelim + "," + doc[1:]
+	}
+
+	var compressedDoc string
+	if config.CompressDoc {
+		compressedDoc, err = compressTemplate(doc)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	state := ""
@@ -478,29 +1516,13 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 		state = cases.Title(language.English).String(strings.ToLower(config.State))
 	}
 
-	buffer := &bytes.Buffer{}
-
-	err = generator.Execute(buffer, struct {
-		Timestamp          time.Time
-		Doc                string
-		Host               string
-		PackageName        string
-		BasePath           string
-		Title              string
-		Description        string
-		Version            string
-		State              string
-		InstanceName       string
-		Schemes            []string
-		GeneratedTime      bool
-		LeftTemplateDelim  string
-		RightTemplateDelim string
-	}{
+	return &goDocData{
 		Timestamp:          time.Now(),
 		GeneratedTime:      config.GeneratedTime,
-		Doc:                string(buf),
+		Static:             config.StaticDoc,
+		Doc:                doc,
+		CompressedDoc:      compressedDoc,
 		Host:               swagger.Host,
-		PackageName:        packageName,
 		BasePath:           swagger.BasePath,
 		Schemes:            swagger.Schemes,
 		Title:              swagger.Info.Title,
@@ -510,17 +1532,7 @@ func (g *Gen) writeGoDoc(packageName string, output io.Writer, swagger *spec.Swa
 		InstanceName:       config.InstanceName,
 		LeftTemplateDelim:  config.LeftTemplateDelim,
 		RightTemplateDelim: config.RightTemplateDelim,
-	})
-	if err != nil {
-		return err
-	}
-
-	code := g.formatSource(buffer.Bytes())
-
-	// write
-	_, err = output.Write(code)
-
-	return err
+	}, nil
 }
 
 var packageTemplate = `// Package {{.PackageName}} Code generated by swaggo/swag{{ if .GeneratedTime }} at {{ .Timestamp }}{{ end }}. DO NOT EDIT
@@ -528,8 +1540,11 @@ package {{.PackageName}}
 
 import "github.com/swaggo/swag"
 
-const docTemplate{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }}{{ .State }} = ` + "`{{ printDoc .Doc}}`" + `
-
+{{ template "instance" . }}
+{{- define "instance" }}{{ if .CompressedDoc }}// docTemplate{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }}{{ .State }} is compressed by gzip and encoded in base64
+const docTemplate{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }}{{ .State }} = {{ printf "%q" .CompressedDoc }}
+{{ else }}const docTemplate{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }}{{ .State }} = ` + "`{{ printDoc .Doc}}`" + `
+{{ end }}
 // Swagger{{ .State }}Info{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }} holds exported Swagger Info so clients can modify it
 var Swagger{{ .State }}Info{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }} = &swag.Spec{
 	Version:     {{ printf "%q" .Version}},
@@ -539,12 +1554,15 @@ var Swagger{{ .State }}Info{{ if ne .InstanceName "swagger" }}{{ .InstanceName }
 	Title:       {{ printf "%q" .Title}},
 	Description: {{ printf "%q" .Description}},
 	InfoInstanceName: {{ printf "%q" .InstanceName }},
-	SwaggerTemplate: docTemplate{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }}{{ .State }},
+	{{ if .CompressedDoc }}CompressedTemplate{{ else }}SwaggerTemplate{{ end }}: docTemplate{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }}{{ .State }},
 	LeftDelim:        {{ printf "%q" .LeftTemplateDelim}},
 	RightDelim:       {{ printf "%q" .RightTemplateDelim}},
+	{{- if .Static }}
+	Static:           true,
+	{{- end }}
 }
 
 func init() {
 	swag.Register(Swagger{{ .State }}Info{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }}.InstanceName(), Swagger{{ .State }}Info{{ if ne .InstanceName "swagger" }}{{ .InstanceName }} {{- end }})
 }
-`
+{{ end }}`
@@ -2,15 +2,24 @@ package gen
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"plugin"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -18,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggo/swag"
+	"sigs.k8s.io/yaml"
 )
 
 const searchDir = "../testdata/simple"
@@ -178,6 +188,88 @@ func TestGen_BuildSnakeCase(t *testing.T) {
 	}
 }
 
+func TestGen_Validate(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/simple",
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/validate_docs",
+		PropNamingStrategy: swag.CamelCase,
+	}
+
+	issues, err := New().Validate(config)
+	assert.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "/definitions/web.Pet/properties/category/properties/small_category/properties/name/example", issues[0].Location)
+	assert.Equal(t, `"detail_category_name" is longer than the maximum length 16`, issues[0].Message)
+	assert.True(t, strings.HasSuffix(issues[0].Source, filepath.Join("testdata", "simple", "web", "handler.go:11")))
+
+	_, err = os.Stat(config.OutputDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGen_ValidateFile(t *testing.T) {
+	issues, err := New().ValidateFile("testdata/pets.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	name := filepath.Join(t.TempDir(), "swagger.json")
+	require.NoError(t, os.WriteFile(name, []byte(`{"swagger": "2.0", "info": {"title": "Pets"}, "paths": {}}`), 0o644))
+
+	issues, err = New().ValidateFile(name)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "/info: missing properties: 'version'", issues[0].String())
+
+	_, err = New().ValidateFile("../testdata/simple/main.go")
+	assert.Error(t, err)
+}
+
+func TestGen_Convert(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"openapi.json", "openapi.yaml"} {
+		output := filepath.Join(dir, name)
+		require.NoError(t, New().Convert(Swagger2Version, OpenAPI3Version, "testdata/pets.yaml", output))
+
+		b, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "3.0.3")
+
+		issues, err := New().ValidateFile(output)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	}
+
+	err := New().Convert(OpenAPI3Version, Swagger2Version, filepath.Join(dir, "openapi.json"), "")
+	assert.EqualError(t, err, `unsupported conversion from "openapi3" to "swagger2", expected from swagger2 to openapi3`)
+
+	err = New().Convert(Swagger2Version, OpenAPI3Version, filepath.Join(dir, "openapi.json"), "")
+	assert.EqualError(t, err, filepath.Join(dir, "openapi.json")+" is not a Swagger 2.0 document")
+}
+
+func TestGen_Bench(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/simple",
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/bench_docs",
+		PropNamingStrategy: swag.CamelCase,
+	}
+
+	result, err := New().Bench(context.Background(), config, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Runs)
+	assert.True(t, result.Min > 0)
+	assert.True(t, result.Min <= result.Mean && result.Mean <= result.Max)
+	assert.NotZero(t, result.AllocsPerRun)
+	assert.Contains(t, result.String(), "2 runs, mean ")
+
+	_, err = os.Stat(config.OutputDir)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = New().Bench(context.Background(), config, 0)
+	assert.EqualError(t, err, "runs must be positive, got 0")
+}
+
 func TestGen_BuildLowerCamelcase(t *testing.T) {
 	config := &Config{
 		SearchDir:          "../testdata/simple3",
@@ -973,3 +1065,1376 @@ func TestGen_StateUser(t *testing.T) {
 
 	assert.JSONEq(t, string(expectedJSON), string(jsonOutput))
 }
+
+func TestGen_Selective(t *testing.T) {
+	outputDir := t.TempDir()
+	newConfig := func() *Config {
+		return &Config{
+			SearchDir:          "../testdata/simple",
+			MainAPIFile:        "./main.go",
+			OutputDir:          outputDir,
+			OutputTypes:        []string{"json"},
+			PropNamingStrategy: swag.CamelCase,
+		}
+	}
+
+	readDoc := func() *spec.Swagger {
+		b, err := os.ReadFile(filepath.Join(outputDir, "swagger.json"))
+		require.NoError(t, err)
+
+		var doc spec.Swagger
+		require.NoError(t, json.Unmarshal(b, &doc))
+
+		return &doc
+	}
+
+	writeDoc := func(doc *spec.Swagger) {
+		b, err := json.Marshal(doc)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(outputDir, "swagger.json"), b, 0644))
+	}
+
+	config := newConfig()
+	config.OnlyRoutes = "/testapi/*"
+	assert.ErrorContains(t, New().Build(config), "selective generation needs a document generated before")
+
+	require.NoError(t, New().Build(newConfig()))
+
+	doc := readDoc()
+	doc.Paths.Paths["/file/upload"].Post.Summary = "kept"
+	doc.Paths.Paths["/testapi/stale"] = spec.PathItem{PathItemProps: spec.PathItemProps{Get: spec.NewOperation("stale")}}
+	delete(doc.Definitions, "web.Pet")
+	writeDoc(doc)
+
+	config = newConfig()
+	config.OnlyRoutes = "/testapi/*"
+	require.NoError(t, New().Build(config))
+
+	doc = readDoc()
+	assert.Equal(t, "kept", doc.Paths.Paths["/file/upload"].Post.Summary)
+	assert.NotContains(t, doc.Paths.Paths, "/testapi/stale")
+	assert.NotNil(t, doc.Paths.Paths["/testapi/get-string-by-int/{some_id}"].Get)
+	assert.Contains(t, doc.Definitions, "web.Pet")
+
+	doc.Paths.Paths["/testapi/stale"] = spec.PathItem{PathItemProps: spec.PathItemProps{Get: spec.NewOperation("stale")}}
+	delete(doc.Paths.Paths, "/file/upload")
+	writeDoc(doc)
+
+	config = newConfig()
+	config.OnlyPackages = "../testdata/simple/api"
+	require.NoError(t, New().Build(config))
+
+	doc = readDoc()
+	assert.NotContains(t, doc.Paths.Paths, "/testapi/stale")
+	assert.NotNil(t, doc.Paths.Paths["/file/upload"].Post)
+	assert.NotEqual(t, "kept", doc.Paths.Paths["/file/upload"].Post.Summary)
+}
+
+func TestGen_BuildContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	config := &Config{
+		SearchDir:          "../testdata/simple",
+		MainAPIFile:        "./main.go",
+		OutputDir:          filepath.Join(t.TempDir(), "docs"),
+		OutputTypes:        outputTypes,
+		PropNamingStrategy: swag.CamelCase,
+	}
+
+	assert.ErrorIs(t, New().BuildContext(ctx, config), context.Canceled)
+
+	_, err := os.Stat(config.OutputDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGen_OpenAPIVersion(t *testing.T) {
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/docs_openapi",
+		OutputTypes:        outputTypes,
+		PropNamingStrategy: swag.CamelCase,
+		OpenAPIVersion:     "3.0",
+	}
+	defer os.RemoveAll(config.OutputDir)
+
+	require.NoError(t, New().Build(config))
+
+	for name, shouldExist := range map[string]bool{
+		"docs.go":      true,
+		"openapi.json": true,
+		"openapi.yaml": true,
+		"swagger.json": false,
+		"swagger.yaml": false,
+	} {
+		_, err := os.Stat(filepath.Join(config.OutputDir, name))
+		assert.Equal(t, shouldExist, err == nil, name)
+	}
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "openapi.json"))
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(b, &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.NotContains(t, doc, "swagger")
+	assert.Contains(t, doc["components"], "schemas")
+
+	goCMD, err := exec.LookPath("go")
+	require.NoError(t, err)
+
+	cmd := exec.Command(goCMD, "build", filepath.Join(config.OutputDir, "docs.go"))
+	cmd.Stderr = os.Stderr
+	assert.NoError(t, cmd.Run())
+
+	require.NoError(t, os.RemoveAll(config.OutputDir))
+
+	config.OpenAPIVersion = "2.0,3.0"
+	require.NoError(t, New().Build(config))
+
+	for _, name := range []string{"docs.go", "openapi.json", "openapi.yaml", "swagger.json", "swagger.yaml"} {
+		_, err := os.Stat(filepath.Join(config.OutputDir, name))
+		assert.NoError(t, err, name)
+	}
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "docs.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"swagger": "2.0"`)
+
+	config.OpenAPIVersion = "3.1"
+	assert.EqualError(t, New().Build(config), `unsupported OpenAPI version "3.1", expected 2.0 or 3.0`)
+}
+
+func TestGen_writeGoDocOpenAPI(t *testing.T) {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Swagger:  "2.0",
+			Info:     &spec.Info{InfoProps: spec.InfoProps{Title: "API", Version: "1.0"}},
+			Host:     "api.example.com",
+			BasePath: "/v1",
+			Schemes:  []string{"https"},
+			Paths:    &spec.Paths{Paths: map[string]spec.PathItem{}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, New().writeGoDocOpenAPI("docs", &buf, swagger, &Config{
+		InstanceName:       swag.Name,
+		LeftTemplateDelim:  "{{",
+		RightTemplateDelim: "}}",
+	}))
+
+	code := buf.String()
+	start := strings.Index(code, "= `") + len("= `")
+	docTemplate := code[start : start+strings.Index(code[start:], "`")]
+
+	doc := (&swag.Spec{
+		Title:           "Runtime title",
+		Version:         "2.0",
+		SwaggerTemplate: docTemplate,
+	}).ReadDoc()
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal([]byte(doc), &document))
+	assert.Equal(t, "3.0.3", document["openapi"])
+	assert.NotContains(t, document, "schemes")
+	assert.Equal(t, map[string]any{"title": "Runtime title", "description": "", "version": "2.0"}, document["info"])
+	assert.Equal(t, []any{map[string]any{"url": "https://api.example.com/v1"}}, document["servers"])
+}
+
+func TestGen_PackageCache(t *testing.T) {
+	cache := swag.NewPackageCache()
+
+	var documents []string
+
+	for i := 0; i < 2; i++ {
+		config := &Config{
+			SearchDir:          searchDir,
+			MainAPIFile:        "./main.go",
+			OutputDir:          t.TempDir(),
+			OutputTypes:        []string{"json"},
+			PropNamingStrategy: swag.CamelCase,
+			PackageCache:       cache,
+		}
+		require.NoError(t, New().Build(config))
+
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+		require.NoError(t, err)
+
+		documents = append(documents, string(b))
+	}
+
+	assert.Equal(t, documents[0], documents[1])
+}
+
+func TestGen_Jobs(t *testing.T) {
+	files := []string{"docs.go", "swagger.json", "swagger.yaml", "openapi.json", "openapi.yaml"}
+
+	var documents [][]string
+
+	for _, jobs := range []int{1, 4, 0} {
+		config := &Config{
+			SearchDir:          searchDir,
+			MainAPIFile:        "./main.go",
+			OutputDir:          t.TempDir(),
+			OutputTypes:        []string{"go", "json", "yaml", "yml"},
+			PropNamingStrategy: swag.CamelCase,
+			OpenAPIVersion:     "2.0,3.0",
+			PackageName:        "docs",
+			Jobs:               jobs,
+		}
+		require.NoError(t, New().Build(config))
+
+		var contents []string
+
+		for _, file := range files {
+			b, err := os.ReadFile(filepath.Join(config.OutputDir, file))
+			require.NoError(t, err)
+
+			contents = append(contents, string(b))
+		}
+
+		documents = append(documents, contents)
+	}
+
+	assert.Equal(t, documents[0], documents[1])
+	assert.Equal(t, documents[0], documents[2])
+}
+
+func TestGen_HTML(t *testing.T) {
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"html"},
+		PropNamingStrategy: swag.CamelCase,
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "<title>Swagger Example API</title>")
+	assert.Contains(t, string(b), "redoc.standalone.js")
+	assert.Contains(t, string(b), `Redoc.init({"swagger":"2.0",`)
+	assert.Contains(t, string(b), `"/testapi/get-string-by-int/{some_id}":`)
+
+	config.OutputDir = t.TempDir()
+	config.HTMLRenderer = SwaggerUIRenderer
+	config.OpenAPIVersion = "3.0"
+	require.NoError(t, New().Build(config))
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "swagger-ui-bundle.js")
+	assert.Contains(t, string(b), `SwaggerUIBundle({spec: {"openapi":"3.0.3",`)
+
+	config.HTMLRenderer = "unknown"
+	assert.EqualError(t, New().Build(config), `unsupported html renderer "unknown", expected redoc or swagger-ui`)
+}
+
+func TestGen_Output(t *testing.T) {
+	var output bytes.Buffer
+
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          filepath.Join(t.TempDir(), "docs"),
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+	}
+	require.NoError(t, New().Build(config))
+
+	expected, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+
+	config.OutputDir = filepath.Join(t.TempDir(), "docs")
+	config.Output = &output
+	require.NoError(t, New().Build(config))
+	assert.Equal(t, string(expected), output.String())
+
+	// nothing is written to the output directory
+	_, err = os.Stat(config.OutputDir)
+	assert.True(t, os.IsNotExist(err))
+
+	output.Reset()
+	config.OutputTypes = []string{"go"}
+	config.OpenAPIVersion = "2.0,3.0"
+	require.NoError(t, New().Build(config))
+	assert.True(t, strings.HasPrefix(output.String(), "// Package docs Code generated by swaggo/swag."))
+
+	config.OutputTypes = []string{"json", "yaml"}
+	assert.EqualError(t, New().Build(config), "writing to an output needs a single output type, got json,yaml")
+
+	config.OutputTypes = []string{"json"}
+	assert.EqualError(t, New().Build(config), "writing to an output needs a single OpenAPI version, got 2.0,3.0")
+}
+
+func TestGen_OperationsFile(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/route_directives",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+		OperationsFile:     "../testdata/route_directives/operations.yaml",
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &swagger))
+
+	list := swagger.Paths.Paths["/users"].Get
+	require.NotNil(t, list)
+	assert.Equal(t, "listUsers", list.ID)
+	assert.Equal(t, []string{"users"}, list.Tags)
+	assert.Equal(t, "#/definitions/main.User", list.Responses.StatusCodeResponses[200].Schema.Items.Schema.Ref.String())
+
+	del := swagger.Paths.Paths["/users/{id}"].Delete
+	require.NotNil(t, del)
+	assert.Equal(t, "deleteUser", del.ID)
+	assert.Equal(t, "Delete a user", del.Summary)
+	assert.Equal(t, "id", del.Parameters[0].Name)
+
+	config.OperationsFile = "../testdata/route_directives/missing.yaml"
+	assert.Error(t, New().Build(config))
+}
+
+func TestGen_readOperationTemplates(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "templates.yaml")
+	require.NoError(t, os.WriteFile(name, []byte(`readonly:
+  - |
+    @ID       list{name}s
+    @Router   {path} [get]
+  - |
+    @ID       get{name}
+    @Router   {path}/{id} [get]
+`), 0o644))
+
+	templates, err := readOperationTemplates(name)
+	require.NoError(t, err)
+	require.Len(t, templates["readonly"], 2)
+	assert.Equal(t, "@ID       get{name}\n@Router   {path}/{id} [get]\n", templates["readonly"][1])
+
+	_, err = readOperationTemplates(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestTagFileNames(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"pets":      "pets",
+		"Pets":      "Pets_2",
+		"pets/cats": "pets_cats",
+		"pets cats": "pets_cats_2",
+		"pets_2":    "pets_2_2",
+	}, tagFileNames([]string{"pets", "Pets", "pets/cats", "pets cats", "pets_2"}))
+}
+
+func TestGen_SplitByTag(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"json", "yaml"},
+		PropNamingStrategy: swag.CamelCase,
+		OpenAPIVersion:     "2.0,3.0",
+		SplitByTag:         true,
+	}
+	require.NoError(t, New().Build(config))
+
+	for _, name := range []string{"swagger.json", "pets.swagger.json", "admin.swagger.yaml", "pets.openapi.json", "admin.openapi.yaml"} {
+		assert.FileExists(t, filepath.Join(config.OutputDir, name))
+	}
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "pets.swagger.json"))
+	require.NoError(t, err)
+
+	var pets spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &pets))
+
+	assert.Len(t, pets.Paths.Paths, 1)
+	assert.NotNil(t, pets.Paths.Paths["/pets"].Post)
+	require.Len(t, pets.Tags, 1)
+	assert.Equal(t, "pets", pets.Tags[0].Name)
+	assert.Contains(t, pets.Definitions, "main.Owner")
+	assert.NotContains(t, pets.Definitions, "main.Audit")
+
+	config.Output = io.Discard
+	config.OutputTypes = []string{"json"}
+	config.OpenAPIVersion = ""
+	assert.EqualError(t, New().Build(config), "writing to an output can not split the document by tag")
+}
+
+func TestGen_Merge(t *testing.T) {
+	dir := t.TempDir()
+
+	users := filepath.Join(dir, "users", "swagger.json")
+	orders := filepath.Join(dir, "orders.yaml")
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(users), 0o755))
+	require.NoError(t, os.WriteFile(users, []byte(`{
+	"swagger": "2.0",
+	"info": {"title": "Users", "version": "1.0"},
+	"tags": [{"name": "users"}],
+	"paths": {
+		"/users": {"get": {"operationId": "list", "responses": {"200": {"schema": {"$ref": "#/definitions/model.Item"}}}}},
+		"/health": {"get": {"responses": {"200": {"description": "OK"}}}}
+	},
+	"definitions": {
+		"model.Item": {"type": "object", "properties": {"name": {"type": "string"}}},
+		"model.Error": {"type": "object"}
+	}
+}`), 0o644))
+	require.NoError(t, os.WriteFile(orders, []byte(`swagger: "2.0"
+info: {title: Orders, version: "1.0"}
+tags: [{name: orders}]
+paths:
+  /orders:
+    get:
+      operationId: list
+      responses: {"200": {schema: {$ref: "#/definitions/model.Item"}}}
+  /health:
+    get:
+      responses: {"200": {description: OK}}
+definitions:
+  model.Item: {type: object, properties: {id: {type: integer}}}
+  model.Error: {type: object}
+`), 0o644))
+
+	output := filepath.Join(dir, "combined.json")
+	config := &MergeConfig{InputFiles: []string{users, orders}, OutputFile: output}
+
+	err := New().Merge(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "definition model.Item differs in "+users+", "+orders)
+	assert.Contains(t, err.Error(), "route GET /health is in")
+	assert.Contains(t, err.Error(), "operation ID list is in")
+
+	config.Strategy = MergePrefixCollisions
+	require.NoError(t, New().Merge(config))
+
+	b, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	var merged spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &merged))
+
+	assert.Equal(t, "Users", merged.Info.Title)
+	assert.Len(t, merged.Tags, 2)
+	assert.ElementsMatch(t, []string{"users.model.Item", "orders.model.Item", "model.Error"}, sortedKeys(merged.Definitions))
+	assert.ElementsMatch(t, []string{"/users", "/orders", "/users/health", "/orders/health"}, sortedKeys(merged.Paths.Paths))
+	assert.Equal(t, "orders.list", merged.Paths.Paths["/orders"].Get.ID)
+	assert.Equal(t, "#/definitions/orders.model.Item",
+		merged.Paths.Paths["/orders"].Get.Responses.StatusCodeResponses[200].Schema.Ref.String())
+
+	config.Strategy = MergePrefixAll
+	config.Prefixes = []string{"u", "o"}
+	require.NoError(t, New().Merge(config))
+
+	b, err = os.ReadFile(output)
+	require.NoError(t, err)
+
+	var prefixed spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &prefixed))
+	assert.Contains(t, prefixed.Definitions, "o.model.Error")
+	assert.Contains(t, prefixed.Paths.Paths, "/u/users")
+	assert.NotContains(t, prefixed.Paths.Paths, "/users")
+}
+
+func TestGen_MergeDefaults(t *testing.T) {
+	dir := t.TempDir()
+
+	users := filepath.Join(dir, "users.json")
+	orders := filepath.Join(dir, "orders.yaml")
+
+	require.NoError(t, os.WriteFile(users, []byte(`{
+	"swagger": "2.0",
+	"info": {"title": "Users", "version": "1.0"},
+	"host": "users.example.com",
+	"paths": {
+		"/users": {"get": {"responses": {"200": {"description": "OK"}}}}
+	}
+}`), 0o644))
+	require.NoError(t, os.WriteFile(orders, []byte(`swagger: "2.0"
+info: {title: Orders, version: "1.0"}
+host: orders.example.com
+consumes: [application/xml]
+produces: [application/xml]
+security: [{apiKey: []}]
+securityDefinitions:
+  apiKey: {type: apiKey, name: X-API-Key, in: header}
+paths:
+  /orders/{id}:
+    parameters:
+      - {name: id, in: path, required: true, type: integer}
+      - {name: X-Tenant, in: header, type: string}
+    get:
+      parameters:
+        - {name: X-Tenant, in: header, type: string, required: true}
+      responses: {"200": {description: OK}}
+    delete:
+      security: []
+      produces: [application/json]
+      responses: {"204": {description: No Content}}
+`), 0o644))
+
+	output := filepath.Join(dir, "combined.json")
+	require.NoError(t, New().Merge(&MergeConfig{InputFiles: []string{users, orders}, OutputFile: output}))
+
+	b, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	var merged spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &merged))
+
+	assert.Equal(t, "users.example.com", merged.Host)
+	assert.Nil(t, merged.Security)
+	assert.Nil(t, merged.Paths.Paths["/users"].Get.Security)
+	assert.Empty(t, merged.Paths.Paths["/users"].Get.Consumes)
+
+	item := merged.Paths.Paths["/orders/{id}"]
+	assert.Empty(t, item.Parameters)
+
+	get := item.Get
+	assert.Equal(t, []map[string][]string{{"apiKey": {}}}, get.Security)
+	assert.Equal(t, []string{"application/xml"}, get.Consumes)
+	assert.Equal(t, []string{"application/xml"}, get.Produces)
+	require.Len(t, get.Parameters, 2)
+	assert.Equal(t, "X-Tenant", get.Parameters[0].Name)
+	assert.True(t, get.Parameters[0].Required)
+	assert.Equal(t, "id", get.Parameters[1].Name)
+
+	del := item.Delete
+	assert.Empty(t, del.Security)
+	assert.NotNil(t, del.Security)
+	assert.Equal(t, []string{"application/json"}, del.Produces)
+	assert.Len(t, del.Parameters, 2)
+}
+
+func TestGen_JSONSchemas(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"schemas"},
+		PropNamingStrategy: swag.CamelCase,
+		OpenAPIVersion:     "2.0,3.0",
+	}
+	require.NoError(t, New().Build(config))
+
+	entries, err := os.ReadDir(filepath.Join(config.OutputDir, "schemas"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "schemas", "main.Pet.json"))
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(b, &schema))
+	assert.Equal(t, "http://json-schema.org/draft-04/schema#", schema["$schema"])
+	assert.Equal(t, "main.Owner.json", schema["properties"].(map[string]any)["owner"].(map[string]any)["$ref"])
+
+	config.Output = io.Discard
+	config.OpenAPIVersion = ""
+	assert.EqualError(t, New().Build(config), "writing to an output can not hold the schemas of several definitions")
+}
+
+func TestGen_ExtensionsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          dir,
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+		ExtensionsFile:     filepath.Join(dir, "extensions.yaml"),
+	}
+
+	require.NoError(t, os.WriteFile(config.ExtensionsFile, []byte(`x-tagGroups:
+  - name: Shop
+    tags: [pets, admin]
+info:
+  x-logo:
+    url: https://example.com/logo.png
+`), 0o644))
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(dir, "swagger.json"))
+	require.NoError(t, err)
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &swagger))
+
+	assert.Equal(t, []any{map[string]any{"name": "Shop", "tags": []any{"pets", "admin"}}}, swagger.Extensions["x-tagGroups"])
+	assert.Equal(t, map[string]any{"url": "https://example.com/logo.png"}, swagger.Info.Extensions["x-logo"])
+
+	require.NoError(t, os.WriteFile(config.ExtensionsFile, []byte(`tagGroups: []`), 0o644))
+	assert.ErrorContains(t, New().Build(config), "tagGroups is not an extension, the names must start with x-")
+}
+
+func TestGen_TagsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          dir,
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+		TagsFile:           filepath.Join(dir, "tags.yaml"),
+	}
+
+	require.NoError(t, os.WriteFile(config.TagsFile, []byte(`- name: reports
+- name: admin
+  description: Administration of the shop
+  order: 1
+`), 0o644))
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(dir, "swagger.json"))
+	require.NoError(t, err)
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &swagger))
+
+	require.Len(t, swagger.Tags, 3)
+	assert.Equal(t, "admin", swagger.Tags[0].Name)
+	assert.Equal(t, "Administration of the shop", swagger.Tags[0].Description)
+	assert.Equal(t, "reports", swagger.Tags[1].Name)
+	assert.Equal(t, "pets", swagger.Tags[2].Name)
+	assert.Equal(t, "Everything about pets", swagger.Tags[2].Description)
+
+	require.NoError(t, os.WriteFile(config.TagsFile, []byte(`- description: no name`), 0o644))
+	assert.ErrorContains(t, New().Build(config), "tag 1 has no name")
+}
+
+func TestGen_StaticDoc(t *testing.T) {
+	for _, openAPIVersion := range []string{"2.0", "3.0"} {
+		config := &Config{
+			SearchDir:          searchDir,
+			MainAPIFile:        "./main.go",
+			OutputDir:          t.TempDir(),
+			OutputTypes:        []string{"go"},
+			PropNamingStrategy: swag.CamelCase,
+			PackageName:        "docs",
+			StaticDoc:          true,
+			OpenAPIVersion:     openAPIVersion,
+		}
+		require.NoError(t, New().Build(config))
+
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, "docs.go"))
+		require.NoError(t, err)
+
+		src := string(b)
+		assert.Contains(t, src, "Static:           true,", openAPIVersion)
+		assert.Contains(t, src, `"title": "Swagger Example API"`, openAPIVersion)
+		assert.NotContains(t, src, "{{.Host}}", openAPIVersion)
+		assert.NotContains(t, src, "{{escape .Description}}", openAPIVersion)
+	}
+}
+
+func TestGen_DefaultSuccess(t *testing.T) {
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+		DefaultSuccess:     "201:empty",
+	}
+	assert.EqualError(t, New().Build(config), `unsupported default success "201:empty", expected 200:none, 200:empty or error`)
+
+	config.DefaultSuccess = swag.DefaultSuccessEmpty
+	assert.NoError(t, New().Build(config))
+}
+
+func TestGen_RefStrategy(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+		RefStrategy:        "inline",
+	}
+	assert.EqualError(t, New().Build(config), `unsupported ref strategy "inline", expected flatten or bundle`)
+
+	config.RefStrategy = swag.RefStrategyFlatten
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &swagger))
+
+	assert.Empty(t, swagger.Definitions)
+	assert.NotContains(t, string(b), "$ref")
+}
+
+func TestGen_Compact(t *testing.T) {
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"go", "json"},
+		PropNamingStrategy: swag.CamelCase,
+		PackageName:        "docs",
+		Compact:            true,
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(b), "\n")
+	assert.True(t, json.Valid(b))
+
+	b, err = os.ReadFile(filepath.Join(config.OutputDir, "docs.go"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), "const docTemplate = `{\"schemes\":{{ marshal .Schemes }},\"swagger\":\"2.0\",")
+}
+
+func TestGen_CodeSamples(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+		CodeSamples:        []string{"wget"},
+	}
+	assert.EqualError(t, New().Build(config), `unsupported code sample "wget", expected curl or httpie`)
+
+	config.CodeSamples = []string{swag.CodeSampleCurl}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), `"x-codeSamples"`)
+}
+
+func TestGen_Instances(t *testing.T) {
+	dir := t.TempDir()
+
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          dir,
+		OutputTypes:        []string{"go", "json"},
+		PropNamingStrategy: swag.CamelCase,
+		Instances:          []Instance{{Name: "admin", Filter: swag.FilterOptions{Tags: "admin"}}},
+		InstancesFile:      filepath.Join(dir, "instances.yaml"),
+	}
+
+	require.NoError(t, os.WriteFile(config.InstancesFile, []byte(`- name: public
+  visibility: [public]
+`), 0o644))
+	require.NoError(t, New().Build(config))
+
+	// the config of the caller is left unchanged
+	assert.Len(t, config.Instances, 1)
+
+	b, err := os.ReadFile(filepath.Join(dir, "docs.go"))
+	require.NoError(t, err)
+
+	for _, name := range []string{"SwaggerInfo ", "SwaggerInfoadmin ", "SwaggerInfopublic ", "docTemplateadmin "} {
+		assert.Contains(t, string(b), name)
+	}
+
+	paths := func(name string) []string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+
+		var swagger spec.Swagger
+		require.NoError(t, json.Unmarshal(b, &swagger))
+
+		paths := make([]string, 0, len(swagger.Paths.Paths))
+		for path := range swagger.Paths.Paths {
+			paths = append(paths, path)
+		}
+
+		sort.Strings(paths)
+
+		return paths
+	}
+
+	assert.Equal(t, []string{"/admin/audit", "/pets"}, paths("swagger.json"))
+	assert.Equal(t, []string{"/admin/audit"}, paths("admin_swagger.json"))
+	assert.Equal(t, []string{"/pets"}, paths("public_swagger.json"))
+
+	require.NoError(t, os.WriteFile(config.InstancesFile, []byte(`- name: admin`), 0o644))
+	assert.ErrorContains(t, New().Build(config), "instance name admin is used several times")
+
+	require.NoError(t, os.WriteFile(config.InstancesFile, []byte(`- name: admin-v2`), 0o644))
+	assert.ErrorContains(t, New().Build(config), `instance name "admin-v2" is not a Go identifier`)
+}
+
+func TestGen_Environments(t *testing.T) {
+	dir := t.TempDir()
+
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          dir,
+		OutputTypes:        []string{"go", "json", "yaml"},
+		OpenAPIVersion:     "2.0,3.0",
+		PropNamingStrategy: swag.CamelCase,
+		Instances:          []Instance{{Name: "admin", Filter: swag.FilterOptions{Tags: "admin"}}},
+		Environments:       []Environment{{Name: "dev", Host: "dev.example.com", Schemes: []string{"http"}}},
+		EnvironmentsFile:   filepath.Join(dir, "environments.yaml"),
+	}
+
+	require.NoError(t, os.WriteFile(config.EnvironmentsFile, []byte(`- name: prod
+  host: api.example.com
+  basePath: /v2
+`), 0o644))
+	require.NoError(t, New().Build(config))
+
+	for _, name := range []string{
+		"swagger.dev.json", "swagger.prod.yaml", "openapi.dev.json", "openapi.prod.yaml", "admin_swagger.prod.json",
+	} {
+		assert.FileExists(t, filepath.Join(dir, name))
+	}
+
+	assert.NoFileExists(t, filepath.Join(dir, "docs.dev.go"))
+
+	read := func(name string) spec.Swagger {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+
+		var swagger spec.Swagger
+		require.NoError(t, json.Unmarshal(b, &swagger))
+
+		return swagger
+	}
+
+	dev := read("swagger.dev.json")
+	assert.Equal(t, "dev.example.com", dev.Host)
+	assert.Equal(t, []string{"http"}, dev.Schemes)
+
+	prod := read("admin_swagger.prod.json")
+	assert.Equal(t, "api.example.com", prod.Host)
+	assert.Equal(t, "/v2", prod.BasePath)
+	assert.Len(t, prod.Paths.Paths, 1)
+
+	// the generated document is left unchanged
+	assert.Empty(t, read("swagger.json").Host)
+
+	require.NoError(t, os.WriteFile(config.EnvironmentsFile, []byte(`- name: dev`), 0o644))
+	assert.ErrorContains(t, New().Build(config), "environment name dev is used several times")
+
+	require.NoError(t, os.WriteFile(config.EnvironmentsFile, []byte(`- name: dev/2`), 0o644))
+	assert.ErrorContains(t, New().Build(config), `environment name "dev/2" can not be used in file names`)
+}
+
+func TestEnvironmentDocuments(t *testing.T) {
+	swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Schemes: []string{"https"},
+		Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+			"/pets": {PathItemProps: spec.PathItemProps{Get: spec.NewOperation("listPets")}},
+		}},
+		Definitions: spec.Definitions{"Pet": *spec.StringProperty()},
+	}}
+
+	documents, err := environmentDocuments(&Config{Environments: []Environment{{Name: "dev", Host: "dev.example.com"}}},
+		[]instanceDocument{{config: &Config{}, swagger: swagger}})
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+
+	doc := documents[0].swagger
+	assert.Equal(t, "dev.example.com", doc.Host)
+
+	// post-processing an environment document leaves the generated one unchanged
+	doc.Paths.Paths["/pets"].Get.Summary = "changed"
+	doc.Definitions["Pet"] = *spec.Int64Property()
+	doc.Schemes[0] = "http"
+
+	assert.Empty(t, swagger.Paths.Paths["/pets"].Get.Summary)
+	assert.Equal(t, spec.StringOrArray{"string"}, swagger.Definitions["Pet"].Type)
+	assert.Equal(t, []string{"https"}, swagger.Schemes)
+}
+
+func TestGen_Sort(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"json"},
+		PropNamingStrategy: swag.CamelCase,
+		Sort:               true,
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &swagger))
+
+	var tags []string
+	for _, tag := range swagger.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	assert.True(t, sort.StringsAreSorted(tags), tags)
+}
+
+func TestGen_FuzzCorpus(t *testing.T) {
+	dir := t.TempDir()
+
+	input := filepath.Join(dir, "swagger.yaml")
+	require.NoError(t, os.WriteFile(input, []byte(`swagger: "2.0"
+paths:
+  /pets:
+    post:
+      parameters:
+        - name: pet
+          in: body
+          schema:
+            type: object
+            properties:
+              name: {type: string, maxLength: 3}
+`), 0o644))
+
+	corpus := filepath.Join(dir, "corpus")
+	require.NoError(t, New().FuzzCorpus(input, corpus))
+
+	files, err := os.ReadDir(filepath.Join(corpus, "post_pets"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+
+	assert.Equal(t, []string{
+		"001_body_wrong_type.json",
+		"002_body.name_maxLength.json",
+		"003_body.name_maxLength_1.json",
+		"004_body.name_wrong_type.json",
+	}, names)
+
+	b, err := os.ReadFile(filepath.Join(corpus, "post_pets", "003_body.name_maxLength_1.json"))
+	require.NoError(t, err)
+
+	var fuzzCase swag.FuzzCase
+	require.NoError(t, json.Unmarshal(b, &fuzzCase))
+	assert.Equal(t, "POST /pets", fuzzCase.Operation)
+	assert.False(t, fuzzCase.Valid)
+	assert.Equal(t, map[string]any{"name": "aaaa"}, fuzzCase.Body)
+
+	assert.Error(t, New().FuzzCorpus(filepath.Join(dir, "missing.json"), corpus))
+}
+
+func TestGen_K6(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"k6"},
+		PropNamingStrategy: swag.CamelCase,
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "k6.js"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), `http.request("GET", BASE_URL + "/pets"`)
+	assert.Contains(t, string(b), `http.request("GET", BASE_URL + "/admin/audit"`)
+	assert.NoFileExists(t, filepath.Join(config.OutputDir, "swagger.json"))
+}
+
+func TestGen_HTTPFile(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"http"},
+		PropNamingStrategy: swag.CamelCase,
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "requests.http"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), "GET {{baseUrl}}/pets\n")
+	assert.Contains(t, string(b), "GET {{baseUrl}}/admin/audit\n")
+	assert.NoFileExists(t, filepath.Join(config.OutputDir, "swagger.json"))
+}
+
+func TestGen_Terraform(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"terraform"},
+		PropNamingStrategy: swag.CamelCase,
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "terraform.json"))
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(b, &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+	assert.NotContains(t, string(b), "allOf")
+}
+
+func TestGen_CRD(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/simple",
+		MainAPIFile:        "./main.go",
+		PropNamingStrategy: swag.CamelCase,
+	}
+
+	b, err := New().CRD(config, "web.Pet2")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "middlename:\n    nullable: true\n    type: string\n")
+
+	_, err = New().CRD(config, "web.Pet")
+	assert.ErrorContains(t, err, "refers to itself")
+}
+
+func TestGen_ConfigMap(t *testing.T) {
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          t.TempDir(),
+		OutputTypes:        []string{"configmap"},
+		PropNamingStrategy: swag.CamelCase,
+		ConfigMapNamespace: "petstore",
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "configmap.yaml"))
+	require.NoError(t, err)
+
+	var manifest struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Data map[string]string `json:"data"`
+	}
+	require.NoError(t, yaml.Unmarshal(b, &manifest))
+
+	assert.Equal(t, "ConfigMap", manifest.Kind)
+	assert.Equal(t, DefaultConfigMapName, manifest.Metadata.Name)
+	assert.Equal(t, "petstore", manifest.Metadata.Namespace)
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(manifest.Data["swagger.json"]), &swagger))
+	assert.Contains(t, swagger.Paths.Paths, "/pets")
+}
+
+func TestGen_PostHooks(t *testing.T) {
+	outputDir := t.TempDir()
+	hooksLog := filepath.Join(t.TempDir(), "hooks.log")
+
+	config := &Config{
+		SearchDir:          "../testdata/emit",
+		MainAPIFile:        "./main.go",
+		OutputDir:          outputDir,
+		OutputTypes:        []string{"json", "yaml"},
+		PropNamingStrategy: swag.CamelCase,
+		PostHooks:          []string{"echo {file} >> " + hooksLog},
+	}
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(hooksLog)
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(outputDir, "swagger.json")+"\n"+path.Join(outputDir, "swagger.yaml")+"\n", string(b))
+
+	t.Run("failing hook", func(t *testing.T) {
+		config := *config
+		config.OutputDir = t.TempDir()
+		config.PostHooks = []string{"exit 3"}
+
+		err := New().Build(&config)
+		assert.ErrorContains(t, err, `post hook "exit 3" of `+path.Join(config.OutputDir, "swagger.json"))
+	})
+}
+
+func TestGen_Push(t *testing.T) {
+	inputFile := filepath.Join(t.TempDir(), "swagger.json")
+	document := []byte(`{"swagger": "2.0", "info": {"title": "Petstore", "version": "1.0"}, "paths": {}}`)
+	require.NoError(t, os.WriteFile(inputFile, document, 0o644))
+
+	type request struct {
+		method, uri, authorization, contentType string
+		body                                    []byte
+	}
+
+	var received request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = request{r.Method, r.URL.RequestURI(), r.Header.Get("Authorization"), r.Header.Get("Content-Type"), body}
+
+		if strings.HasSuffix(r.URL.Path, "/locked") {
+			http.Error(w, `{"message": "forbidden"}`, http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("swaggerhub", func(t *testing.T) {
+		err := New().Push(context.Background(), &PushConfig{
+			Registry: RegistrySwaggerHub, API: "acme/petstore/1.0", InputFile: inputFile, Token: "key",
+			RegistryURL: server.URL,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, request{
+			http.MethodPost, "/apis/acme/petstore?version=1.0", "key", "application/json", document,
+		}, received)
+	})
+
+	t.Run("apigee", func(t *testing.T) {
+		spec := "projects/acme/locations/global/apis/petstore/versions/v1/specs/openapi"
+
+		err := New().Push(context.Background(), &PushConfig{
+			Registry: RegistryApigee, API: spec, InputFile: inputFile, Token: "token", RegistryURL: server.URL,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.MethodPatch, received.method)
+		assert.Equal(t, "/v1/"+spec+"?allowMissing=true", received.uri)
+		assert.Equal(t, "Bearer token", received.authorization)
+
+		var body struct {
+			Filename string `json:"filename"`
+			MimeType string `json:"mimeType"`
+			Contents []byte `json:"contents"`
+		}
+		require.NoError(t, json.Unmarshal(received.body, &body))
+		assert.Equal(t, "swagger.json", body.Filename)
+		assert.Equal(t, "application/x.openapi;version=2", body.MimeType)
+		assert.Equal(t, document, body.Contents)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Setenv(SwaggerHubAPIKeyEnv, "")
+
+		for config, message := range map[PushConfig]string{
+			{Registry: "postman", API: "acme/petstore/1.0"}:                      `unsupported registry "postman"`,
+			{Registry: RegistrySwaggerHub, API: "petstore"}:                      `invalid SwaggerHub API "petstore", expected owner/name/version`,
+			{Registry: RegistryApigee, API: "apis/petstore", Token: "token"}:     `invalid Apigee spec "apis/petstore"`,
+			{Registry: RegistrySwaggerHub, API: "acme/petstore/1.0"}:             "no credentials to push to swaggerhub, set " + SwaggerHubAPIKeyEnv,
+			{Registry: RegistrySwaggerHub, API: "acme/locked/1.0", Token: "key"}: `push to swaggerhub: 403 Forbidden: {"message": "forbidden"}`,
+		} {
+			config.InputFile, config.RegistryURL = inputFile, server.URL
+
+			err := New().Push(context.Background(), &config)
+			assert.ErrorContains(t, err, message, config.API)
+		}
+	})
+}
+
+func TestGen_Mount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/swagger.json" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+	"swagger": "2.0",
+	"info": {"title": "Payments", "version": "1.0"},
+	"basePath": "/api/v1",
+	"paths": {
+		"/charges": {"post": {"operationId": "createCharge", "responses": {"200": {"schema": {"$ref": "#/definitions/main.Pet"}}}}}
+	},
+	"definitions": {
+		"main.Pet": {"type": "object", "properties": {"id": {"type": "integer"}}},
+		"main.Owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+	}
+}`))
+	}))
+	defer server.Close()
+
+	build := func(t *testing.T, location string) (*spec.Swagger, error) {
+		searchDir := t.TempDir()
+		src, err := os.ReadFile("../testdata/emit/main.go")
+		require.NoError(t, err)
+
+		src = bytes.Replace(src, []byte("// @version 1.0\n"), []byte("// @version 1.0\n// @mount /payments "+location+"\n"), 1)
+		require.NoError(t, os.WriteFile(filepath.Join(searchDir, "main.go"), src, 0o644))
+
+		config := &Config{
+			SearchDir:          searchDir,
+			MainAPIFile:        "./main.go",
+			OutputDir:          t.TempDir(),
+			OutputTypes:        []string{"json"},
+			PropNamingStrategy: swag.CamelCase,
+		}
+		if err := New().Build(config); err != nil {
+			return nil, err
+		}
+
+		var swagger spec.Swagger
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &swagger))
+
+		return &swagger, nil
+	}
+
+	swagger, err := build(t, server.URL+"/swagger.json")
+	require.NoError(t, err)
+
+	assert.Contains(t, swagger.Paths.Paths, "/pets")
+
+	charges := swagger.Paths.Paths["/payments/charges"].Post
+	require.NotNil(t, charges)
+	assert.Equal(t, "createCharge", charges.ID)
+	assert.Equal(t, "#/definitions/payments.main.Pet", charges.Responses.StatusCodeResponses[200].Schema.Ref.String())
+
+	// the identical definition is shared, the differing one prefixed
+	assert.Contains(t, swagger.Definitions, "main.Owner")
+	assert.NotContains(t, swagger.Definitions, "payments.main.Owner")
+	assert.Equal(t, spec.StringOrArray{"string"}, swagger.Definitions["main.Pet"].Properties["name"].Type)
+	assert.Equal(t, spec.StringOrArray{"integer"}, swagger.Definitions["payments.main.Pet"].Properties["id"].Type)
+
+	_, err = build(t, server.URL+"/missing.json")
+	assert.ErrorContains(t, err, "@mount /payments: cannot fetch "+server.URL+"/missing.json: 404 Not Found")
+}
+
+func TestGen_RemoteRefs(t *testing.T) {
+	var fetched int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+
+		switch r.URL.Path {
+		case "/user.json":
+			_, _ = w.Write([]byte(`{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"address": {"$ref": "address.json"},
+		"role": {"$ref": "#/definitions/Role"}
+	},
+	"definitions": {"Role": {"type": "string", "enum": ["admin", "member"]}}
+}`))
+		case "/address.json":
+			_, _ = w.Write([]byte("type: object\nproperties:\n  city:\n    type: string\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	searchDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(searchDir, "main.go"), []byte(`package main
+
+// @title Users
+// @version 1.0
+func main() {}
+
+// @Param   user body     `+server.URL+`/user.json true "the new user"
+// @Success 200  {object} `+server.URL+`/user.json
+// @Router  /users [post]
+func CreateUser() {}
+`), 0o644))
+
+	cacheDir := t.TempDir()
+
+	build := func(t *testing.T) *spec.Swagger {
+		config := &Config{
+			SearchDir:          searchDir,
+			MainAPIFile:        "./main.go",
+			OutputDir:          t.TempDir(),
+			OutputTypes:        []string{"json"},
+			PropNamingStrategy: swag.CamelCase,
+			VendorRemoteRefs:   true,
+			RemoteRefsCacheDir: cacheDir,
+		}
+		require.NoError(t, New().Build(config))
+
+		var swagger spec.Swagger
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &swagger))
+
+		return &swagger
+	}
+
+	swagger := build(t)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	user := swagger.Definitions[host+".user"]
+	address, role := user.Properties["address"], user.Properties["role"]
+
+	assert.Equal(t, "#/definitions/"+host+".user", swagger.Paths.Paths["/users"].Post.Parameters[0].Schema.Ref.String())
+	assert.Equal(t, "#/definitions/"+host+".address", address.Ref.String())
+	assert.Equal(t, "#/definitions/"+host+".user.Role", role.Ref.String())
+	assert.Equal(t, spec.StringOrArray{"string"}, swagger.Definitions[host+".address"].Properties["city"].Type)
+	assert.Len(t, swagger.Definitions[host+".user.Role"].Enum, 2)
+
+	// the documents are fetched once, and read from the cache by the next builds
+	assert.Equal(t, 2, fetched)
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	server.Close()
+
+	assert.Equal(t, swagger, build(t))
+}
+
+func TestGen_CompressDoc(t *testing.T) {
+	docTemplate := func(compress bool) (string, string) {
+		config := &Config{
+			SearchDir:          searchDir,
+			MainAPIFile:        "./main.go",
+			OutputDir:          t.TempDir(),
+			OutputTypes:        []string{"go"},
+			PropNamingStrategy: swag.CamelCase,
+			PackageName:        "docs",
+			CompressDoc:        compress,
+		}
+		require.NoError(t, New().Build(config))
+
+		src, err := os.ReadFile(filepath.Join(config.OutputDir, "docs.go"))
+		require.NoError(t, err)
+
+		file, err := parser.ParseFile(token.NewFileSet(), "docs.go", src, 0)
+		require.NoError(t, err)
+
+		// the template is a string literal, or raw string literals joined around backticks
+		var value strings.Builder
+
+		ast.Inspect(file.Scope.Lookup("docTemplate").Decl.(*ast.ValueSpec), func(node ast.Node) bool {
+			if lit, ok := node.(*ast.BasicLit); ok {
+				s, err := strconv.Unquote(lit.Value)
+				require.NoError(t, err)
+				value.WriteString(s)
+			}
+
+			return true
+		})
+
+		return value.String(), string(src)
+	}
+
+	expected, src := docTemplate(false)
+	assert.Contains(t, src, "SwaggerTemplate: ")
+
+	compressed, src := docTemplate(true)
+	assert.Contains(t, src, "CompressedTemplate: ")
+	assert.Less(t, len(src), len(expected))
+
+	doc := swag.Spec{CompressedTemplate: compressed}
+	uncompressed := swag.Spec{SwaggerTemplate: expected}
+	assert.Equal(t, uncompressed.ReadDoc(), doc.ReadDoc())
+}
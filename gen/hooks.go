@@ -0,0 +1,84 @@
+package gen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// emittedFiles the files written by a build, recorded for Config.PostHooks.
+type emittedFiles struct {
+	mu    sync.Mutex
+	files []string
+}
+
+func (emitted *emittedFiles) add(file string) {
+	emitted.mu.Lock()
+	defer emitted.mu.Unlock()
+
+	emitted.files = append(emitted.files, file)
+}
+
+// withPostHooks returns a copy of config recording the files it writes when it has post hooks.
+func withPostHooks(config *Config) *Config {
+	if len(config.PostHooks) == 0 || config.Output != nil {
+		return config
+	}
+
+	copied := *config
+	copied.emitted = &emittedFiles{}
+
+	return &copied
+}
+
+// runPostHooks runs the post hooks of config for each file it wrote, in the order of the file names since the files
+// are written concurrently.
+func (g *Gen) runPostHooks(ctx context.Context, config *Config) error {
+	if config.emitted == nil {
+		return nil
+	}
+
+	files := config.emitted.files
+	sort.Strings(files)
+
+	for _, file := range files {
+		for _, hook := range config.PostHooks {
+			command := strings.ReplaceAll(hook, "{file}", quoteShellArgument(file))
+
+			g.debug.Printf("run post hook %s", command)
+
+			cmd := shellCommand(ctx, command)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("post hook %q of %s: %w", hook, file, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// shellCommand returns the command running command by the shell of the platform.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// quoteShellArgument quotes argument for the shell of the platform, e.g. a path with spaces.
+func quoteShellArgument(argument string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + argument + `"`
+	}
+
+	return "'" + strings.ReplaceAll(argument, "'", `'\''`) + "'"
+}
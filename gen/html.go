@@ -0,0 +1,108 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag/openapi3"
+)
+
+// HTML renderers of the html output type.
+const (
+	RedocRenderer     = "redoc"
+	SwaggerUIRenderer = "swagger-ui"
+)
+
+// htmlTemplates render a single page with the document embedded, the scripts of the renderers come from their CDN.
+var htmlTemplates = map[string]*template.Template{
+	RedocRenderer: template.Must(template.New(RedocRenderer).Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>{{.Title}}</title>
+    <style>body { margin: 0; padding: 0; }</style>
+</head>
+<body>
+    <div id="redoc"></div>
+    <script src="https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js"></script>
+    <script>
+        Redoc.init({{.Document}}, {}, document.getElementById("redoc"));
+    </script>
+</body>
+</html>
+`)),
+	SwaggerUIRenderer: template.Must(template.New(SwaggerUIRenderer).Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>{{.Title}}</title>
+    <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css">
+</head>
+<body>
+    <div id="swagger-ui"></div>
+    <script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js"></script>
+    <script>
+        SwaggerUIBundle({spec: {{.Document}}, dom_id: "#swagger-ui"});
+    </script>
+</body>
+</html>
+`)),
+}
+
+func (g *Gen) writeHTMLSwagger(config *Config, swagger *spec.Swagger) error {
+	return g.writeHTML(config, swagger, swagger)
+}
+
+func (g *Gen) writeHTMLOpenAPI(config *Config, swagger *spec.Swagger) error {
+	doc, err := openapi3.NewConverter().Convert(swagger)
+	if err != nil {
+		return err
+	}
+
+	return g.writeHTML(config, swagger, doc)
+}
+
+// writeHTML writes index.html, a static page rendering document with config.HTMLRenderer.
+func (g *Gen) writeHTML(config *Config, swagger *spec.Swagger, document any) error {
+	renderer := config.HTMLRenderer
+	if renderer == "" {
+		renderer = RedocRenderer
+	}
+
+	tmpl, ok := htmlTemplates[renderer]
+	if !ok {
+		return fmt.Errorf("unsupported html renderer %q, expected %s or %s", renderer, RedocRenderer, SwaggerUIRenderer)
+	}
+
+	var title string
+	if swagger.Info != nil {
+		title = swagger.Info.Title
+	}
+
+	// html/template escapes the title and embeds document as a JavaScript object
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, struct {
+		Title    string
+		Document any
+	}{
+		Title:    title,
+		Document: document,
+	}); err != nil {
+		return err
+	}
+
+	htmlFileName := path.Join(config.OutputDir, outputFileName(config, "index.html"))
+
+	if err := g.writeFile(config, buffer.Bytes(), htmlFileName); err != nil {
+		return err
+	}
+
+	g.debug.Printf("create index.html at %+v", htmlFileName)
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package gen
+
+import (
+	"path"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// writeHTTPFile writes requests.http, the requests of the operations of swagger for the REST Client of VS Code and
+// the HTTP client of JetBrains IDEs.
+func (g *Gen) writeHTTPFile(config *Config, swagger *spec.Swagger) error {
+	httpFileName := path.Join(config.OutputDir, outputFileName(config, "requests.http"))
+
+	if err := g.writeFile(config, swag.HTTPFile(swagger), httpFileName); err != nil {
+		return err
+	}
+
+	g.debug.Printf("create requests.http at %+v", httpFileName)
+
+	return nil
+}
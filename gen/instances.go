@@ -0,0 +1,126 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// Instance a variant of the document, e.g. an internal one next to the public one, registered by the same
+// docs.go under its own name and written to its own files, e.g. internal_swagger.json.
+type Instance struct {
+	// Name the instance name of the variant, a Go identifier which swag.ReadDoc and the UIs are given
+	Name string
+
+	// Filter selects the operations of the variant, like swag.Parser.Emit
+	Filter swag.FilterOptions
+}
+
+// instanceDocument a document written besides the main one, of an instance or an environment, and the config
+// writing it.
+type instanceDocument struct {
+	config  *Config
+	swagger *spec.Swagger
+}
+
+// instanceDocuments returns the documents of the instances of config, filtered from swagger.
+func instanceDocuments(config *Config, swagger *spec.Swagger) ([]instanceDocument, error) {
+	documents := make([]instanceDocument, 0, len(config.Instances))
+
+	for _, instance := range config.Instances {
+		doc, err := swag.FilterDocument(swagger, instance.Filter)
+		if err != nil {
+			return nil, err
+		}
+
+		instanceConfig := *config
+		instanceConfig.InstanceName = instance.Name
+		instanceConfig.Instances = nil
+
+		documents = append(documents, instanceDocument{config: &instanceConfig, swagger: doc})
+	}
+
+	return documents, nil
+}
+
+// checkInstances checks that the names of the instances are distinct Go identifiers, and differ from the name of
+// the main document.
+func checkInstances(config *Config) error {
+	names := map[string]bool{config.InstanceName: true}
+
+	for _, instance := range config.Instances {
+		if !isIdentifier(instance.Name) {
+			return fmt.Errorf("instance name %q is not a Go identifier", instance.Name)
+		}
+
+		if names[instance.Name] {
+			return fmt.Errorf("instance name %s is used several times", instance.Name)
+		}
+
+		names[instance.Name] = true
+	}
+
+	return nil
+}
+
+// isIdentifier reports whether name is made of letters, digits and underscores, and does not start with a digit.
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if r != '_' && !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return false
+		}
+	}
+
+	return name != ""
+}
+
+// readInstances reads the instances of a JSON or YAML file, a list of objects with a name and the tags, extension
+// and visibility filters.
+func readInstances(name string) ([]Instance, error) {
+	var declarations []struct {
+		Name       string   `json:"name"`
+		Tags       string   `json:"tags"`
+		Extension  string   `json:"extension"`
+		Visibility []string `json:"visibility"`
+	}
+
+	if err := swag.ReadYAMLOrJSON(name, &declarations); err != nil {
+		return nil, err
+	}
+
+	instances := make([]Instance, 0, len(declarations))
+	for _, declaration := range declarations {
+		instances = append(instances, Instance{
+			Name: declaration.Name,
+			Filter: swag.FilterOptions{
+				Tags:       declaration.Tags,
+				Extension:  declaration.Extension,
+				Visibility: declaration.Visibility,
+			},
+		})
+	}
+
+	return instances, nil
+}
+
+// withInstancesFile returns config with the instances of its InstancesFile added to its Instances, leaving config
+// unchanged, after checking their names.
+func withInstancesFile(config *Config) (*Config, error) {
+	if config.InstancesFile != "" {
+		instances, err := readInstances(config.InstancesFile)
+		if err != nil {
+			return nil, err
+		}
+
+		copied := *config
+		copied.Instances = append(append([]Instance{}, config.Instances...), instances...)
+		config = &copied
+	}
+
+	if err := checkInstances(config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,21 @@
+package gen
+
+import (
+	"path"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// writeK6 writes k6.js, the skeleton of a k6 load test script with a request by operation of swagger.
+func (g *Gen) writeK6(config *Config, swagger *spec.Swagger) error {
+	k6FileName := path.Join(config.OutputDir, outputFileName(config, "k6.js"))
+
+	if err := g.writeFile(config, swag.K6Script(swagger), k6FileName); err != nil {
+		return err
+	}
+
+	g.debug.Printf("create k6.js at %+v", k6FileName)
+
+	return nil
+}
@@ -0,0 +1,586 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Strategies of MergeConfig.Strategy, how the names and paths of the merged documents are prefixed.
+const (
+	// MergePrefixNone merges the documents as they are, collisions are errors.
+	MergePrefixNone = "none"
+
+	// MergePrefixCollisions prefixes the colliding definitions, operation IDs and routes by the prefix of their
+	// document.
+	MergePrefixCollisions = "collisions"
+
+	// MergePrefixAll prefixes the definitions, operation IDs and routes of every document.
+	MergePrefixAll = "all"
+)
+
+// MergeConfig presents the documents merged by Merge.
+type MergeConfig struct {
+	// InputFiles the Swagger 2.0 documents to merge, in JSON or YAML. The info and host of the first one are kept,
+	// a gateway serving all of them from one host. The security and mime types of each document are copied into its
+	// operations which do not set them.
+	InputFiles []string
+
+	// OutputFile the merged document, in YAML with a .yaml or .yml extension, standard output in JSON when empty
+	OutputFile string
+
+	// Strategy how the documents are prefixed: none (default), collisions or all. Definitions are prefixed like
+	// users.model.User, operation IDs like users.listUsers and routes like /users/list.
+	Strategy string
+
+	// Prefixes the prefix of each input file, the base name of the file without its extension by default, or the
+	// name of its directory for swagger.json and openapi.json
+	Prefixes []string
+}
+
+// Merge merges the documents of config, e.g. the documents of several services into the document of their
+// gateway. Definitions which are identical in several documents are merged, other definitions, routes and
+// operation IDs of several documents collide. Global parameters, responses and security definitions must be
+// identical.
+func (g *Gen) Merge(config *MergeConfig) error {
+	if len(config.InputFiles) == 0 {
+		return errors.New("no document to merge")
+	}
+
+	if len(config.Prefixes) > 0 && len(config.Prefixes) != len(config.InputFiles) {
+		return fmt.Errorf("expected a prefix for each of the %d documents, got %d",
+			len(config.InputFiles), len(config.Prefixes))
+	}
+
+	strategy := config.Strategy
+	if strategy == "" {
+		strategy = MergePrefixNone
+	}
+
+	switch strategy {
+	case MergePrefixNone, MergePrefixCollisions, MergePrefixAll:
+	default:
+		return fmt.Errorf("unknown merge strategy %q, expected %s, %s or %s",
+			strategy, MergePrefixNone, MergePrefixCollisions, MergePrefixAll)
+	}
+
+	docs := make([]mergedDocument, len(config.InputFiles))
+
+	for i, name := range config.InputFiles {
+		swagger, err := readSwagger(name)
+		if err != nil {
+			return err
+		}
+
+		docs[i] = mergedDocument{name: name, prefix: mergePrefix(name), swagger: swagger}
+		if len(config.Prefixes) > 0 {
+			docs[i].prefix = config.Prefixes[i]
+		}
+
+		if host := docs[0].swagger.Host; swagger.Host != "" && host != "" && swagger.Host != host {
+			g.debug.Printf("warning: the host %s of %s is replaced by the host %s of %s",
+				swagger.Host, name, host, config.InputFiles[0])
+		}
+	}
+
+	merged, err := mergeDocuments(docs, strategy)
+	if err != nil {
+		return err
+	}
+
+	return g.writeDocumentFile(merged, config.OutputFile)
+}
+
+// mergedDocument a document of Merge.
+type mergedDocument struct {
+	name    string
+	prefix  string
+	swagger *spec.Swagger
+}
+
+// mergePrefix returns the default prefix of the document of a file.
+func mergePrefix(name string) string {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	if base == "swagger" || base == "openapi" {
+		if dir := filepath.Base(filepath.Dir(name)); dir != "." && dir != string(filepath.Separator) {
+			return dir
+		}
+	}
+
+	return base
+}
+
+// mergeDocuments merges docs with strategy, the first document is changed.
+func mergeDocuments(docs []mergedDocument, strategy string) (*spec.Swagger, error) {
+	foldBasePaths(docs)
+
+	for _, doc := range docs {
+		localizeDefaults(doc.swagger)
+	}
+
+	collisions := findCollisions(docs)
+
+	if strategy == MergePrefixNone && len(collisions.errs) > 0 {
+		return nil, errors.Join(collisions.errs...)
+	}
+
+	if strategy != MergePrefixNone {
+		for i := range docs {
+			if err := prefixDocument(&docs[i], collisions, strategy == MergePrefixAll); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	merged := docs[0].swagger
+	if merged.Paths == nil {
+		merged.Paths = &spec.Paths{}
+	}
+
+	if merged.Paths.Paths == nil {
+		merged.Paths.Paths = make(map[string]spec.PathItem)
+	}
+
+	for _, doc := range docs[1:] {
+		swagger := doc.swagger
+
+		if swagger.Paths != nil {
+			for path, item := range swagger.Paths.Paths {
+				mergedItem := merged.Paths.Paths[path]
+
+				for _, op := range pathOperations(&item) {
+					*op.ref(&mergedItem) = *op.ref(&item)
+				}
+
+				merged.Paths.Paths[path] = mergedItem
+			}
+		}
+
+		for name, schema := range swagger.Definitions {
+			if merged.Definitions == nil {
+				merged.Definitions = make(spec.Definitions)
+			}
+
+			merged.Definitions[name] = schema
+		}
+
+		var errs []error
+
+		merged.Parameters, errs = mergeGlobals(merged.Parameters, swagger.Parameters, "parameter", doc.name, errs)
+		merged.Responses, errs = mergeGlobals(merged.Responses, swagger.Responses, "response", doc.name, errs)
+		merged.SecurityDefinitions, errs = mergeGlobals(merged.SecurityDefinitions, swagger.SecurityDefinitions,
+			"security definition", doc.name, errs)
+
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+
+		for _, tag := range swagger.Tags {
+			if !hasTag(merged.Tags, tag.Name) {
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+
+		merged.Consumes = appendMissing(merged.Consumes, swagger.Consumes...)
+		merged.Produces = appendMissing(merged.Produces, swagger.Produces...)
+		merged.Schemes = appendMissing(merged.Schemes, swagger.Schemes...)
+	}
+
+	return merged, nil
+}
+
+// localizeDefaults copies the security, mime types and path parameters of swagger into its operations, which keep
+// them once merged with the operations of other documents.
+func localizeDefaults(swagger *spec.Swagger) {
+	if swagger.Paths == nil {
+		return
+	}
+
+	for path, item := range swagger.Paths.Paths {
+		for _, op := range pathOperations(&item) {
+			operation := *op.ref(&item)
+
+			// an empty security, unlike a missing one, is an operation without security
+			if operation.Security == nil {
+				operation.Security = swagger.Security
+			}
+
+			if len(operation.Consumes) == 0 {
+				operation.Consumes = swagger.Consumes
+			}
+
+			if len(operation.Produces) == 0 {
+				operation.Produces = swagger.Produces
+			}
+
+			operation.Parameters = appendPathParameters(operation.Parameters, item.Parameters)
+		}
+
+		item.Parameters = nil
+		swagger.Paths.Paths[path] = item
+	}
+}
+
+// appendPathParameters appends the parameters of a path item which the parameters of its operation do not override.
+func appendPathParameters(params, pathParams []spec.Parameter) []spec.Parameter {
+	key := func(param spec.Parameter) string {
+		if ref := param.Ref.String(); ref != "" {
+			return ref
+		}
+
+		return param.In + " " + param.Name
+	}
+
+	overridden := make(map[string]bool, len(params))
+	for _, param := range params {
+		overridden[key(param)] = true
+	}
+
+	for _, param := range pathParams {
+		if !overridden[key(param)] {
+			params = append(params, param)
+		}
+	}
+
+	return params
+}
+
+// foldBasePaths moves the base paths of docs into their paths when they differ.
+func foldBasePaths(docs []mergedDocument) {
+	same := true
+
+	for _, doc := range docs[1:] {
+		if doc.swagger.BasePath != docs[0].swagger.BasePath {
+			same = false
+		}
+	}
+
+	if same {
+		return
+	}
+
+	for _, doc := range docs {
+		basePath := strings.TrimSuffix(doc.swagger.BasePath, "/")
+		doc.swagger.BasePath = ""
+
+		if basePath == "" || doc.swagger.Paths == nil {
+			continue
+		}
+
+		paths := make(map[string]spec.PathItem, len(doc.swagger.Paths.Paths))
+		for path, item := range doc.swagger.Paths.Paths {
+			paths[basePath+path] = item
+		}
+
+		doc.swagger.Paths.Paths = paths
+	}
+}
+
+// mergeCollisions the names and routes which several documents have.
+type mergeCollisions struct {
+	definitions  map[string]bool
+	routes       map[string]bool
+	operationIDs map[string]bool
+	errs         []error
+}
+
+// findCollisions returns the definitions which differ in several documents, and the routes and operation IDs of
+// several documents.
+func findCollisions(docs []mergedDocument) mergeCollisions {
+	definitions := make(map[string][]int)
+	routes := make(map[string][]int)
+	operationIDs := make(map[string][]int)
+
+	for i, doc := range docs {
+		for name := range doc.swagger.Definitions {
+			definitions[name] = append(definitions[name], i)
+		}
+
+		if doc.swagger.Paths == nil {
+			continue
+		}
+
+		for path, item := range doc.swagger.Paths.Paths {
+			for _, op := range pathOperations(&item) {
+				routes[op.method+" "+path] = append(routes[op.method+" "+path], i)
+
+				if id := (*op.ref(&item)).ID; id != "" {
+					operationIDs[id] = append(operationIDs[id], i)
+				}
+			}
+		}
+	}
+
+	collisions := mergeCollisions{
+		definitions:  make(map[string]bool),
+		routes:       make(map[string]bool),
+		operationIDs: make(map[string]bool),
+	}
+
+	names := func(indexes []int) string {
+		files := make([]string, len(indexes))
+		for i, index := range indexes {
+			files[i] = docs[index].name
+		}
+
+		return strings.Join(files, ", ")
+	}
+
+	for _, name := range sortedKeys(definitions) {
+		indexes := definitions[name]
+		for _, index := range indexes[1:] {
+			if !reflect.DeepEqual(docs[index].swagger.Definitions[name], docs[indexes[0]].swagger.Definitions[name]) {
+				collisions.definitions[name] = true
+				collisions.errs = append(collisions.errs, fmt.Errorf("definition %s differs in %s", name, names(indexes)))
+
+				break
+			}
+		}
+	}
+
+	for _, route := range sortedKeys(routes) {
+		if indexes := routes[route]; len(indexes) > 1 {
+			collisions.routes[route] = true
+			collisions.errs = append(collisions.errs, fmt.Errorf("route %s is in %s", route, names(indexes)))
+		}
+	}
+
+	for _, id := range sortedKeys(operationIDs) {
+		if indexes := operationIDs[id]; len(indexes) > 1 {
+			collisions.operationIDs[id] = true
+			collisions.errs = append(collisions.errs, fmt.Errorf("operation ID %s is in %s", id, names(indexes)))
+		}
+	}
+
+	return collisions
+}
+
+// prefixDocument prefixes the colliding definitions, operation IDs and routes of doc, or all of them.
+func prefixDocument(doc *mergedDocument, collisions mergeCollisions, all bool) error {
+	renames := make(map[string]string)
+
+	for name := range doc.swagger.Definitions {
+		if all || collisions.definitions[name] {
+			renames[name] = doc.prefix + "." + name
+		}
+	}
+
+	if len(renames) > 0 {
+		definitions := make(spec.Definitions, len(doc.swagger.Definitions))
+		for name, schema := range doc.swagger.Definitions {
+			if rename, ok := renames[name]; ok {
+				name = rename
+			}
+
+			definitions[name] = schema
+		}
+
+		doc.swagger.Definitions = definitions
+
+		swagger, err := renameDefinitionRefs(doc.swagger, renames)
+		if err != nil {
+			return err
+		}
+
+		doc.swagger = swagger
+	}
+
+	if doc.swagger.Paths == nil {
+		return nil
+	}
+
+	paths := make(map[string]spec.PathItem, len(doc.swagger.Paths.Paths))
+
+	for path, item := range doc.swagger.Paths.Paths {
+		for _, op := range pathOperations(&item) {
+			operation := *op.ref(&item)
+
+			if all || collisions.operationIDs[operation.ID] && operation.ID != "" {
+				operation.ID = doc.prefix + "." + operation.ID
+			}
+
+			route := path
+			if all || collisions.routes[op.method+" "+path] {
+				route = "/" + doc.prefix + path
+			}
+
+			prefixed := paths[route]
+			*op.ref(&prefixed) = operation
+			paths[route] = prefixed
+		}
+	}
+
+	doc.swagger.Paths.Paths = paths
+
+	return nil
+}
+
+// renameDefinitionRefs returns a copy of swagger whose references to the definitions of renames are renamed.
+func renameDefinitionRefs(swagger *spec.Swagger, renames map[string]string) (*spec.Swagger, error) {
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+
+	value, err := rewriteRefs(swagger, func(ref string) string {
+		for name, rename := range renames {
+			if ref == "#/definitions/"+escape.Replace(name) {
+				return "#/definitions/" + escape.Replace(rename)
+			}
+		}
+
+		return ref
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var renamed spec.Swagger
+	if err := json.Unmarshal(b, &renamed); err != nil {
+		return nil, err
+	}
+
+	return &renamed, nil
+}
+
+// rewriteRefs returns the JSON value of v, with numbers kept as json.Number, whose $ref are replaced by rewrite.
+func rewriteRefs(v any, rewrite func(ref string) string) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			for key, child := range value {
+				if ref, ok := child.(string); ok && key == "$ref" {
+					value[key] = rewrite(ref)
+
+					continue
+				}
+
+				walk(child)
+			}
+		case []any:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+
+	walk(value)
+
+	return value, nil
+}
+
+// mergeGlobals adds the values of from to into, the values of a name must be identical.
+func mergeGlobals[T any](into, from map[string]T, kind, file string, errs []error) (map[string]T, []error) {
+	for _, name := range sortedKeys(from) {
+		value, ok := into[name]
+		if !ok {
+			if into == nil {
+				into = make(map[string]T, len(from))
+			}
+
+			into[name] = from[name]
+
+			continue
+		}
+
+		if !reflect.DeepEqual(value, from[name]) {
+			errs = append(errs, fmt.Errorf("%s %s of %s differs from the one of a previous document", kind, name, file))
+		}
+	}
+
+	return into, errs
+}
+
+// methodOperation refers to the operation of a method of path items.
+type methodOperation struct {
+	method string
+	ref    func(item *spec.PathItem) **spec.Operation
+}
+
+// pathOperations returns the operations of item by method.
+func pathOperations(item *spec.PathItem) []methodOperation {
+	all := []methodOperation{
+		{"DELETE", func(item *spec.PathItem) **spec.Operation { return &item.Delete }},
+		{"GET", func(item *spec.PathItem) **spec.Operation { return &item.Get }},
+		{"HEAD", func(item *spec.PathItem) **spec.Operation { return &item.Head }},
+		{"OPTIONS", func(item *spec.PathItem) **spec.Operation { return &item.Options }},
+		{"PATCH", func(item *spec.PathItem) **spec.Operation { return &item.Patch }},
+		{"POST", func(item *spec.PathItem) **spec.Operation { return &item.Post }},
+		{"PUT", func(item *spec.PathItem) **spec.Operation { return &item.Put }},
+	}
+
+	operations := all[:0]
+
+	for _, op := range all {
+		if *op.ref(item) != nil {
+			operations = append(operations, op)
+		}
+	}
+
+	return operations
+}
+
+func hasTag(tags []spec.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendMissing appends the values which are not in values yet.
+func appendMissing(values []string, more ...string) []string {
+	for _, value := range more {
+		found := false
+
+		for _, existing := range values {
+			if existing == value {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,113 @@
+package gen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// mountDocuments fetches the documents of mounts and mounts their paths under their prefixes into swagger, which is
+// changed in place. The definitions which differ from the ones of swagger, and the colliding operation IDs, are
+// prefixed by the prefix of the mount, e.g. payments.model.Error, the colliding routes are errors.
+func (g *Gen) mountDocuments(ctx context.Context, swagger *spec.Swagger, mounts []swag.Mount) (*spec.Swagger, error) {
+	// the definitions compare equal to the ones of the fetched documents
+	b, err := json.Marshal(swagger.Definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &swagger.Definitions); err != nil {
+		return nil, err
+	}
+
+	for _, mount := range mounts {
+		b, err := fetchDocument(ctx, mount.Location)
+		if err != nil {
+			return nil, fmt.Errorf("@mount %s: %w", mount.Prefix, err)
+		}
+
+		mounted, err := parseSwagger(b, mount.Location)
+		if err != nil {
+			return nil, fmt.Errorf("@mount %s: %w", mount.Prefix, err)
+		}
+
+		swagger, err = mountDocument(swagger, mount, mounted)
+		if err != nil {
+			return nil, err
+		}
+
+		g.debug.Printf("mount %s at %s", mount.Location, mount.Prefix)
+	}
+
+	return swagger, nil
+}
+
+// mountDocument mounts the paths of mounted under the prefix of mount into swagger.
+func mountDocument(swagger *spec.Swagger, mount swag.Mount, mounted *spec.Swagger) (*spec.Swagger, error) {
+	// the prefix replaces the base path of the mounted document, like the route of a gateway
+	if mounted.Paths != nil {
+		paths := make(map[string]spec.PathItem, len(mounted.Paths.Paths))
+		for path, item := range mounted.Paths.Paths {
+			paths[mount.Prefix+path] = item
+		}
+
+		mounted.Paths.Paths = paths
+	}
+
+	mounted.BasePath = swagger.BasePath
+
+	docs := []mergedDocument{
+		{name: "the generated document", swagger: swagger},
+		{name: mount.Location, prefix: strings.ReplaceAll(strings.Trim(mount.Prefix, "/"), "/", "."), swagger: mounted},
+	}
+
+	collisions := findCollisions(docs)
+
+	if len(collisions.routes) > 0 {
+		var errs []error
+		for _, route := range sortedKeys(collisions.routes) {
+			errs = append(errs, fmt.Errorf("@mount %s: route %s is already declared", mount.Prefix, route))
+		}
+
+		return nil, errors.Join(errs...)
+	}
+
+	if err := prefixDocument(&docs[1], collisions, false); err != nil {
+		return nil, err
+	}
+
+	return mergeDocuments(docs, MergePrefixNone)
+}
+
+// fetchDocument returns the document at location, an http or https URL, or a file.
+func fetchDocument(ctx context.Context, location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return os.ReadFile(location)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cannot fetch %s: %s", location, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
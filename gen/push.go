@@ -0,0 +1,231 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// Registries of PushConfig.Registry.
+const (
+	// RegistrySwaggerHub publishes to SwaggerHub, the API being owner/name/version.
+	RegistrySwaggerHub = "swaggerhub"
+
+	// RegistryApigee publishes to the Apigee API registry, the API being the name of the spec, e.g.
+	// projects/acme/locations/global/apis/petstore/versions/v1/specs/openapi.
+	RegistryApigee = "apigee"
+)
+
+// The environment variables holding the credentials of the registries when PushConfig.Token is empty.
+const (
+	SwaggerHubAPIKeyEnv = "SWAGGERHUB_API_KEY"
+	ApigeeTokenEnv      = "APIGEE_TOKEN"
+)
+
+// The default URLs of the REST APIs of the registries.
+const (
+	DefaultSwaggerHubURL = "https://api.swaggerhub.com"
+	DefaultApigeeURL     = "https://apigeeregistry.googleapis.com"
+)
+
+// apigeeSpecPattern matches the name of a spec of the Apigee API registry.
+var apigeeSpecPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/apis/[^/]+/versions/[^/]+/specs/[^/]+$`)
+
+// PushConfig presents the document published by Push.
+type PushConfig struct {
+	// Registry the registry the document is published to: swaggerhub or apigee
+	Registry string
+
+	// API the API in the registry, owner/name/version for swaggerhub, the name of the spec for apigee
+	API string
+
+	// InputFile the generated document, Swagger 2.0 or OpenAPI 3.0, in JSON or YAML
+	InputFile string
+
+	// Token the API key of SwaggerHub or the OAuth access token of Apigee, read from SWAGGERHUB_API_KEY or
+	// APIGEE_TOKEN when empty
+	Token string
+
+	// RegistryURL the URL of the REST API of the registry, e.g. of an on-premise SwaggerHub, the public one by
+	// default
+	RegistryURL string
+
+	// Client the HTTP client of the requests, http.DefaultClient by default
+	Client *http.Client
+}
+
+// Push publishes the document of config to an API registry, creating the API or the spec when it does not exist and
+// updating it otherwise.
+func (g *Gen) Push(ctx context.Context, config *PushConfig) error {
+	b, err := os.ReadFile(config.InputFile)
+	if err != nil {
+		return err
+	}
+
+	version, err := documentVersion(b, config.InputFile)
+	if err != nil {
+		return err
+	}
+
+	var req *http.Request
+
+	switch config.Registry {
+	case RegistrySwaggerHub:
+		req, err = swaggerHubRequest(ctx, config, b)
+	case RegistryApigee:
+		req, err = apigeeRequest(ctx, config, b, version)
+	default:
+		return fmt.Errorf("unsupported registry %q, expected %s or %s", config.Registry, RegistrySwaggerHub, RegistryApigee)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	client := config.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("push to %s: %w", config.Registry, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return fmt.Errorf("push to %s: %s: %s", config.Registry, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	g.debug.Printf("push %s to %s %s", config.InputFile, config.Registry, config.API)
+
+	return nil
+}
+
+// documentVersion returns the major version of the OpenAPI document b of name, 2 or 3.
+func documentVersion(b []byte, name string) (string, error) {
+	var doc struct {
+		Swagger string `json:"swagger"`
+		OpenAPI string `json:"openapi"`
+	}
+
+	if err := swag.UnmarshalYAMLOrJSON(b, name, &doc); err != nil {
+		return "", err
+	}
+
+	switch {
+	case doc.Swagger == "2.0":
+		return "2", nil
+	case strings.HasPrefix(doc.OpenAPI, "3."):
+		return "3", nil
+	default:
+		return "", fmt.Errorf("%s is not a Swagger 2.0 or OpenAPI 3.0 document", name)
+	}
+}
+
+// registryToken returns the token of config, or the one of the environment variable env.
+func registryToken(config *PushConfig, env string) (string, error) {
+	if config.Token != "" {
+		return config.Token, nil
+	}
+
+	token := os.Getenv(env)
+	if token == "" {
+		return "", fmt.Errorf("no credentials to push to %s, set %s", config.Registry, env)
+	}
+
+	return token, nil
+}
+
+// registryURL returns the URL of the REST API of config, defaultURL when it has none.
+func registryURL(config *PushConfig, defaultURL string) string {
+	if config.RegistryURL == "" {
+		return defaultURL
+	}
+
+	return strings.TrimSuffix(config.RegistryURL, "/")
+}
+
+// swaggerHubRequest returns the request saving the document b as the version of the API of config in SwaggerHub.
+func swaggerHubRequest(ctx context.Context, config *PushConfig, b []byte) (*http.Request, error) {
+	parts := strings.Split(config.API, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid SwaggerHub API %q, expected owner/name/version", config.API)
+	}
+
+	token, err := registryToken(config, SwaggerHubAPIKeyEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	target := fmt.Sprintf("%s/apis/%s/%s?%s", registryURL(config, DefaultSwaggerHubURL),
+		url.PathEscape(parts[0]), url.PathEscape(parts[1]), url.Values{"version": {parts[2]}}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Content-Type", documentContentType(config.InputFile))
+
+	return req, nil
+}
+
+// apigeeRequest returns the request creating or updating the spec of config in the Apigee API registry with the
+// document b of the OpenAPI version.
+func apigeeRequest(ctx context.Context, config *PushConfig, b []byte, version string) (*http.Request, error) {
+	if !apigeeSpecPattern.MatchString(config.API) {
+		return nil, fmt.Errorf("invalid Apigee spec %q, expected projects/p/locations/l/apis/a/versions/v/specs/s",
+			config.API)
+	}
+
+	token, err := registryToken(config, ApigeeTokenEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// the contents are encoded in base64 by json
+	body, err := json.Marshal(map[string]any{
+		"filename": filepath.Base(config.InputFile),
+		"mimeType": "application/x.openapi;version=" + version,
+		"contents": b,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	target := fmt.Sprintf("%s/v1/%s?allowMissing=true", registryURL(config, DefaultApigeeURL), config.API)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// documentContentType returns the content type of a document file, YAML with a .yaml or .yml extension.
+func documentContentType(file string) string {
+	if strings.HasSuffix(file, ".yaml") || strings.HasSuffix(file, ".yml") {
+		return "application/yaml"
+	}
+
+	return "application/json"
+}
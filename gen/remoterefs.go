@@ -0,0 +1,259 @@
+package gen
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// defaultRemoteRefsCacheDir returns the folder of the remote schemas downloaded by Config.VendorRemoteRefs when
+// Config.RemoteRefsCacheDir is empty.
+func defaultRemoteRefsCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "swag", "remote-refs"), nil
+}
+
+// remoteRefVendor copies the remote schemas referred to by a document into its definitions.
+type remoteRefVendor struct {
+	ctx      context.Context
+	cacheDir string
+
+	// existing the definitions of the document
+	existing spec.Definitions
+
+	// documents the fetched documents by URL
+	documents map[string]any
+
+	// names the names of the definitions of the vendored schemas by URL, and their URLs by name
+	names map[string]string
+	urls  map[string]string
+
+	// queue the URLs of the schemas to vendor
+	queue []string
+
+	err error
+}
+
+// vendorRemoteRefs returns a copy of swagger whose references to remote schemas, e.g.
+// https://schemas.example.com/user.json, refer to definitions holding the schemas, named after their URLs, e.g.
+// schemas.example.com.user. The references of the remote schemas are vendored too. The documents are read from
+// cacheDir, or downloaded into it.
+func (g *Gen) vendorRemoteRefs(ctx context.Context, swagger *spec.Swagger, cacheDir string) (*spec.Swagger, error) {
+	vendor := &remoteRefVendor{
+		ctx:       ctx,
+		cacheDir:  cacheDir,
+		existing:  swagger.Definitions,
+		documents: make(map[string]any),
+		names:     make(map[string]string),
+		urls:      make(map[string]string),
+	}
+
+	value, err := rewriteRefs(swagger, func(ref string) string {
+		return vendor.localRef(nil, ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := make(map[string]any)
+
+	for len(vendor.queue) > 0 && vendor.err == nil {
+		location := vendor.queue[0]
+		vendor.queue = vendor.queue[1:]
+
+		schema, err := vendor.resolve(location)
+		if err != nil {
+			return nil, err
+		}
+
+		base, _ := url.Parse(location)
+
+		definitions[vendor.names[location]], err = rewriteRefs(schema, func(ref string) string {
+			return vendor.localRef(base, ref)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		g.debug.Printf("vendor %s as %s", location, vendor.names[location])
+	}
+
+	if vendor.err != nil {
+		return nil, vendor.err
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var vendored spec.Swagger
+	if err := json.Unmarshal(b, &vendored); err != nil {
+		return nil, err
+	}
+
+	for name, definition := range definitions {
+		b, err := json.Marshal(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		var schema spec.Schema
+		if err := json.Unmarshal(b, &schema); err != nil {
+			return nil, fmt.Errorf("remote schema %s: %w", vendor.urls[name], err)
+		}
+
+		if vendored.Definitions == nil {
+			vendored.Definitions = make(spec.Definitions)
+		}
+
+		vendored.Definitions[name] = schema
+	}
+
+	return &vendored, nil
+}
+
+// localRef returns the reference to the definition of the remote schema ref, resolved against base, the URL of the
+// schema it is found in, or ref itself when it is local to the generated document.
+func (vendor *remoteRefVendor) localRef(base *url.URL, ref string) string {
+	if vendor.err != nil {
+		return ref
+	}
+
+	if base == nil && !strings.HasPrefix(ref, "https://") && !strings.HasPrefix(ref, "http://") {
+		return ref
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		vendor.err = fmt.Errorf("invalid reference %s: %w", ref, err)
+
+		return ref
+	}
+
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+
+	location := u.String()
+
+	name, ok := vendor.names[location]
+	if !ok {
+		name = remoteDefinitionName(u)
+
+		if other, ok := vendor.urls[name]; ok {
+			vendor.err = fmt.Errorf("the remote schemas %s and %s have the same name %s", other, location, name)
+
+			return ref
+		}
+
+		if _, ok := vendor.existing[name]; ok {
+			vendor.err = fmt.Errorf("the remote schema %s has the name of the definition %s", location, name)
+
+			return ref
+		}
+
+		vendor.names[location], vendor.urls[name] = name, location
+		vendor.queue = append(vendor.queue, location)
+	}
+
+	return "#/definitions/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// remoteDefinitionName returns the name of the definition of the remote schema u, its host and path without
+// extension, followed by the last token of its JSON pointer, e.g. schemas.example.com.user.Address.
+func remoteDefinitionName(u *url.URL) string {
+	name := u.Host + strings.TrimSuffix(u.Path, filepath.Ext(u.Path))
+
+	if tokens := strings.Split(u.Fragment, "/"); u.Fragment != "" {
+		name += "/" + tokens[len(tokens)-1]
+	}
+
+	return strings.Trim(strings.ReplaceAll(name, "/", "."), ".")
+}
+
+// resolve returns the schema at location, the URL of a document followed by an optional JSON pointer.
+func (vendor *remoteRefVendor) resolve(location string) (any, error) {
+	document, pointer, _ := strings.Cut(location, "#")
+
+	value, ok := vendor.documents[document]
+	if !ok {
+		b, err := vendor.fetch(document)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := swag.UnmarshalYAMLOrJSON(b, document, &value); err != nil {
+			return nil, err
+		}
+
+		vendor.documents[document] = value
+	}
+
+	if pointer == "" {
+		return value, nil
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch v := value.(type) {
+		case map[string]any:
+			value, ok = v[token]
+		case []any:
+			index, err := strconv.Atoi(token)
+			ok = err == nil && index >= 0 && index < len(v)
+
+			if ok {
+				value = v[index]
+			}
+		default:
+			ok = false
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%s has no value at %s", document, pointer)
+		}
+	}
+
+	return value, nil
+}
+
+// fetch returns the document of the URL location, from the cache when it was downloaded before.
+func (vendor *remoteRefVendor) fetch(location string) ([]byte, error) {
+	sum := sha256.Sum256([]byte(location))
+	cached := filepath.Join(vendor.cacheDir, hex.EncodeToString(sum[:]))
+
+	if b, err := os.ReadFile(cached); err == nil {
+		return b, nil
+	}
+
+	b, err := fetchDocument(vendor.ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(vendor.cacheDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(cached, b, 0o644); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
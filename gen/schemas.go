@@ -0,0 +1,60 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// jsonSchemaDraft04 the JSON Schema version of the definitions, the one Swagger 2.0 schemas are based on.
+const jsonSchemaDraft04 = "http://json-schema.org/draft-04/schema#"
+
+// writeJSONSchemas writes each definition of swagger as a JSON Schema file in the schemas directory of OutputDir,
+// e.g. schemas/model.User.json, whose references to the other definitions are the relative paths of their files.
+func (g *Gen) writeJSONSchemas(config *Config, swagger *spec.Swagger) error {
+	dir := filepath.Join(config.OutputDir, outputFileName(config, "schemas"))
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	files := make(map[string]string, len(swagger.Definitions))
+	for name := range swagger.Definitions {
+		files[name] = safeFileName(name) + ".json"
+	}
+
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+
+	for _, name := range sortedKeys(swagger.Definitions) {
+		value, err := rewriteRefs(swagger.Definitions[name], func(ref string) string {
+			if definition, ok := strings.CutPrefix(ref, "#/definitions/"); ok {
+				if file, ok := files[unescape.Replace(definition)]; ok {
+					return file
+				}
+			}
+
+			return ref
+		})
+		if err != nil {
+			return err
+		}
+
+		if schema, ok := value.(map[string]any); ok {
+			schema["$schema"] = jsonSchemaDraft04
+		}
+
+		b, err := g.marshalDocument(config, value)
+		if err != nil {
+			return err
+		}
+
+		if err := g.writeFile(config, b, filepath.Join(dir, files[name])); err != nil {
+			return err
+		}
+	}
+
+	g.debug.Printf("create %d schemas at %+v", len(swagger.Definitions), dir)
+
+	return nil
+}
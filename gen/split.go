@@ -0,0 +1,161 @@
+package gen
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/openapi3"
+	"golang.org/x/sync/errgroup"
+)
+
+// writeSplitByTag writes the document of each tag of swagger in the json and yaml output types of config.
+func (g *Gen) writeSplitByTag(group *errgroup.Group, config *Config, swagger *spec.Swagger, swagger2, openAPI3 bool) error {
+	extensions := make(map[string]bool)
+
+	for _, outputType := range config.OutputTypes {
+		outputType = strings.ToLower(strings.TrimSpace(outputType))
+		if outputType == "yml" {
+			outputType = "yaml"
+		}
+
+		if outputType == "json" || outputType == "yaml" {
+			extensions[outputType] = true
+		}
+	}
+
+	if len(extensions) == 0 {
+		return nil
+	}
+
+	docs, err := swag.SplitByTag(swagger)
+	if err != nil {
+		return err
+	}
+
+	tags := documentTags(swagger)
+	names := tagFileNames(tags)
+
+	for _, tag := range tags {
+		doc, name := docs[tag], names[tag]
+
+		var converted *openapi3.Document
+		if openAPI3 {
+			converted, err = openapi3.NewConverter().Convert(doc)
+			if err != nil {
+				return fmt.Errorf("tag %s: %w", tag, err)
+			}
+		}
+
+		for extension := range extensions {
+			if swagger2 {
+				group.Go(func() error {
+					return g.writeTagDocument(config, doc, name+".swagger."+extension)
+				})
+			}
+
+			if openAPI3 {
+				group.Go(func() error {
+					return g.writeTagDocument(config, converted, name+".openapi."+extension)
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// tagFileNames returns the base name of the files of each tag, the tag with the characters which are not safe in a
+// file name replaced. Tags whose names collide, even in another case, get a _2, _3... suffix in the order of tags.
+func tagFileNames(tags []string) map[string]string {
+	names := make(map[string]string, len(tags))
+	used := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		base := safeFileName(tag)
+		name := base
+
+		for i := 2; used[strings.ToLower(name)]; i++ {
+			name = base + "_" + strconv.Itoa(i)
+		}
+
+		used[strings.ToLower(name)] = true
+		names[tag] = name
+	}
+
+	return names
+}
+
+// writeTagDocument writes doc to the file name of OutputDir, in YAML when its extension is .yaml.
+func (g *Gen) writeTagDocument(config *Config, doc any, name string) error {
+	fileName := path.Join(config.OutputDir, outputFileName(config, name))
+
+	b, err := g.marshalDocument(config, doc)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(name, ".yaml") {
+		b, err = g.jsonToYAML(b)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := g.writeFile(config, b, fileName); err != nil {
+		return err
+	}
+
+	g.debug.Printf("create %s at %+v", name, fileName)
+
+	return nil
+}
+
+// documentTags returns the tags of the operations of swagger, in the order of its tags and then sorted.
+func documentTags(swagger *spec.Swagger) []string {
+	used := make(map[string]bool)
+
+	if swagger.Paths != nil {
+		for _, item := range swagger.Paths.Paths {
+			for _, op := range pathOperations(&item) {
+				for _, tag := range (*op.ref(&item)).Tags {
+					used[tag] = true
+				}
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(used))
+
+	for _, tag := range swagger.Tags {
+		if used[tag.Name] {
+			tags = append(tags, tag.Name)
+			delete(used, tag.Name)
+		}
+	}
+
+	rest := make([]string, 0, len(used))
+	for tag := range used {
+		rest = append(rest, tag)
+	}
+
+	sort.Strings(rest)
+
+	return append(tags, rest...)
+}
+
+// safeFileName replaces the characters of a name which are not safe in a file name by _.
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, name)
+}
@@ -0,0 +1,42 @@
+package gen
+
+import (
+	"path"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/openapi3"
+)
+
+// writeTerraform writes terraform.json, the OpenAPI 3.0 document of swagger restricted to the subset understood by
+// the provider generator of terraform-plugin-framework, and logs the warnings of the transformation.
+func (g *Gen) writeTerraform(config *Config, swagger *spec.Swagger) error {
+	terraform, warnings, err := swag.TerraformDocument(swagger)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		g.debug.Printf("terraform: %s", warning)
+	}
+
+	doc, err := openapi3.NewConverter().Convert(terraform)
+	if err != nil {
+		return err
+	}
+
+	b, err := g.marshalDocument(config, doc)
+	if err != nil {
+		return err
+	}
+
+	terraformFileName := path.Join(config.OutputDir, outputFileName(config, "terraform.json"))
+
+	if err := g.writeFile(config, b, terraformFileName); err != nil {
+		return err
+	}
+
+	g.debug.Printf("create terraform.json at %+v", terraformFileName)
+
+	return nil
+}
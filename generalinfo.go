@@ -0,0 +1,124 @@
+package swag
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// urlAttributes the general API info attributes whose value is an absolute URL.
+var urlAttributes = map[string]bool{
+	tosAttr:         true,
+	conURLAttr:      true,
+	licURLAttr:      true,
+	extDocsURLAttr:  true,
+	"@tag.docs.url": true,
+}
+
+// validateGeneralAPIInfo checks the host, the base path and the URLs of the general API info comments of file, and
+// reports the malformed ones at their file:line as warnings, or as errors when strict.
+func (parser *Parser) validateGeneralAPIInfo(fileSet *token.FileSet, path string, file *ast.File) error {
+	var errs []error
+
+	for _, group := range file.Comments {
+		if !isGeneralAPIComment(commentGroupLines(group)) {
+			continue
+		}
+
+		for _, comment := range group.List {
+			fields := FieldsByAnySpace(strings.TrimSpace(strings.TrimLeft(comment.Text, "/")), 3)
+			if len(fields) < 2 {
+				continue
+			}
+
+			attribute := strings.ToLower(fields[0])
+			value := parser.expandVariables(strings.TrimSpace(strings.Join(fields[1:], " ")))
+
+			// the placeholders of unknown variables are left as they are
+			if strings.Contains(value, "{{") {
+				continue
+			}
+
+			var err error
+
+			switch {
+			case attribute == "@host":
+				err = validateHost(value)
+			case attribute == "@hoststate" && len(fields) == 3:
+				err = validateHost(fields[2])
+			case attribute == "@basepath":
+				err = validateBasePath(value)
+			case urlAttributes[attribute]:
+				err = validateAbsoluteURL(value)
+			}
+
+			if err == nil {
+				continue
+			}
+
+			err = fmt.Errorf("%s:%d: %s %s: %w", path, fileSet.Position(comment.Slash).Line, fields[0], value, err)
+			if parser.Strict {
+				errs = append(errs, err)
+			} else {
+				parser.debug.Printf("warning: %s", err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateHost checks a host, a name or an IP with an optional port, without scheme nor path.
+func validateHost(host string) error {
+	if strings.Contains(host, "://") {
+		return errors.New("must not contain a scheme; declare it with @schemes")
+	}
+
+	if strings.ContainsAny(host, "/?# \t") {
+		return errors.New("must not contain a path; declare it with @BasePath")
+	}
+
+	u, err := url.Parse("//" + host)
+	if err != nil || u.Host != host || u.Hostname() == "" {
+		return errors.New("must be a host like api.example.com or localhost:8080")
+	}
+
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("must have a port between 1 and 65535, not %s", port)
+		}
+	}
+
+	return nil
+}
+
+// validateBasePath checks a base path, which starts with a slash and has neither query nor fragment.
+func validateBasePath(basePath string) error {
+	if !strings.HasPrefix(basePath, "/") {
+		return errors.New("must start with /")
+	}
+
+	if strings.ContainsAny(basePath, "?# \t") {
+		return errors.New("must not contain a query, a fragment or spaces")
+	}
+
+	return nil
+}
+
+// validateAbsoluteURL checks an absolute http or https URL.
+func validateAbsoluteURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+
+	return nil
+}
@@ -180,6 +180,16 @@ func (pkgDefs *PackagesDefinitions) parametrizeGenericType(file *ast.File, origi
 		return typeSpec
 	}
 
+	pkgDefs.genericInstantiations++
+	if pkgDefs.maxGenericInstantiations > 0 && pkgDefs.genericInstantiations > pkgDefs.maxGenericInstantiations {
+		if pkgDefs.limitErr == nil {
+			pkgDefs.limitErr = fmt.Errorf("%w: more than %d instantiations of generic types, instantiating %s",
+				ErrLimitExceeded, pkgDefs.maxGenericInstantiations, fullGenericForm)
+		}
+
+		return nil
+	}
+
 	parametrizedTypeSpec := &TypeSpecDef{
 		File:    original.File,
 		PkgPath: original.PkgPath,
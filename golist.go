@@ -78,6 +78,11 @@ func listOnePackages(ctx context.Context, dir string, env []string, args ...stri
 }
 
 func (parser *Parser) getAllGoFileInfoFromDepsByList(pkg *build.Package, parseFlag ParseFlag) error {
+	return parser.parseFiles(parser.goFilesOfPackage(pkg, parseFlag))
+}
+
+// goFilesOfPackage returns the files of a package listed by go list which should be parsed.
+func (parser *Parser) goFilesOfPackage(pkg *build.Package, parseFlag ParseFlag) []sourceFile {
 	ignoreInternal := pkg.Goroot && !parser.ParseInternal
 	if ignoreInternal { // ignored internal
 		return nil
@@ -88,21 +93,15 @@ func (parser *Parser) getAllGoFileInfoFromDepsByList(pkg *build.Package, parseFl
 	}
 
 	srcDir := pkg.Dir
-	var err error
+	files := make([]sourceFile, 0, len(pkg.GoFiles)+len(pkg.CgoFiles))
 	for i := range pkg.GoFiles {
-		err = parser.parseFile(pkg.ImportPath, filepath.Join(srcDir, pkg.GoFiles[i]), nil, parseFlag)
-		if err != nil {
-			return err
-		}
+		files = append(files, sourceFile{packageDir: pkg.ImportPath, path: filepath.Join(srcDir, pkg.GoFiles[i]), flag: parseFlag})
 	}
 
 	// parse .go source files that import "C"
 	for i := range pkg.CgoFiles {
-		err = parser.parseFile(pkg.ImportPath, filepath.Join(srcDir, pkg.CgoFiles[i]), nil, parseFlag)
-		if err != nil {
-			return err
-		}
+		files = append(files, sourceFile{packageDir: pkg.ImportPath, path: filepath.Join(srcDir, pkg.CgoFiles[i]), flag: parseFlag})
 	}
 
-	return nil
+	return files
 }
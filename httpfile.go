@@ -0,0 +1,164 @@
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+)
+
+// httpFileBoundary the boundary of the multipart bodies of an .http file.
+const httpFileBoundary = "boundary"
+
+// httpPlaceholder matches the placeholders of the sample requests, e.g. {id}.
+var httpPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// HTTPFile returns the requests of doc in the .http format of the REST Client of VS Code and of the HTTP client of
+// JetBrains IDEs, with a request by operation in the order of their paths and methods. The requests are synthesized
+// like the code samples of SetCodeSamples, their placeholders, e.g. {id} or the {X-API-Key} of an api key, become
+// variables declared at the top of the file, next to the baseUrl variable of the host and base path.
+func HTTPFile(doc *spec.Swagger) []byte {
+	var (
+		requests  []string
+		baseURL   string
+		variables = map[string]bool{}
+	)
+
+	if doc.Paths != nil {
+		for _, path := range sortedKeys(doc.Paths.Paths) {
+			item := doc.Paths.Paths[path]
+
+			for _, method := range sortedMethods() {
+				op := *refRouteMethodOp(&item, method)
+				if op == nil {
+					continue
+				}
+
+				request := newSampleRequest(doc, method, path, &item, op)
+				if baseURL == "" {
+					baseURL = request.baseURL
+				}
+
+				requests = append(requests, request.http(op.ID, variables))
+			}
+		}
+	}
+
+	if baseURL == "" {
+		baseURL = "http://localhost"
+	}
+
+	var file bytes.Buffer
+
+	file.WriteString("@baseUrl = " + baseURL + "\n")
+
+	for _, name := range sortedKeys(variables) {
+		file.WriteString("@" + name + " =\n")
+	}
+
+	for _, request := range requests {
+		file.WriteString("\n" + request)
+	}
+
+	return file.Bytes()
+}
+
+// http returns the request in the .http format, named id when not empty, and adds the names of the variables of its
+// placeholders to variables.
+func (request *sampleRequest) http(id string, variables map[string]bool) string {
+	variable := func(s string) string {
+		return httpPlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+			name := httpVariableName(placeholder[1 : len(placeholder)-1])
+			variables[name] = true
+
+			return "{{" + name + "}}"
+		})
+	}
+
+	title := request.method + " " + request.target
+	if id != "" {
+		title = id + ": " + title
+	}
+
+	lines := []string{"### " + title}
+	if id != "" {
+		lines = append(lines, "# @name "+id)
+	}
+
+	lines = append(lines, request.method+" {{baseUrl}}"+variable(request.target))
+
+	for _, header := range request.headers {
+		lines = append(lines, header[0]+": "+variable(header[1]))
+	}
+
+	if request.credentials != "" {
+		variables["username"], variables["password"] = true, true
+		lines = append(lines, "Authorization: Basic {{username}} {{password}}")
+	}
+
+	switch request.contentType {
+	case "":
+	case mimeMultipartForm:
+		lines = append(lines, "Content-Type: "+mimeMultipartForm+"; boundary="+httpFileBoundary)
+	default:
+		lines = append(lines, "Content-Type: "+request.contentType)
+	}
+
+	switch {
+	case request.body != "":
+		body := request.body
+
+		var indented bytes.Buffer
+		if json.Indent(&indented, []byte(body), "", "  ") == nil {
+			body = indented.String()
+		}
+
+		lines = append(lines, "", body)
+	case request.contentType == mimeMultipartForm:
+		lines = append(lines, "")
+
+		for _, field := range request.form {
+			lines = append(lines,
+				"--"+httpFileBoundary,
+				`Content-Disposition: form-data; name="`+field[0]+`"`,
+				"",
+				variable(field[1]))
+		}
+
+		for _, file := range request.files {
+			lines = append(lines,
+				"--"+httpFileBoundary,
+				`Content-Disposition: form-data; name="`+file+`"; filename="`+file+`"`,
+				"",
+				"< ./"+file)
+		}
+
+		lines = append(lines, "--"+httpFileBoundary+"--")
+	case len(request.form) > 0:
+		fields := make([]string, 0, len(request.form))
+		for _, field := range request.form {
+			// the placeholders stay variables
+			value := strings.NewReplacer("%7B", "{", "%7D", "}").Replace(url.QueryEscape(field[1]))
+			fields = append(fields, url.QueryEscape(field[0])+"="+variable(value))
+		}
+
+		lines = append(lines, "", strings.Join(fields, "&"))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// httpVariableName returns the name of the variable of a placeholder, e.g. X_API_Key of X-API-Key.
+func httpVariableName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, name)
+}
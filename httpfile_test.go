@@ -0,0 +1,95 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPFile(t *testing.T) {
+	t.Parallel()
+
+	var doc spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+    "swagger": "2.0",
+    "host": "petstore.example.com",
+    "basePath": "/v1",
+    "schemes": ["https"],
+    "paths": {
+        "/pets/{id}": {
+            "put": {
+                "operationId": "updatePet",
+                "security": [{"ApiKeyAuth": []}],
+                "parameters": [
+                    {"name": "id", "in": "path", "required": true, "type": "integer"},
+                    {"name": "pet", "in": "body", "required": true, "schema": {"type": "object", "example": {"name": "rex"}}}
+                ]
+            }
+        },
+        "/photos": {
+            "post": {
+                "security": [{"BasicAuth": []}],
+                "parameters": [
+                    {"name": "title", "in": "formData", "type": "string", "default": "me"},
+                    {"name": "photo", "in": "formData", "type": "file"}
+                ]
+            }
+        },
+        "/tokens": {
+            "post": {
+                "consumes": ["application/x-www-form-urlencoded"],
+                "parameters": [
+                    {"name": "user", "in": "formData", "type": "string", "required": true},
+                    {"name": "scope", "in": "formData", "type": "string", "default": "read write"}
+                ]
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+        "BasicAuth": {"type": "basic"}
+    }
+}`), &doc))
+
+	assert.Equal(t, `@baseUrl = https://petstore.example.com/v1
+@X_API_Key =
+@id =
+@password =
+@user =
+@username =
+
+### updatePet: PUT /pets/{id}
+# @name updatePet
+PUT {{baseUrl}}/pets/{{id}}
+X-API-Key: {{X_API_Key}}
+Content-Type: application/json
+
+{
+  "name": "rex"
+}
+
+### POST /photos
+POST {{baseUrl}}/photos
+Authorization: Basic {{username}} {{password}}
+Content-Type: multipart/form-data; boundary=boundary
+
+--boundary
+Content-Disposition: form-data; name="title"
+
+me
+--boundary
+Content-Disposition: form-data; name="photo"; filename="photo"
+
+< ./photo
+--boundary--
+
+### POST /tokens
+POST {{baseUrl}}/tokens
+Content-Type: application/x-www-form-urlencoded
+
+user={{user}}&scope=read+write
+`, string(HTTPFile(&doc)))
+}
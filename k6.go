@@ -0,0 +1,196 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+)
+
+// K6Script returns the skeleton of a k6 load test script of doc, with a request by operation, in the order of
+// their paths and methods. The requests are synthesized like the code samples of SetCodeSamples, the base URL comes
+// from the BASE_URL environment variable and the placeholders of headers, e.g. of credentials, from environment
+// variables named after them, e.g. X_API_KEY for {X-API-Key}.
+func K6Script(doc *spec.Swagger) []byte {
+	var requests []k6Request
+
+	if doc.Paths != nil {
+		for _, path := range sortedKeys(doc.Paths.Paths) {
+			item := doc.Paths.Paths[path]
+
+			for _, method := range sortedMethods() {
+				op := *refRouteMethodOp(&item, method)
+				if op == nil {
+					continue
+				}
+
+				name := method + " " + path
+				if op.ID != "" {
+					name = op.ID + ": " + name
+				}
+
+				requests = append(requests, k6Request{
+					sampleRequest: newSampleRequest(doc, method, path, &item, op),
+					name:          name,
+					status:        successStatus(op),
+				})
+			}
+		}
+	}
+
+	baseURL := "http://localhost"
+	if len(requests) > 0 {
+		baseURL = requests[0].baseURL
+	}
+
+	var script strings.Builder
+
+	script.WriteString(`import encoding from "k6/encoding";
+import http from "k6/http";
+import { check, sleep } from "k6";
+
+// the base URL of the API, e.g. BASE_URL=https://staging.example.com/v1 k6 run k6.js
+const BASE_URL = __ENV.BASE_URL || ` + jsString(baseURL) + `;
+
+export const options = {
+  vus: Number(__ENV.VUS || 1),
+  duration: __ENV.DURATION || "30s",
+};
+
+export default function () {
+  let res;
+`)
+
+	for _, request := range requests {
+		script.WriteString(request.k6())
+	}
+
+	script.WriteString(`
+  sleep(1);
+}
+`)
+
+	return []byte(script.String())
+}
+
+// successStatus returns the lowest success status code of op, 0 when it has none.
+func successStatus(op *spec.Operation) int {
+	if op.Responses == nil {
+		return 0
+	}
+
+	codes := make([]int, 0, len(op.Responses.StatusCodeResponses))
+	for code := range op.Responses.StatusCodeResponses {
+		if code >= 200 && code < 400 {
+			codes = append(codes, code)
+		}
+	}
+
+	if len(codes) == 0 {
+		return 0
+	}
+
+	sort.Ints(codes)
+
+	return codes[0]
+}
+
+// k6Request the request of an operation in a k6 script.
+type k6Request struct {
+	*sampleRequest
+
+	// name the operation ID, method and path of the request
+	name string
+
+	// status the expected status code, any success status when 0
+	status int
+}
+
+// k6 returns the statements of the k6 script sending the request and checking its status.
+func (request k6Request) k6() string {
+	var headers []string
+
+	for _, header := range request.headers {
+		headers = append(headers, jsString(header[0])+": "+jsPlaceholder(header[1]))
+	}
+
+	if request.credentials != "" {
+		headers = append(headers,
+			jsString("Authorization")+`: "Basic " + encoding.b64encode(__ENV.USERNAME + ":" + __ENV.PASSWORD)`)
+	}
+
+	if request.contentType != "" && request.contentType != mimeMultipartForm {
+		headers = append(headers, jsString("Content-Type")+": "+jsString(request.contentType))
+	}
+
+	body := "null"
+
+	switch {
+	case request.body != "":
+		body = jsString(request.body)
+	case len(request.form) > 0 || len(request.files) > 0:
+		fields := make([]string, 0, len(request.form)+len(request.files))
+		for _, field := range request.form {
+			fields = append(fields, jsString(field[0])+": "+jsString(field[1]))
+		}
+
+		for _, file := range request.files {
+			// open is only available in the init context of k6
+			fields = append(fields, jsString(file)+": http.file("+jsString("{"+file+"}")+", "+jsString(file)+")")
+		}
+
+		body = "{ " + strings.Join(fields, ", ") + " }"
+	}
+
+	statement := "\n  // " + request.name + "\n" +
+		"  res = http.request(" + jsString(request.method) + ", BASE_URL + " + jsString(request.target) + ", " + body
+
+	if len(headers) > 0 {
+		statement += ", {\n    headers: { " + strings.Join(headers, ", ") + " },\n  }"
+	}
+
+	statement += ");\n"
+
+	if request.status != 0 {
+		statement += fmt.Sprintf("  check(res, { \"status is %d\": (r) => r.status === %d });\n", request.status, request.status)
+	} else {
+		statement += "  check(res, { \"status is 2xx\": (r) => r.status >= 200 && r.status < 300 });\n"
+	}
+
+	return statement
+}
+
+// jsString returns a JavaScript string literal of s.
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+
+	return string(b)
+}
+
+// jsPlaceholder returns the expression of a header value, the environment variable named after a placeholder like
+// {X-API-Key}, or the value.
+func jsPlaceholder(value string) string {
+	if strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}") && len(value) > 2 {
+		return "__ENV." + envName(value[1:len(value)-1]) + " || " + jsString(value)
+	}
+
+	if strings.HasPrefix(value, "Bearer {") {
+		return "\"Bearer \" + (__ENV.TOKEN || " + jsString(strings.TrimPrefix(value, "Bearer ")) + ")"
+	}
+
+	return jsString(value)
+}
+
+// envName returns the name of the environment variable of a placeholder, e.g. X_API_KEY of X-API-Key.
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+
+		return '_'
+	}, name)
+}
@@ -0,0 +1,79 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestK6Script(t *testing.T) {
+	t.Parallel()
+
+	var doc spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+    "swagger": "2.0",
+    "host": "petstore.example.com",
+    "basePath": "/v1",
+    "schemes": ["https"],
+    "paths": {
+        "/pets/{id}": {
+            "put": {
+                "operationId": "updatePet",
+                "security": [{"ApiKeyAuth": []}],
+                "parameters": [
+                    {"name": "id", "in": "path", "required": true, "type": "integer", "x-example": 42},
+                    {"name": "pet", "in": "body", "required": true, "schema": {"type": "object", "example": {"name": "rex"}}}
+                ],
+                "responses": {"204": {"description": "updated"}, "400": {"description": "bad request"}}
+            }
+        },
+        "/photos": {
+            "post": {
+                "security": [{"BasicAuth": []}],
+                "parameters": [
+                    {"name": "title", "in": "formData", "type": "string", "default": "it's me"},
+                    {"name": "photo", "in": "formData", "type": "file"}
+                ]
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+        "BasicAuth": {"type": "basic"}
+    }
+}`), &doc))
+
+	assert.Equal(t, `import encoding from "k6/encoding";
+import http from "k6/http";
+import { check, sleep } from "k6";
+
+// the base URL of the API, e.g. BASE_URL=https://staging.example.com/v1 k6 run k6.js
+const BASE_URL = __ENV.BASE_URL || "https://petstore.example.com/v1";
+
+export const options = {
+  vus: Number(__ENV.VUS || 1),
+  duration: __ENV.DURATION || "30s",
+};
+
+export default function () {
+  let res;
+
+  // updatePet: PUT /pets/{id}
+  res = http.request("PUT", BASE_URL + "/pets/{id}", "{\"name\":\"rex\"}", {
+    headers: { "X-API-Key": __ENV.X_API_KEY || "{X-API-Key}", "Content-Type": "application/json" },
+  });
+  check(res, { "status is 204": (r) => r.status === 204 });
+
+  // POST /photos
+  res = http.request("POST", BASE_URL + "/photos", { "title": "it's me", "photo": http.file("{photo}", "photo") }, {
+    headers: { "Authorization": "Basic " + encoding.b64encode(__ENV.USERNAME + ":" + __ENV.PASSWORD) },
+  });
+  check(res, { "status is 2xx": (r) => r.status >= 200 && r.status < 300 });
+
+  sleep(1);
+}
+`, string(K6Script(&doc)))
+}
@@ -0,0 +1,153 @@
+package swag
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+// TagOverviewExtension is the tag extension holding the HTML rendering of the @tag.overview.markdown files,
+// a longer body for documentation portals than the description.
+const TagOverviewExtension = "x-tag-overview"
+
+// markdownIncludePattern matches include directives like <!-- @include fragment.md --> on their own line.
+var markdownIncludePattern = regexp.MustCompile(`(?m)^[ \t]*<!--\s*@include\s+(\S+)\s*-->[ \t]*$`)
+
+// markdownLinkPattern matches inline markdown links and images like [text](target) or ![alt](target).
+var markdownLinkPattern = regexp.MustCompile(`(!?\[[^\]]*\]\()([^)\s]+)((?:\s+"[^"]*")?\))`)
+
+// getMarkdown reads the markdown file of the given tag, expands its include
+// directives and rewrites relative links against the markdown base URL and the folder of their file.
+func (parser *Parser) getMarkdown(tagName string) ([]byte, error) {
+	content, err := getMarkdownForTag(tagName, parser.markdownFileDir)
+	if err != nil || len(content) == 0 {
+		return content, err
+	}
+
+	// the includes of a file in a folder, e.g. users/create.md, are relative to the folder
+	expanded, err := parser.expandMarkdownIncludes(string(content),
+		filepath.Join(parser.markdownFileDir, filepath.Dir(tagName)), map[string]struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(expanded), nil
+}
+
+// getTagMarkdown reads the space separated markdown files of a tag annotation, or the one named like the tag
+// when there are none, and joins them in their order, separated by a blank line.
+func (parser *Parser) getTagMarkdown(tagName, fileNames string) ([]byte, error) {
+	names := strings.Fields(fileNames)
+	if len(names) == 0 {
+		return parser.getMarkdown(tagName)
+	}
+
+	parts := make([]string, 0, len(names))
+
+	for _, name := range names {
+		content, err := parser.getMarkdown(name)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, strings.TrimRight(string(content), "\n"))
+	}
+
+	return []byte(strings.Join(parts, "\n\n") + "\n"), nil
+}
+
+// renderMarkdown renders markdown to HTML.
+func renderMarkdown(content []byte) string {
+	return string(blackfriday.Run(content))
+}
+
+// expandMarkdownIncludes replaces include directives with the content of the
+// referenced files, which are resolved relative to dir and may include further fragments.
+// The relative links of content are rewritten against dir, the folder of its file, first.
+func (parser *Parser) expandMarkdownIncludes(content, dir string, visiting map[string]struct{}) (string, error) {
+	folder, err := filepath.Rel(parser.markdownFileDir, dir)
+	if err != nil {
+		return "", err
+	}
+
+	content = rewriteMarkdownLinks(content, parser.markdownBaseURL, filepath.ToSlash(folder))
+
+	var expandErr error
+
+	result := markdownIncludePattern.ReplaceAllStringFunc(content, func(directive string) string {
+		if expandErr != nil {
+			return directive
+		}
+
+		fullPath := filepath.Join(dir, markdownIncludePattern.FindStringSubmatch(directive)[1])
+		if _, ok := visiting[fullPath]; ok {
+			expandErr = fmt.Errorf("markdown file %s includes itself recursively", fullPath)
+
+			return directive
+		}
+
+		fragment, err := os.ReadFile(fullPath)
+		if err != nil {
+			expandErr = fmt.Errorf("failed to read markdown fragment %s error: %s", fullPath, err)
+
+			return directive
+		}
+
+		visiting[fullPath] = struct{}{}
+		defer delete(visiting, fullPath)
+
+		expanded, err := parser.expandMarkdownIncludes(strings.TrimRight(string(fragment), "\n"), filepath.Dir(fullPath), visiting)
+		if err != nil {
+			expandErr = err
+
+			return directive
+		}
+
+		return expanded
+	})
+
+	return result, expandErr
+}
+
+// rewriteMarkdownLinks prefixes relative link and image targets with baseURL and dir, the folder of their markdown
+// file relative to the markdown directory, e.g. fragments.
+func rewriteMarkdownLinks(content, baseURL, dir string) string {
+	if baseURL == "" {
+		return content
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/") + "/"
+
+	return markdownLinkPattern.ReplaceAllStringFunc(content, func(link string) string {
+		matches := markdownLinkPattern.FindStringSubmatch(link)
+		target := matches[2]
+
+		if isAbsoluteMarkdownTarget(target) {
+			return link
+		}
+
+		resolved := path.Join(dir, target)
+		if strings.HasSuffix(target, "/") {
+			resolved += "/"
+		}
+
+		return matches[1] + baseURL + resolved + matches[3]
+	})
+}
+
+func isAbsoluteMarkdownTarget(target string) bool {
+	if strings.HasPrefix(target, "/") || strings.HasPrefix(target, "#") {
+		return true
+	}
+
+	if colon := strings.Index(target, ":"); colon > 0 && !strings.ContainsAny(target[:colon], "/?#") {
+		return true // has a scheme, e.g. https: or mailto:
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_getMarkdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("includes and links", func(t *testing.T) {
+		parser := New(SetMarkdownFileDirectory("testdata/markdown_include"), SetMarkdownBaseURL("https://docs.example.com/api/"))
+
+		content, err := parser.getMarkdown("api")
+		assert.NoError(t, err)
+
+		expected := `# API
+
+See the [guide](https://docs.example.com/api/guide.html) and the ![diagram](https://docs.example.com/api/img/flow.png "Flow").
+
+## Auth
+
+Read [RFC 6750](https://tools.ietf.org/html/rfc6750) or the [anchor](#auth).
+
+Contact [support](mailto:support@example.com) or read the [FAQ](https://docs.example.com/api/fragments/faq.html).
+`
+		assert.Equal(t, expected, string(content))
+	})
+
+	t.Run("without base url", func(t *testing.T) {
+		parser := New(SetMarkdownFileDirectory("testdata/markdown_include"))
+
+		content, err := parser.getMarkdown("api")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "[guide](guide.html)")
+	})
+
+	t.Run("file in a folder", func(t *testing.T) {
+		parser := New(SetMarkdownFileDirectory("testdata/markdown_include"))
+
+		content, err := parser.getMarkdown("fragments/auth.md")
+		assert.NoError(t, err)
+		assert.Equal(t, `## Auth
+
+Read [RFC 6750](https://tools.ietf.org/html/rfc6750) or the [anchor](#auth).
+
+Contact [support](mailto:support@example.com) or read the [FAQ](faq.html).
+`, string(content))
+
+		_, err = parser.getMarkdown("../markdown_include/api.md")
+		assert.ErrorContains(t, err, "is not inside the markdown directory")
+	})
+
+	t.Run("recursive include", func(t *testing.T) {
+		parser := New(SetMarkdownFileDirectory("testdata/markdown_include"))
+
+		_, err := parser.getMarkdown("loop")
+		assert.ErrorContains(t, err, "includes itself recursively")
+	})
+}
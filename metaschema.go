@@ -0,0 +1,204 @@
+package swag
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// metaSchemaFiles the JSON schemas of the Swagger 2.0 and OpenAPI 3.0 documents, and of draft 4 which the
+// Swagger 2.0 one refers to.
+//
+//go:embed schemas/*.json
+var metaSchemaFiles embed.FS
+
+// metaSchemaURLs the ids of the meta-schemas by file name.
+var metaSchemaURLs = map[string]string{
+	"jsonschema-draft-04.json": "http://json-schema.org/draft-04/schema",
+	"swagger-2.0.json":         "http://swagger.io/v2/schema.json",
+	"openapi-3.0.json":         "https://spec.openapis.org/oas/3.0/schema/2021-09-28",
+}
+
+var (
+	metaSchemasOnce sync.Once
+	metaSchemas     map[string]*jsonschema.Schema
+	metaSchemasErr  error
+)
+
+// compileMetaSchemas compiles the meta-schemas once, keyed by the major version of the documents they describe.
+func compileMetaSchemas() (map[string]*jsonschema.Schema, error) {
+	metaSchemasOnce.Do(func() {
+		compiler := jsonschema.NewCompiler()
+		compiler.Draft = jsonschema.Draft4
+
+		for name, url := range metaSchemaURLs {
+			b, err := metaSchemaFiles.ReadFile("schemas/" + name)
+			if err != nil {
+				metaSchemasErr = err
+
+				return
+			}
+
+			if err := compiler.AddResource(url, bytes.NewReader(b)); err != nil {
+				metaSchemasErr = err
+
+				return
+			}
+		}
+
+		metaSchemas = make(map[string]*jsonschema.Schema, 2)
+
+		for version, name := range map[string]string{"2": "swagger-2.0.json", "3": "openapi-3.0.json"} {
+			metaSchemas[version], metaSchemasErr = compiler.Compile(metaSchemaURLs[name])
+			if metaSchemasErr != nil {
+				return
+			}
+		}
+	})
+
+	return metaSchemas, metaSchemasErr
+}
+
+// ValidateDocument checks a Swagger 2.0 or OpenAPI 3.0 document in JSON against the schema of its version,
+// which is read from its swagger or openapi field. Issues are sorted by location.
+func ValidateDocument(document []byte) ([]ValidationIssue, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return nil, errors.New("the document is not a JSON object")
+	}
+
+	version := "2"
+
+	if openAPI, ok := root["openapi"].(string); ok {
+		version, _, _ = strings.Cut(openAPI, ".")
+	} else if _, ok := root["swagger"]; !ok {
+		return nil, errors.New("the document has neither a swagger nor an openapi version")
+	}
+
+	schemas, err := compileMetaSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	schema, ok := schemas[version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported OpenAPI version %v, expected 2.0 or 3.0", root["openapi"])
+	}
+
+	var validationErr *jsonschema.ValidationError
+
+	err = schema.Validate(doc)
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var issues []ValidationIssue
+
+	for _, cause := range leafErrors(validationErr) {
+		// the locations are escaped like URL fragments
+		location, err := url.PathUnescape(cause.InstanceLocation)
+		if err != nil {
+			location = cause.InstanceLocation
+		}
+
+		message := cause.Message
+		if strings.HasSuffix(cause.KeywordLocation, "/not") {
+			_, keyword, _ := strings.Cut(cause.AbsoluteKeywordLocation, "#")
+			message = "must not validate against the schema at " + keyword
+		}
+
+		issues = append(issues, ValidationIssue{Location: location, Message: message})
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Location != issues[j].Location {
+			return issues[i].Location < issues[j].Location
+		}
+
+		return issues[i].Message < issues[j].Message
+	})
+
+	return issues, nil
+}
+
+// ValidateDocument checks the parsed document against the Swagger 2.0 schema, issues refer to the annotations
+// which produced them.
+func (parser *Parser) ValidateDocument() ([]ValidationIssue, error) {
+	document, err := json.Marshal(parser.swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.ValidateDocumentOf(document)
+}
+
+// ValidateDocumentOf checks a document generated from the parsed one, like its OpenAPI 3.0 conversion, against
+// the schema of its version, issues refer to the annotations which produced them.
+func (parser *Parser) ValidateDocumentOf(document []byte) ([]ValidationIssue, error) {
+	issues, err := ValidateDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range issues {
+		issues[i].Source = parser.Source(issues[i].Location)
+	}
+
+	return issues, nil
+}
+
+// leafErrors returns the innermost errors of err. Of the alternatives of a failed oneOf or anyOf, only the errors
+// of the one which matches the deepest are kept, since the others were most likely not meant.
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		if err.InstanceLocation == "" {
+			err.InstanceLocation = "/"
+		}
+
+		return []*jsonschema.ValidationError{err}
+	}
+
+	if strings.HasSuffix(err.KeywordLocation, "/oneOf") || strings.HasSuffix(err.KeywordLocation, "/anyOf") {
+		var best []*jsonschema.ValidationError
+
+		bestDepth := -1
+
+		for _, cause := range err.Causes {
+			leaves := leafErrors(cause)
+
+			depth := 0
+			for _, leaf := range leaves {
+				depth = max(depth, strings.Count(leaf.InstanceLocation, "/"))
+			}
+
+			if depth > bestDepth || depth == bestDepth && len(leaves) < len(best) {
+				best, bestDepth = leaves, depth
+			}
+		}
+
+		return best
+	}
+
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+
+	return leaves
+}
@@ -0,0 +1,83 @@
+package swag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDocument(t *testing.T) {
+	t.Parallel()
+
+	issues, err := ValidateDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1.0"},
+		"paths": {
+			"/pets/{id}": {
+				"get": {
+					"parameters": [{"name": "id", "in": "path", "required": true, "type": "int"}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "/paths/~1pets~1{id}/get/parameters/0/type", issues[0].Location)
+
+	issues, err = ValidateDocument([]byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets"},
+		"paths": {
+			"/pets": {
+				"get": {"responses": {"200": {}}}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "/info", issues[0].Location)
+	assert.Equal(t, "missing properties: 'version'", issues[0].Message)
+	assert.Equal(t, "/paths/~1pets/get/responses/200", issues[1].Location)
+
+	_, err = ValidateDocument([]byte(`{"openapi": "4.0.0"}`))
+	assert.EqualError(t, err, "unsupported OpenAPI version 4.0.0, expected 2.0 or 3.0")
+
+	_, err = ValidateDocument([]byte(`{"info": {}}`))
+	assert.Error(t, err)
+}
+
+func TestParser_ValidateDocument(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package test
+
+// @Success 200 {string} string "ok"
+// @Header 200 {object} X-Rate-Limit "limit"
+// @Router /pets [get]
+func Test(){
+}
+`
+	p := New()
+	p.swagger.Swagger = "2.0"
+	p.swagger.Info.Title = "Pets"
+	p.swagger.Info.Version = "1.0"
+
+	err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	require.NoError(t, err)
+
+	_, err = p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	require.NoError(t, err)
+
+	issues, err := p.ValidateDocument()
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "/paths/~1pets/get/responses/200/headers/X-Rate-Limit/type", issues[0].Location)
+	assert.True(t, strings.HasSuffix(issues[0].Source, "api/api.go:4"), issues[0].Source)
+}
@@ -0,0 +1,178 @@
+package swag
+
+import (
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// ModelField is a field of a struct given to a ModelFilter.
+type ModelField struct {
+	// Package the import path of the package declaring the struct
+	Package string
+
+	// Name the name of the field, empty when it is embedded
+	Name string
+
+	// Type the type of the field with the import path of its package, without pointer, e.g. gorm.io/gorm.DeletedAt
+	Type string
+
+	// Tag the tag of the field
+	Tag reflect.StructTag
+
+	propNamingStrategy string
+}
+
+// PropertyName returns the name of the property of a Go field without json tag, following the naming strategy.
+func (field ModelField) PropertyName(name string) string {
+	switch field.propNamingStrategy {
+	case SnakeCase:
+		return toSnakeCase(name)
+	case PascalCase:
+		return name
+	default:
+		return toLowerCamelCase(name)
+	}
+}
+
+// ModelFilter decides how the fields of structs are documented before their types are parsed, e.g. to leave out
+// the bookkeeping fields of an ORM without swaggerignore tags.
+type ModelFilter interface {
+	// FilterField returns skip to leave the field out, or the schema of its type instead of parsing the type.
+	// An object schema of an embedded field adds its properties to the struct. The tags of the field complete
+	// the schema, so it must be a new one on each call. It returns nil and false to parse the field as usual.
+	FilterField(field ModelField) (schema *spec.Schema, skip bool)
+}
+
+// SetModelFilters sets the filters applied to the fields of structs, in order, the first one deciding wins.
+func SetModelFilters(filters ...ModelFilter) func(*Parser) {
+	return func(p *Parser) {
+		p.modelFilters = append(p.modelFilters, filters...)
+	}
+}
+
+// filterModelField applies the model filters to a field of a struct declared in file.
+func (parser *Parser) filterModelField(file *ast.File, field *ast.Field, fieldNames []string) (*spec.Schema, bool) {
+	if len(parser.modelFilters) == 0 {
+		return nil, false
+	}
+
+	modelField := ModelField{propNamingStrategy: parser.PropNamingStrategy}
+
+	if info, ok := parser.packages.files[file]; ok {
+		modelField.Package = info.PackagePath
+	}
+
+	if len(field.Names) > 0 {
+		modelField.Name = field.Names[0].Name
+	}
+
+	if field.Tag != nil {
+		modelField.Tag = reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", ""))
+	}
+
+	if typeName, err := getFieldType(file, field.Type, nil); err == nil {
+		modelField.Type = qualifiedTypeName(file, modelField.Package, typeName)
+	}
+
+	for _, filter := range parser.modelFilters {
+		if schema, skip := filter.FilterField(modelField); schema != nil || skip {
+			if skip {
+				parser.debug.Printf("Skipping field %v of type %s by model filter", fieldNames, modelField.Type)
+			}
+
+			return schema, skip
+		}
+	}
+
+	return nil, false
+}
+
+// qualifiedTypeName replaces the package name of typeName, used in file of package pkgPath, with its import path.
+func qualifiedTypeName(file *ast.File, pkgPath, typeName string) string {
+	pkgName, name, found := strings.Cut(typeName, ".")
+	if !found {
+		if IsGolangPrimitiveType(typeName) || typeName == "any" || typeName == "error" {
+			return typeName
+		}
+
+		return pkgPath + "." + typeName
+	}
+
+	for _, imp := range file.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+
+		if imp.Name != nil {
+			if imp.Name.Name == pkgName {
+				return path + "." + name
+			}
+
+			continue
+		}
+
+		// versioned import paths like gopkg.in/yaml.v3 or github.com/x/y/v2 are left out
+		if path == pkgName || strings.HasSuffix(path, "/"+pkgName) {
+			return path + "." + name
+		}
+	}
+
+	return typeName
+}
+
+// GormFilter documents the bookkeeping fields of GORM models: the soft delete timestamp gorm.DeletedAt as a nullable
+// date-time, or not at all with SkipDeletedAt, and the embedded gorm.Model without parsing the GORM sources.
+type GormFilter struct {
+	SkipDeletedAt bool
+}
+
+// FilterField implements ModelFilter.
+func (f GormFilter) FilterField(field ModelField) (*spec.Schema, bool) {
+	switch field.Type {
+	case "gorm.io/gorm.DeletedAt":
+		if f.SkipDeletedAt {
+			return nil, true
+		}
+
+		return nullableDateTime(), false
+	case "gorm.io/gorm.Model", "github.com/jinzhu/gorm.Model":
+		if field.Name != "" {
+			return nil, false
+		}
+
+		schema := &spec.Schema{}
+		schema.Type = []string{OBJECT}
+		schema.Properties = map[string]spec.Schema{
+			field.PropertyName("ID"):        *PrimitiveSchema(INTEGER),
+			field.PropertyName("CreatedAt"): *spec.DateTimeProperty(),
+			field.PropertyName("UpdatedAt"): *spec.DateTimeProperty(),
+		}
+
+		if !f.SkipDeletedAt {
+			schema.Properties[field.PropertyName("DeletedAt")] = *nullableDateTime()
+		}
+
+		return schema, false
+	}
+
+	return nil, false
+}
+
+func nullableDateTime() *spec.Schema {
+	schema := spec.DateTimeProperty()
+	schema.AddExtension(NullableExtension, true)
+
+	return schema
+}
+
+// EntFilter leaves out the Edges field of the models generated by ent, which holds the loaded relations.
+type EntFilter struct{}
+
+// FilterField implements ModelFilter.
+func (EntFilter) FilterField(field ModelField) (*spec.Schema, bool) {
+	edges := field.Name == "Edges" && strings.HasSuffix(field.Type, "Edges") &&
+		strings.HasPrefix(field.Type, field.Package+".")
+
+	return nil, edges
+}
@@ -0,0 +1,90 @@
+package swag
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+const modelFilterSource = `
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type User struct {
+	gorm.Model
+	Name  string
+	Edges UserEdges ` + "`json:\"edges\"`" + `
+}
+
+type UserEdges struct {
+	Pets []string
+}
+
+type Account struct {
+	ID        uint
+	CreatedAt time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+// @Success 200 {object} User
+// @Success 201 {object} Account
+// @Router /users [get]
+func GetUser() {}
+`
+
+func parseModelFilterSource(t *testing.T, options ...func(*Parser)) map[string]spec.Schema {
+	p := New(options...)
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", modelFilterSource, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	assert.NoError(t, err)
+	assert.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	return p.swagger.Definitions
+}
+
+func TestParser_ModelFilters(t *testing.T) {
+	t.Parallel()
+
+	definitions := parseModelFilterSource(t, SetModelFilters(GormFilter{}, EntFilter{}))
+
+	user := definitions["api.User"]
+	assert.Equal(t, []string{"createdAt", "deletedAt", "id", "name", "updatedAt"}, sortedKeys(user.Properties))
+	assert.Equal(t, "date-time", user.Properties["deletedAt"].Format)
+	assert.Equal(t, true, user.Properties["deletedAt"].Extensions["x-nullable"])
+	assert.NotContains(t, definitions, "api.UserEdges")
+
+	account := definitions["api.Account"]
+	assert.Equal(t, []string{"string"}, []string(account.Properties["deletedAt"].Type))
+	assert.Equal(t, true, account.Properties["deletedAt"].Extensions["x-nullable"])
+
+	definitions = parseModelFilterSource(t, SetModelFilters(GormFilter{SkipDeletedAt: true}))
+	assert.NotContains(t, definitions["api.User"].Properties, "deletedAt")
+	assert.Contains(t, definitions["api.User"].Properties, "edges")
+	assert.NotContains(t, definitions["api.Account"].Properties, "deletedAt")
+}
+
+func TestQualifiedTypeName(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	assert.NoError(t, p.packages.ParseFile("api", "api/api.go", modelFilterSource, ParseAll))
+
+	var file *ast.File
+	for f := range p.packages.files {
+		file = f
+	}
+
+	assert.Equal(t, "gorm.io/gorm.Model", qualifiedTypeName(file, "api", "gorm.Model"))
+	assert.Equal(t, "time.Time", qualifiedTypeName(file, "api", "time.Time"))
+	assert.Equal(t, "api.UserEdges", qualifiedTypeName(file, "api", "UserEdges"))
+	assert.Equal(t, "string", qualifiedTypeName(file, "api", "string"))
+	assert.Equal(t, "other.Type", qualifiedTypeName(file, "api", "other.Type"))
+}
@@ -0,0 +1,207 @@
+package swag
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+var licenseFileNames = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "COPYING"}
+
+var codeOwnersPaths = []string{"CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS"}
+
+var spdxIdentifierPattern = regexp.MustCompile(`SPDX-License-Identifier:\s*([\w.\-+]+)`)
+
+// licenseSignatures maps well known license texts to SPDX identifiers, ordered
+// so that more specific texts are matched first.
+var licenseSignatures = []struct {
+	spdxID   string
+	keywords []string
+}{
+	{"AGPL-3.0", []string{"GNU AFFERO GENERAL PUBLIC LICENSE", "Version 3"}},
+	{"LGPL-3.0", []string{"GNU LESSER GENERAL PUBLIC LICENSE", "Version 3"}},
+	{"LGPL-2.1", []string{"GNU LESSER GENERAL PUBLIC LICENSE", "Version 2.1"}},
+	{"GPL-3.0", []string{"GNU GENERAL PUBLIC LICENSE", "Version 3"}},
+	{"GPL-2.0", []string{"GNU GENERAL PUBLIC LICENSE", "Version 2"}},
+	{"Apache-2.0", []string{"Apache License", "Version 2.0"}},
+	{"MPL-2.0", []string{"Mozilla Public License", "2.0"}},
+	{"BSD-3-Clause", []string{"Redistribution and use in source and binary forms", "Neither the name"}},
+	{"BSD-2-Clause", []string{"Redistribution and use in source and binary forms"}},
+	{"ISC", []string{"Permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"MIT", []string{"Permission is hereby granted, free of charge"}},
+	{"Unlicense", []string{"This is free and unencumbered software released into the public domain"}},
+}
+
+// gitDescribeTag returns the latest git tag reachable from HEAD in dir.
+var gitDescribeTag = func(dir string) (string, error) {
+	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// inferInfoFromModule fills license, contact and version of the swagger info from
+// the module containing dir, when they were not set by annotations.
+func (parser *Parser) inferInfoFromModule(dir string) {
+	root, modulePath := findModuleRoot(dir)
+	if root == "" {
+		parser.debug.Printf("warning: cannot infer info, no go.mod found from %s", dir)
+
+		return
+	}
+
+	info := parser.swagger.Info
+
+	if info.License == nil {
+		if spdxID := detectLicense(root); spdxID != "" {
+			info.License = &spec.License{
+				LicenseProps: spec.LicenseProps{
+					Name: spdxID,
+					URL:  "https://spdx.org/licenses/" + spdxID + ".html",
+				},
+			}
+		}
+	}
+
+	if isEmptyContact(info.Contact) {
+		// an empty contact is left as it is when nothing is inferred
+		var contact spec.ContactInfo
+		inferContact(&contact, root, modulePath)
+
+		if !isEmptyContact(&contact) {
+			info.Contact = &contact
+		}
+	}
+
+	if info.Version == "" {
+		tag, err := gitDescribeTag(root)
+		if err != nil {
+			parser.debug.Printf("warning: cannot infer version from git tags: %s", err)
+		} else {
+			info.Version = tag
+		}
+	}
+}
+
+// findModuleRoot walks up from dir and returns the directory and module path of the nearest go.mod.
+func findModuleRoot(dir string) (string, string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+
+	for {
+		if content, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			for _, line := range strings.Split(string(content), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) == 2 && fields[0] == "module" {
+					return dir, strings.Trim(fields[1], `"`)
+				}
+			}
+
+			return dir, ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+
+		dir = parent
+	}
+}
+
+// detectLicense returns the SPDX identifier of the license file in dir, if any.
+func detectLicense(dir string) string {
+	for _, name := range licenseFileNames {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+
+		return detectSPDXIdentifier(string(content))
+	}
+
+	return ""
+}
+
+func detectSPDXIdentifier(text string) string {
+	if matches := spdxIdentifierPattern.FindStringSubmatch(text); len(matches) == 2 {
+		return matches[1]
+	}
+
+	text = strings.Join(strings.Fields(text), " ")
+
+	for _, signature := range licenseSignatures {
+		matched := true
+
+		for _, keyword := range signature.keywords {
+			if !strings.Contains(text, keyword) {
+				matched = false
+
+				break
+			}
+		}
+
+		if matched {
+			return signature.spdxID
+		}
+	}
+
+	return ""
+}
+
+// isEmptyContact reports whether contact is nil or has no name, URL and email.
+func isEmptyContact(contact *spec.ContactInfo) bool {
+	return contact == nil || contact.Name == "" && contact.URL == "" && contact.Email == ""
+}
+
+// inferContact fills contact from the default owner in CODEOWNERS, falling back to the module path.
+func inferContact(contact *spec.ContactInfo, root, modulePath string) {
+	if owner := defaultCodeOwner(root); owner != "" {
+		if strings.HasPrefix(owner, "@") {
+			contact.Name = owner[1:]
+			if strings.HasPrefix(modulePath, "github.com/") {
+				contact.URL = "https://github.com/" + strings.SplitN(owner[1:], "/", 2)[0]
+			}
+		} else {
+			contact.Email = owner
+		}
+
+		return
+	}
+
+	parts := strings.Split(modulePath, "/")
+	if len(parts) >= 3 && strings.Contains(parts[0], ".") {
+		contact.Name = parts[1]
+		contact.URL = "https://" + strings.Join(parts[:3], "/")
+	}
+}
+
+// defaultCodeOwner returns the first owner of the catch-all rule in CODEOWNERS.
+func defaultCodeOwner(root string) string {
+	for _, name := range codeOwnersPaths {
+		content, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == "*" {
+				return fields[1]
+			}
+		}
+	}
+
+	return ""
+}
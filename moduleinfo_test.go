@@ -0,0 +1,84 @@
+package swag
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectSPDXIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"SPDX-License-Identifier: BSD-3-Clause":                                        "BSD-3-Clause",
+		"MIT License\n\nPermission is hereby granted, free of charge, to any person":   "MIT",
+		"Apache License\n                           Version 2.0, January 2004":         "Apache-2.0",
+		"GNU LESSER GENERAL PUBLIC LICENSE\n                       Version 3, 29 June": "LGPL-3.0",
+		"GNU GENERAL PUBLIC LICENSE\n                       Version 2, June 1991":      "GPL-2.0",
+		"All rights reserved.": "",
+	}
+	for text, expected := range tests {
+		assert.Equal(t, expected, detectSPDXIdentifier(text), text)
+	}
+}
+
+func TestParser_inferInfoFromModule(t *testing.T) {
+	gitDescribe := gitDescribeTag
+	defer func() { gitDescribeTag = gitDescribe }()
+
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/example/petstore\n\ngo 1.20\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "LICENSE"), []byte("MIT License\n\nPermission is hereby granted, free of charge, to any person obtaining a copy"), 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "cmd", "api"), 0o755))
+
+	t.Run("from module path", func(t *testing.T) {
+		gitDescribeTag = func(dir string) (string, error) {
+			return "v1.4.2", nil
+		}
+
+		parser := New()
+		parser.inferInfoFromModule(filepath.Join(root, "cmd", "api"))
+
+		info := parser.swagger.Info
+		assert.Equal(t, "MIT", info.License.Name)
+		assert.Equal(t, "https://spdx.org/licenses/MIT.html", info.License.URL)
+		assert.Equal(t, "example", info.Contact.Name)
+		assert.Equal(t, "https://github.com/example/petstore", info.Contact.URL)
+		assert.Equal(t, "v1.4.2", info.Version)
+	})
+
+	t.Run("from CODEOWNERS without overriding annotations", func(t *testing.T) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("# owners\n* @example/api-team @other\n"), 0o644))
+		gitDescribeTag = func(dir string) (string, error) {
+			return "", errors.New("no tags")
+		}
+
+		parser := New()
+		assert.NoError(t, parseGeneralAPIInfo(parser, []string{
+			"@version 2.0",
+			"@license.name Apache 2.0",
+		}))
+		parser.inferInfoFromModule(root)
+
+		info := parser.swagger.Info
+		assert.Equal(t, "Apache 2.0", info.License.Name)
+		assert.Equal(t, "example/api-team", info.Contact.Name)
+		assert.Equal(t, "https://github.com/example", info.Contact.URL)
+		assert.Equal(t, "2.0", info.Version)
+	})
+
+	t.Run("without contact to infer", func(t *testing.T) {
+		local := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(local, "go.mod"), []byte("module petstore\n\ngo 1.20\n"), 0o644))
+
+		parser := New()
+		parser.swagger.Info.Contact = nil
+		parser.inferInfoFromModule(local)
+
+		assert.Nil(t, parser.swagger.Info.Contact)
+	})
+}
@@ -0,0 +1,37 @@
+package swag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mount an external document whose paths are mounted under a prefix of the generated document, of
+// @mount /payments https://payments.internal/swagger.json.
+type Mount struct {
+	// Prefix the path the paths of the document are mounted under, replacing its base path, e.g. /payments
+	Prefix string
+
+	// Location the URL of the Swagger 2.0 document, or its file
+	Location string
+}
+
+// parseMountComment parses @mount of the general API info: a prefix and the location of a document.
+func parseMountComment(value string) (Mount, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return Mount{}, fmt.Errorf("can not parse mount comment \"%s\", expected a prefix and the location of a document", value)
+	}
+
+	prefix := strings.TrimSuffix(fields[0], "/")
+	if !strings.HasPrefix(prefix, "/") {
+		return Mount{}, fmt.Errorf("prefix %s of @mount must start with /", fields[0])
+	}
+
+	return Mount{Prefix: prefix, Location: fields[1]}, nil
+}
+
+// Mounts returns the documents mounted by the @mount annotations of the general API info, in their order. They are
+// mounted by gen when the docs are generated.
+func (parser *Parser) Mounts() []Mount {
+	return parser.mounts
+}
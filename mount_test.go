@@ -0,0 +1,34 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Mounts(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	require.NoError(t, parseGeneralAPIInfo(p, []string{
+		"@title   Gateway",
+		"@mount   /payments/  https://payments.internal/swagger.json",
+		"@mount   /billing    ./billing/swagger.yaml",
+	}))
+
+	assert.Equal(t, []Mount{
+		{Prefix: "/payments", Location: "https://payments.internal/swagger.json"},
+		{Prefix: "/billing", Location: "./billing/swagger.yaml"},
+	}, p.Mounts())
+}
+
+func TestParseMountComment(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseMountComment("/payments")
+	assert.EqualError(t, err, `can not parse mount comment "/payments", expected a prefix and the location of a document`)
+
+	_, err = parseMountComment("payments https://payments.internal/swagger.json")
+	assert.EqualError(t, err, "prefix payments of @mount must start with /")
+}
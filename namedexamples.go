@@ -0,0 +1,143 @@
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// NamedExamplesExtension keeps the named examples of a response, which Swagger 2.0 can not express, the examples
+// map of its content in OpenAPI 3.0.
+const NamedExamplesExtension = "x-examples"
+
+// NamedExample a named example of a response, e.g. of @Example 200 empty file(examples/empty.json).
+type NamedExample struct {
+	Summary string `json:"summary,omitempty"`
+	Value   any    `json:"value"`
+}
+
+// ParseExampleComment parses the named example of a response, the response being declared before:
+// @Example 200 happy file(examples/ok.json) "a summary", the file being relative to the annotated file, or an
+// inline JSON value: @Example 404 missing {"code": 404}.
+func (operation *Operation) ParseExampleComment(commentLine string, astFile *ast.File) error {
+	fields := FieldsByAnySpace(commentLine, 3)
+	if len(fields) != 3 {
+		return fmt.Errorf("can not parse example comment \"%s\", expected code, name and value", commentLine)
+	}
+
+	codeStr, name, remainder := fields[0], fields[1], fields[2]
+
+	value, summary, err := operation.parseExampleValue(remainder, astFile)
+	if err != nil {
+		return fmt.Errorf("example %s of %s: %w", name, codeStr, err)
+	}
+
+	if strings.EqualFold(codeStr, defaultTag) {
+		if operation.Responses.Default == nil {
+			return fmt.Errorf("example %s of %s has no response, declare it before with @Success or @Failure", name, codeStr)
+		}
+
+		return addNamedExample(operation.Responses.Default, name, summary, value)
+	}
+
+	code, err := strconv.Atoi(codeStr)
+	if err != nil {
+		return fmt.Errorf("can not parse example comment \"%s\"", commentLine)
+	}
+
+	response, ok := operation.Responses.StatusCodeResponses[code]
+	if !ok {
+		return fmt.Errorf("example %s of %s has no response, declare it before with @Success or @Failure", name, codeStr)
+	}
+
+	if err := addNamedExample(&response, name, summary, value); err != nil {
+		return err
+	}
+
+	operation.Responses.StatusCodeResponses[code] = response
+
+	return nil
+}
+
+// namedExamples returns the named examples of response, as parsed or as read from a document.
+func namedExamples(response *spec.Response) map[string]NamedExample {
+	switch value := response.Extensions[NamedExamplesExtension].(type) {
+	case nil:
+		return nil
+	case map[string]NamedExample:
+		return value
+	default:
+		var examples map[string]NamedExample
+
+		b, err := json.Marshal(value)
+		if err != nil || json.Unmarshal(b, &examples) != nil {
+			return nil
+		}
+
+		return examples
+	}
+}
+
+// addNamedExample adds the example name to the named examples of response.
+func addNamedExample(response *spec.Response, name, summary string, value any) error {
+	examples, _ := response.Extensions[NamedExamplesExtension].(map[string]NamedExample)
+	if examples == nil {
+		examples = make(map[string]NamedExample)
+	}
+
+	if _, ok := examples[name]; ok {
+		return fmt.Errorf("example %s is declared several times", name)
+	}
+
+	examples[name] = NamedExample{Summary: summary, Value: value}
+
+	if response.Extensions == nil {
+		response.Extensions = make(spec.Extensions)
+	}
+
+	setExtension(response.Extensions, NamedExamplesExtension, examples)
+
+	return nil
+}
+
+// parseExampleValue returns the value of file(name.json), relative to the directory of astFile, or of an inline
+// JSON value, followed by an optional quoted summary.
+func (operation *Operation) parseExampleValue(remainder string, astFile *ast.File) (any, string, error) {
+	data := []byte(remainder)
+	rest := ""
+
+	if strings.HasPrefix(remainder, "file(") {
+		end := strings.Index(remainder, ")")
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated %s", remainder)
+		}
+
+		fileName := operation.parser.exampleFilePath(remainder[len("file("):end], astFile)
+
+		b, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read file %s: %w", fileName, err)
+		}
+
+		data, rest = b, remainder[end+1:]
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, "", fmt.Errorf("need a valid json value: %w", err)
+	}
+
+	if rest == "" && !strings.HasPrefix(remainder, "file(") {
+		rest = remainder[decoder.InputOffset():]
+	}
+
+	return value, unquoteAttribute(strings.TrimSpace(rest)), nil
+}
@@ -0,0 +1,43 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExampleComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(New())
+
+	for _, comment := range []string{
+		`@Success 200 {object} map[string]any "OK"`,
+		`@Failure default {object} map[string]any`,
+		`@Example 200 happy file(testdata/extension_files/examples/ok.json) "a pet"`,
+		`@Example 200 empty {}`,
+		`@Example default error {"code": 500, "message": "it's broken"} "server error"`,
+	} {
+		require.NoError(t, operation.ParseComment(comment, nil), comment)
+	}
+
+	assert.Equal(t, map[string]NamedExample{
+		"happy": {Summary: "a pet", Value: map[string]any{"id": float64(1), "name": "doggie"}},
+		"empty": {Value: map[string]any{}},
+	}, operation.Responses.StatusCodeResponses[200].Extensions[NamedExamplesExtension])
+
+	assert.Equal(t, map[string]NamedExample{
+		"error": {Summary: "server error", Value: map[string]any{"code": float64(500), "message": "it's broken"}},
+	}, operation.Responses.Default.Extensions[NamedExamplesExtension])
+
+	for comment, message := range map[string]string{
+		`@Example 200 happy {}`:                    "example happy is declared several times",
+		`@Example 404 missing {}`:                  "example missing of 404 has no response",
+		`@Example 200 broken {`:                    "example broken of 200: need a valid json value",
+		`@Example 200 lost file(examples/no.json)`: "example lost of 200: failed to read file",
+		`@Example 200`:                             "expected code, name and value",
+	} {
+		assert.ErrorContains(t, operation.ParseComment(comment, nil), message, comment)
+	}
+}
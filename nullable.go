@@ -0,0 +1,29 @@
+package swag
+
+import (
+	"go/ast"
+
+	"github.com/go-openapi/spec"
+)
+
+// NullableExtension marks the schemas whose value may be null, e.g. the pointer fields with Parser.NullablePointers.
+// OpenAPI 3.0 documents have the real nullable.
+const NullableExtension = "x-nullable"
+
+// markNullablePointer marks the schema of a pointer field with the NullableExtension, so that clients tell an absent
+// value from a null one. A reference is wrapped in an allOf, since the keywords next to $ref are ignored.
+func markNullablePointer(fieldType ast.Expr, schema *spec.Schema) {
+	if _, ok := fieldType.(*ast.StarExpr); !ok {
+		return
+	}
+
+	if IsRefSchema(schema) {
+		*schema = *(&spec.Schema{}).WithAllOf(*schema)
+	}
+
+	if schema.Extensions == nil {
+		schema.Extensions = make(spec.Extensions)
+	}
+
+	schema.Extensions[NullableExtension] = true
+}
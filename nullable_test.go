@@ -0,0 +1,63 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_NullablePointers(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+type User struct {
+	Name     string   ` + "`json:\"name\"`" + `
+	Nickname *string  ` + "`json:\"nickname\"`" + `
+	Address  *Address ` + "`json:\"address\"`" + `
+	Tags     []*string ` + "`json:\"tags\"`" + `
+}
+
+// @Success 200 {object} User
+// @Router  /users [get]
+func GetUser(){
+}
+`
+	parse := func(t *testing.T, nullablePointers bool) map[string]spec.Schema {
+		p := New()
+		p.NullablePointers = nullablePointers
+
+		require.NoError(t, p.packages.ParseFile("api", "testdata/nullable/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+		return p.swagger.Definitions["api.User"].Properties
+	}
+
+	properties := parse(t, true)
+
+	assert.NotContains(t, properties["name"].Extensions, NullableExtension)
+	assert.Equal(t, true, properties["nickname"].Extensions[NullableExtension])
+	assert.NotContains(t, properties["tags"].Extensions, NullableExtension)
+
+	address := properties["address"]
+	assert.Equal(t, true, address.Extensions[NullableExtension])
+	require.Len(t, address.AllOf, 1)
+	assert.Equal(t, "#/definitions/api.Address", address.AllOf[0].Ref.String())
+
+	properties = parse(t, false)
+
+	assert.NotContains(t, properties["nickname"].Extensions, NullableExtension)
+	address = properties["address"]
+	assert.Equal(t, "#/definitions/api.Address", address.Ref.String())
+}
@@ -0,0 +1,712 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+const (
+	mimeJSON           = "application/json"
+	mimeMultipartForm  = "multipart/form-data"
+	mimeURLEncodedForm = "application/x-www-form-urlencoded"
+
+	definitionsPrefix = "#/definitions/"
+	schemasPrefix     = "#/components/schemas/"
+)
+
+// Converter converts Swagger 2.0 documents into OpenAPI 3.0 documents.
+type Converter struct {
+	// parameterizeHost emits a single server whose scheme and host are server variables
+	parameterizeHost bool
+}
+
+// NewConverter creates a new Converter with default properties.
+func NewConverter(options ...func(*Converter)) *Converter {
+	converter := &Converter{}
+
+	for _, option := range options {
+		option(converter)
+	}
+
+	return converter
+}
+
+// SetParameterizeHost sets whether the scheme and host of the servers are emitted as server variables.
+func SetParameterizeHost(parameterizeHost bool) func(*Converter) {
+	return func(c *Converter) {
+		c.parameterizeHost = parameterizeHost
+	}
+}
+
+// Convert converts the swagger document, which is left unchanged, into an OpenAPI 3.0 document.
+func (c *Converter) Convert(swagger *spec.Swagger) (*Document, error) {
+	// work on a deep copy, so that refs can be rewritten in place
+	var source spec.Swagger
+
+	b, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(b, &source)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI:      Version,
+		Info:         source.Info,
+		Servers:      c.servers(&source),
+		Paths:        make(map[string]*PathItem),
+		Security:     source.Security,
+		Tags:         source.Tags,
+		ExternalDocs: source.ExternalDocs,
+		Extensions:   Extensions(source.Extensions),
+	}
+
+	if doc.Info == nil {
+		doc.Info = &spec.Info{}
+	}
+
+	components := &Components{}
+
+	if len(source.Definitions) > 0 {
+		components.Schemas = make(map[string]spec.Schema, len(source.Definitions))
+
+		for name, schema := range source.Definitions {
+			rewriteRefs(&schema)
+			components.Schemas[name] = schema
+		}
+	}
+
+	if len(source.SecurityDefinitions) > 0 {
+		components.SecuritySchemes = make(map[string]*SecurityScheme, len(source.SecurityDefinitions))
+
+		for name, scheme := range source.SecurityDefinitions {
+			components.SecuritySchemes[name] = convertSecurityScheme(scheme)
+		}
+	}
+
+	if components.Schemas != nil || components.SecuritySchemes != nil {
+		doc.Components = components
+	}
+
+	if source.Paths != nil {
+		basePath := strings.TrimSuffix(source.BasePath, "/")
+		stripBasePath := basePath != "" && serversTakeBasePath(doc.Servers, basePath)
+
+		for path, item := range source.Paths.Paths {
+			// the base path is part of the server url, so it is stripped from the paths which repeat it, unless
+			// an operation has servers of its own
+			if stripBasePath && !hasOperationServers(item) {
+				path = trimBasePath(path, basePath)
+			}
+
+			pathItem, err := convertPathItem(&source, item)
+			if err != nil {
+				return nil, fmt.Errorf("path %s: %w", path, err)
+			}
+
+			doc.Paths[path] = pathItem
+		}
+	}
+
+	return doc, nil
+}
+
+// servers merges schemes, host and basePath into server entries.
+func (c *Converter) servers(swagger *spec.Swagger) []Server {
+	basePath := strings.TrimSuffix(swagger.BasePath, "/")
+
+	if swagger.Host == "" {
+		if basePath == "" {
+			return nil
+		}
+
+		return []Server{{URL: basePath}}
+	}
+
+	schemes := swagger.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	if c.parameterizeHost {
+		return []Server{{
+			URL: "{scheme}://{host}" + basePath,
+			Variables: map[string]ServerVariable{
+				"scheme": {Enum: schemes, Default: schemes[0]},
+				"host":   {Default: swagger.Host},
+			},
+		}}
+	}
+
+	servers := make([]Server, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, Server{URL: scheme + "://" + swagger.Host + basePath})
+	}
+
+	return servers
+}
+
+// serversTakeBasePath reports whether the urls of all the servers end with basePath.
+func serversTakeBasePath(servers []Server, basePath string) bool {
+	if len(servers) == 0 {
+		return false
+	}
+
+	for _, server := range servers {
+		if !strings.HasSuffix(server.URL, basePath) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasOperationServers reports whether an operation of item overrides the servers of the document.
+func hasOperationServers(item spec.PathItem) bool {
+	for _, op := range []*spec.Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch} {
+		if op == nil {
+			continue
+		}
+
+		if _, ok := op.Extensions[swag.ServersExtension]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trimBasePath removes basePath from the paths which start with it, the base path itself becoming /.
+func trimBasePath(path, basePath string) string {
+	if path == basePath || path == basePath+"/" {
+		return "/"
+	}
+
+	if strings.HasPrefix(path, basePath+"/") {
+		return strings.TrimPrefix(path, basePath)
+	}
+
+	return path
+}
+
+func convertPathItem(swagger *spec.Swagger, item spec.PathItem) (*PathItem, error) {
+	result := &PathItem{Extensions: Extensions(item.Extensions)}
+
+	for _, op := range []struct {
+		source *spec.Operation
+		target **Operation
+	}{
+		{item.Get, &result.Get},
+		{item.Put, &result.Put},
+		{item.Post, &result.Post},
+		{item.Delete, &result.Delete},
+		{item.Options, &result.Options},
+		{item.Head, &result.Head},
+		{item.Patch, &result.Patch},
+	} {
+		operation, err := convertOperation(swagger, op.source)
+		if err != nil {
+			return nil, err
+		}
+
+		*op.target = operation
+	}
+
+	return result, nil
+}
+
+func convertOperation(swagger *spec.Swagger, op *spec.Operation) (*Operation, error) {
+	if op == nil {
+		return nil, nil
+	}
+
+	result := &Operation{
+		Tags:         op.Tags,
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: op.ExternalDocs,
+		OperationID:  op.ID,
+		Responses:    make(map[string]*Response),
+		Deprecated:   op.Deprecated,
+		Extensions:   Extensions(op.Extensions),
+	}
+
+	if op.Security != nil {
+		security := op.Security
+		result.Security = &security
+	}
+
+	consumes := op.Consumes
+	if len(consumes) == 0 {
+		consumes = swagger.Consumes
+	}
+
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = swagger.Produces
+	}
+
+	var formParams []spec.Parameter
+
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "body":
+			rewriteRefs(param.Schema)
+
+			result.RequestBody = &RequestBody{
+				Description: param.Description,
+				Content:     mediaTypes(consumes, mimeJSON, param.Schema, nil),
+				Required:    param.Required,
+			}
+		case "formData":
+			formParams = append(formParams, param)
+		default:
+			parameter, err := convertParameter(param)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Parameters = append(result.Parameters, parameter)
+		}
+	}
+
+	if len(formParams) > 0 {
+		result.RequestBody = convertFormParameters(formParams, consumes)
+	}
+
+	// restore what the parser downgraded for Swagger 2.0
+	var cookieParams []spec.Parameter
+	if popExtension(result.Extensions, swag.CookieParametersExtension, &cookieParams) {
+		for _, param := range cookieParams {
+			parameter, err := convertParameter(param)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Parameters = append(result.Parameters, parameter)
+		}
+	}
+
+	var content map[string]*spec.Schema
+	if popExtension(result.Extensions, swag.RequestBodyContentExtension, &content) && result.RequestBody != nil {
+		for mime, schema := range content {
+			rewriteRefs(schema)
+			result.RequestBody.Content[mime] = &MediaType{Schema: schema}
+		}
+	}
+
+	if op.Responses != nil {
+		if op.Responses.Default != nil {
+			result.Responses["default"] = convertResponse(op.Responses.Default, produces)
+		}
+
+		for code, response := range op.Responses.StatusCodeResponses {
+			result.Responses[strconv.Itoa(code)] = convertResponse(&response, produces)
+		}
+	}
+
+	popExtension(result.Extensions, swag.ServersExtension, &result.Servers)
+
+	var callbacks map[string]swag.Callback
+	if popExtension(result.Extensions, swag.CallbacksExtension, &callbacks) {
+		result.Callbacks = make(map[string]Callback, len(callbacks))
+
+		for name, callback := range callbacks {
+			result.Callbacks[name] = make(Callback, len(callback))
+
+			for expression, item := range callback {
+				pathItem, err := convertPathItem(swagger, item)
+				if err != nil {
+					return nil, fmt.Errorf("callback %s: %w", name, err)
+				}
+
+				result.Callbacks[name][expression] = pathItem
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func convertParameter(param spec.Parameter) (*Parameter, error) {
+	result := &Parameter{
+		Name:        param.Name,
+		In:          param.In,
+		Description: param.Description,
+		Required:    param.Required || param.In == "path",
+		Schema:      simpleSchema(&param.SimpleSchema, &param.CommonValidations),
+		Example:     param.Example,
+		Extensions:  Extensions(param.Extensions),
+	}
+
+	// the example is kept on the parameter itself
+	result.Schema.Example = nil
+
+	if param.Type == "array" {
+		if err := setCollectionStyle(result, param.CollectionFormat); err != nil {
+			return nil, fmt.Errorf("%s parameter %s: %w", param.In, param.Name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// setCollectionStyle maps the collectionFormat of an array parameter to style and explode. tsv has no style in
+// OpenAPI 3.0.
+func setCollectionStyle(param *Parameter, collectionFormat string) error {
+	explode := false
+
+	switch collectionFormat {
+	case "multi":
+		return nil // form style with explode is the default of query parameters
+	case "tsv":
+		return fmt.Errorf("collection format tsv has no OpenAPI 3.0 style")
+	case "ssv":
+		param.Style = "spaceDelimited"
+	case "pipes":
+		param.Style = "pipeDelimited"
+	default:
+		if param.In != "query" && param.In != "cookie" {
+			return nil // simple style is comma separated
+		}
+
+		param.Style = "form"
+	}
+
+	param.Explode = &explode
+
+	return nil
+}
+
+func convertFormParameters(params []spec.Parameter, consumes []string) *RequestBody {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Type:       []string{"object"},
+			Properties: make(map[string]spec.Schema),
+		},
+	}
+
+	hasFile := false
+	encodings := make(map[string]*Encoding)
+
+	for _, param := range params {
+		property := simpleSchema(&param.SimpleSchema, &param.CommonValidations)
+		property.Description = param.Description
+
+		if encoding := partEncoding(param.Extensions); encoding != nil {
+			encodings[param.Name] = encoding
+		}
+
+		if param.Type == "file" {
+			hasFile = true
+			property.Type, property.Format = []string{"string"}, "binary"
+		} else if property.Items != nil && property.Items.Schema != nil && property.Items.Schema.Type.Contains("file") {
+			hasFile = true
+			property.Items.Schema.Type, property.Items.Schema.Format = []string{"string"}, "binary"
+		}
+
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+
+		schema.Properties[param.Name] = *property
+	}
+
+	var formConsumes []string
+
+	for _, mime := range consumes {
+		// files are only sent in multipart forms
+		if mime == mimeMultipartForm || mime == mimeURLEncodedForm && !hasFile {
+			formConsumes = append(formConsumes, mime)
+		}
+	}
+
+	defaultMime := mimeURLEncodedForm
+	if hasFile || len(encodings) > 0 {
+		defaultMime = mimeMultipartForm
+	}
+
+	content := mediaTypes(formConsumes, defaultMime, schema, nil)
+
+	// the content types and headers of the parts only apply to multipart forms
+	if multipart, ok := content[mimeMultipartForm]; ok && len(encodings) > 0 {
+		multipart.Encoding = encodings
+	}
+
+	return &RequestBody{
+		Content:  content,
+		Required: len(schema.Required) > 0,
+	}
+}
+
+// partEncoding returns the encoding of the part of a form parameter, of its encoding extensions, which are removed.
+func partEncoding(extensions spec.Extensions) *Encoding {
+	var encoding Encoding
+
+	popExtension(Extensions(extensions), swag.EncodingContentTypeExtension, &encoding.ContentType)
+
+	var headers []string
+	popExtension(Extensions(extensions), swag.EncodingHeadersExtension, &headers)
+
+	for _, name := range headers {
+		if encoding.Headers == nil {
+			encoding.Headers = make(map[string]*Header, len(headers))
+		}
+
+		encoding.Headers[name] = &Header{Schema: spec.StringProperty()}
+	}
+
+	if encoding.ContentType == "" && encoding.Headers == nil {
+		return nil
+	}
+
+	return &encoding
+}
+
+func convertResponse(response *spec.Response, produces []string) *Response {
+	result := &Response{
+		Description: response.Description,
+		Extensions:  Extensions(response.Extensions),
+	}
+
+	if len(response.Headers) > 0 {
+		result.Headers = make(map[string]*Header, len(response.Headers))
+
+		for name, header := range response.Headers {
+			result.Headers[name] = &Header{
+				Description: header.Description,
+				Schema:      simpleSchema(&header.SimpleSchema, &header.CommonValidations),
+			}
+		}
+	}
+
+	if response.Schema != nil {
+		rewriteRefs(response.Schema)
+		result.Content = mediaTypes(produces, mimeJSON, response.Schema, response.Examples)
+	}
+
+	// restore the named examples, which replace the example of each media type
+	var examples map[string]*Example
+	if popExtension(result.Extensions, swag.NamedExamplesExtension, &examples) {
+		if result.Content == nil {
+			result.Content = mediaTypes(produces, mimeJSON, nil, nil)
+		}
+
+		for _, mediaType := range result.Content {
+			mediaType.Examples = make(map[string]*Example, len(examples)+1)
+			for name, example := range examples {
+				mediaType.Examples[name] = example
+			}
+
+			if _, ok := examples["default"]; !ok && mediaType.Example != nil {
+				mediaType.Examples["default"] = &Example{Value: mediaType.Example}
+			}
+
+			mediaType.Example = nil
+		}
+	}
+
+	return result
+}
+
+// mediaTypes creates the content of a request or response for the given mime types.
+func mediaTypes(mimes []string, defaultMime string, schema *spec.Schema, examples map[string]any) map[string]*MediaType {
+	if len(mimes) == 0 {
+		mimes = []string{defaultMime}
+	}
+
+	content := make(map[string]*MediaType, len(mimes))
+	for _, mime := range mimes {
+		content[mime] = &MediaType{
+			Schema:  schema,
+			Example: examples[mime],
+		}
+	}
+
+	return content
+}
+
+func simpleSchema(simple *spec.SimpleSchema, validations *spec.CommonValidations) *spec.Schema {
+	schema := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Format:           simple.Format,
+			Nullable:         simple.Nullable,
+			Default:          simple.Default,
+			Maximum:          validations.Maximum,
+			ExclusiveMaximum: validations.ExclusiveMaximum,
+			Minimum:          validations.Minimum,
+			ExclusiveMinimum: validations.ExclusiveMinimum,
+			MaxLength:        validations.MaxLength,
+			MinLength:        validations.MinLength,
+			Pattern:          validations.Pattern,
+			MaxItems:         validations.MaxItems,
+			MinItems:         validations.MinItems,
+			UniqueItems:      validations.UniqueItems,
+			MultipleOf:       validations.MultipleOf,
+			Enum:             validations.Enum,
+		},
+		SwaggerSchemaProps: spec.SwaggerSchemaProps{
+			Example: simple.Example,
+		},
+	}
+
+	if simple.Type != "" {
+		schema.Type = []string{simple.Type}
+	}
+
+	if simple.Items != nil {
+		schema.Items = &spec.SchemaOrArray{
+			Schema: simpleSchema(&simple.Items.SimpleSchema, &simple.Items.CommonValidations),
+		}
+	}
+
+	return schema
+}
+
+func convertSecurityScheme(scheme *spec.SecurityScheme) *SecurityScheme {
+	result := &SecurityScheme{
+		Type:        scheme.Type,
+		Description: scheme.Description,
+		Extensions:  Extensions(scheme.Extensions),
+	}
+
+	switch scheme.Type {
+	case "basic":
+		result.Type, result.Scheme = "http", "basic"
+	case "apiKey":
+		result.Name, result.In = scheme.Name, scheme.In
+
+		// api keys in cookies were downgraded to the Cookie header for Swagger 2.0
+		var in, name string
+		if popExtension(result.Extensions, swag.APIKeyInExtension, &in) &&
+			popExtension(result.Extensions, swag.APIKeyNameExtension, &name) {
+			result.In, result.Name = in, name
+		}
+
+		var bearer string
+		if popExtension(result.Extensions, swag.BearerSchemeExtension, &bearer) {
+			result.Type, result.Scheme, result.Name, result.In = "http", bearer, "", ""
+			popExtension(result.Extensions, swag.BearerFormatExtension, &result.BearerFormat)
+		}
+	case "oauth2":
+		scopes := scheme.Scopes
+		if scopes == nil {
+			scopes = make(map[string]string)
+		}
+
+		flow := &OAuthFlow{
+			AuthorizationURL: scheme.AuthorizationURL,
+			TokenURL:         scheme.TokenURL,
+			Scopes:           scopes,
+		}
+
+		result.Flows = &OAuthFlows{}
+
+		switch scheme.Flow {
+		case "implicit":
+			result.Flows.Implicit = flow
+		case "password":
+			result.Flows.Password = flow
+		case "application":
+			result.Flows.ClientCredentials = flow
+		case "accessCode":
+			result.Flows.AuthorizationCode = flow
+		}
+	}
+
+	return result
+}
+
+// rewriteRefs points refs to definitions to the component schemas instead, and restores the unions, nullable,
+// writeOnly and deprecated Swagger 2.0 can not express.
+func rewriteRefs(schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+
+	if ref := schema.Ref.String(); strings.HasPrefix(ref, definitionsPrefix) {
+		schema.Ref = spec.MustCreateRef(schemasPrefix + strings.TrimPrefix(ref, definitionsPrefix))
+	}
+
+	popExtension(Extensions(schema.Extensions), swag.OneOfExtension, &schema.OneOf)
+	popExtension(Extensions(schema.Extensions), swag.AnyOfExtension, &schema.AnyOf)
+
+	var nullable bool
+	if popExtension(Extensions(schema.Extensions), swag.NullableExtension, &nullable) {
+		schema.Nullable = nullable
+	}
+
+	var writeOnly bool
+	if popExtension(Extensions(schema.Extensions), swag.WriteOnlyExtension, &writeOnly) && writeOnly {
+		// the spec lib has no writeOnly, and marshals the x- extensions only
+		if schema.ExtraProps == nil {
+			schema.ExtraProps = make(map[string]any)
+		}
+
+		schema.ExtraProps["writeOnly"] = true
+	}
+
+	var deprecated bool
+	if popExtension(Extensions(schema.Extensions), swag.DeprecatedExtension, &deprecated) && deprecated {
+		if schema.ExtraProps == nil {
+			schema.ExtraProps = make(map[string]any)
+		}
+
+		schema.ExtraProps["deprecated"] = true
+	}
+
+	for name, property := range schema.Properties {
+		rewriteRefs(&property)
+		schema.Properties[name] = property
+	}
+
+	for name, property := range schema.PatternProperties {
+		rewriteRefs(&property)
+		schema.PatternProperties[name] = property
+	}
+
+	for _, schemas := range [][]spec.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for i := range schemas {
+			rewriteRefs(&schemas[i])
+		}
+	}
+
+	rewriteRefs(schema.Not)
+
+	if schema.Items != nil {
+		rewriteRefs(schema.Items.Schema)
+
+		for i := range schema.Items.Schemas {
+			rewriteRefs(&schema.Items.Schemas[i])
+		}
+	}
+
+	if schema.AdditionalProperties != nil {
+		rewriteRefs(schema.AdditionalProperties.Schema)
+	}
+}
+
+// popExtension decodes the extension named key into v and removes it from extensions.
+func popExtension(extensions Extensions, key string, v any) bool {
+	value, ok := extensions[key]
+	if !ok {
+		return false
+	}
+
+	delete(extensions, key)
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(b, v) == nil
+}
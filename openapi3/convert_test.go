@@ -0,0 +1,568 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func newTestSwagger() *spec.Swagger {
+	var swagger spec.Swagger
+
+	err := json.Unmarshal([]byte(`{
+    "swagger": "2.0",
+    "info": {"title": "Petstore", "version": "1.0"},
+    "host": "petstore.example.com",
+    "basePath": "/api/v1",
+    "schemes": ["https", "http"],
+    "paths": {
+        "/api/v1/pets": {
+            "post": {
+                "consumes": ["application/json"],
+                "parameters": [
+                    {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}}
+                ],
+                "responses": {
+                    "200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}
+                }
+            }
+        },
+        "/pets/{id}/photo": {
+            "put": {
+                "parameters": [
+                    {"name": "id", "in": "path", "required": true, "type": "integer"},
+                    {"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "csv"},
+                    {"name": "photo", "in": "formData", "required": true, "type": "file"}
+                ],
+                "responses": {
+                    "204": {"description": "No Content", "headers": {"X-Request-Id": {"type": "string"}}}
+                },
+                "x-internal": true
+            }
+        }
+    },
+    "definitions": {
+        "Pet": {"type": "object", "properties": {"parent": {"$ref": "#/definitions/Pet"}}}
+    },
+    "securityDefinitions": {
+        "Basic": {"type": "basic"},
+        "OAuth2": {"type": "oauth2", "flow": "application", "tokenUrl": "https://example.com/token"}
+    }
+}`), &swagger)
+	if err != nil {
+		panic(err)
+	}
+
+	return &swagger
+}
+
+func TestConverter_Convert(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	b, err := json.MarshalIndent(doc, "", "    ")
+	assert.NoError(t, err)
+
+	expected := `{
+    "openapi": "3.0.3",
+    "info": {
+        "title": "Petstore",
+        "version": "1.0"
+    },
+    "servers": [
+        {
+            "url": "https://petstore.example.com/api/v1"
+        },
+        {
+            "url": "http://petstore.example.com/api/v1"
+        }
+    ],
+    "paths": {
+        "/pets": {
+            "post": {
+                "requestBody": {
+                    "content": {
+                        "application/json": {
+                            "schema": {
+                                "$ref": "#/components/schemas/Pet"
+                            }
+                        }
+                    },
+                    "required": true
+                },
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "content": {
+                            "application/json": {
+                                "schema": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/components/schemas/Pet"
+                                    }
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pets/{id}/photo": {
+            "put": {
+                "parameters": [
+                    {
+                        "name": "id",
+                        "in": "path",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "name": "tags",
+                        "in": "query",
+                        "style": "form",
+                        "explode": false,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "requestBody": {
+                    "content": {
+                        "multipart/form-data": {
+                            "schema": {
+                                "type": "object",
+                                "required": [
+                                    "photo"
+                                ],
+                                "properties": {
+                                    "photo": {
+                                        "type": "string",
+                                        "format": "binary"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "required": true
+                },
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "headers": {
+                            "X-Request-Id": {
+                                "schema": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                },
+                "x-internal": true
+            }
+        }
+    },
+    "components": {
+        "schemas": {
+            "Pet": {
+                "type": "object",
+                "properties": {
+                    "parent": {
+                        "$ref": "#/components/schemas/Pet"
+                    }
+                }
+            }
+        },
+        "securitySchemes": {
+            "Basic": {
+                "type": "http",
+                "scheme": "basic"
+            },
+            "OAuth2": {
+                "type": "oauth2",
+                "flows": {
+                    "clientCredentials": {
+                        "tokenUrl": "https://example.com/token",
+                        "scopes": {}
+                    }
+                }
+            }
+        }
+    }
+}`
+	assert.Equal(t, expected, string(b))
+
+	// the source document is left unchanged
+	parent := swagger.Definitions["Pet"].Properties["parent"]
+	assert.Equal(t, "#/definitions/Pet", parent.Ref.String())
+}
+
+func TestConverter_servers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parameterized host", func(t *testing.T) {
+		doc, err := NewConverter(SetParameterizeHost(true)).Convert(newTestSwagger())
+		assert.NoError(t, err)
+
+		assert.Equal(t, []Server{{
+			URL: "{scheme}://{host}/api/v1",
+			Variables: map[string]ServerVariable{
+				"scheme": {Enum: []string{"https", "http"}, Default: "https"},
+				"host":   {Default: "petstore.example.com"},
+			},
+		}}, doc.Servers)
+	})
+
+	t.Run("without host", func(t *testing.T) {
+		swagger := newTestSwagger()
+		swagger.Host = ""
+
+		doc, err := NewConverter().Convert(swagger)
+		assert.NoError(t, err)
+		assert.Equal(t, []Server{{URL: "/api/v1"}}, doc.Servers)
+	})
+
+	t.Run("without host and base path", func(t *testing.T) {
+		swagger := newTestSwagger()
+		swagger.Host, swagger.BasePath = "", "/"
+
+		doc, err := NewConverter().Convert(swagger)
+		assert.NoError(t, err)
+		assert.Nil(t, doc.Servers)
+		assert.Contains(t, doc.Paths, "/api/v1/pets")
+	})
+}
+
+func TestConverter_basePath(t *testing.T) {
+	t.Parallel()
+
+	t.Run("base path itself", func(t *testing.T) {
+		swagger := newTestSwagger()
+		swagger.Paths.Paths["/api/v1"] = swagger.Paths.Paths["/api/v1/pets"]
+
+		doc, err := NewConverter().Convert(swagger)
+		assert.NoError(t, err)
+		assert.Contains(t, doc.Paths, "/")
+		assert.Contains(t, doc.Paths, "/pets")
+		assert.NotContains(t, doc.Paths, "")
+	})
+
+	t.Run("operation servers", func(t *testing.T) {
+		swagger := newTestSwagger()
+		swagger.Paths.Paths["/api/v1/pets"].Post.AddExtension(swag.ServersExtension,
+			[]swag.Server{{URL: "https://files.example.com"}})
+
+		doc, err := NewConverter().Convert(swagger)
+		assert.NoError(t, err)
+		assert.Contains(t, doc.Paths, "/api/v1/pets")
+		assert.NotContains(t, doc.Paths, "/pets")
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		swagger := newTestSwagger()
+		swagger.Paths.Paths["/pets/{id}/photo"].Put.Parameters[1].CollectionFormat = "tsv"
+
+		_, err := NewConverter().Convert(swagger)
+		assert.EqualError(t, err,
+			"path /pets/{id}/photo: query parameter tags: collection format tsv has no OpenAPI 3.0 style")
+	})
+}
+
+func TestConverter_restoreDowngraded(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+	session := spec.QueryParam("session").Typed("string", "")
+	session.In = "cookie"
+
+	post := swagger.Paths.Paths["/api/v1/pets"].Post
+	post.AddExtension("x-cookie-parameters", []spec.Parameter{*session})
+	post.AddExtension("x-request-body-content", map[string]spec.Schema{
+		"text/plain": *spec.StringProperty(),
+		"text/xml":   *spec.RefSchema("#/definitions/Pet"),
+	})
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	op := doc.Paths["/pets"].Post
+	assert.Empty(t, op.Extensions)
+	assert.Len(t, op.Parameters, 1)
+	assert.Equal(t, "cookie", op.Parameters[0].In)
+	assert.Equal(t, []string{"application/json", "text/plain", "text/xml"}, sortedKeys(op.RequestBody.Content))
+
+	ref := op.RequestBody.Content["text/xml"].Schema.Ref
+	assert.Equal(t, "#/components/schemas/Pet", ref.String())
+}
+
+func TestConverter_namedExamples(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	response := swagger.Paths.Paths["/api/v1/pets"].Post.Responses.StatusCodeResponses[200]
+	response.Examples = map[string]any{"application/json": []any{}}
+	response.AddExtension("x-examples", map[string]any{
+		"happy": map[string]any{"summary": "a pet", "value": []any{map[string]any{}}},
+	})
+	swagger.Paths.Paths["/api/v1/pets"].Post.Responses.StatusCodeResponses[200] = response
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	converted := doc.Paths["/pets"].Post.Responses["200"]
+	assert.Empty(t, converted.Extensions)
+
+	mediaType := converted.Content["application/json"]
+	assert.Nil(t, mediaType.Example)
+	assert.Equal(t, map[string]*Example{
+		"happy":   {Summary: "a pet", Value: []any{map[string]any{}}},
+		"default": {Value: []any{}},
+	}, mediaType.Examples)
+}
+
+func TestConverter_callbacks(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	op := swagger.Paths.Paths["/api/v1/pets"].Post
+	op.AddExtension("x-callbacks", map[string]any{
+		"onCreated": map[string]any{
+			"{$request.body#/callbackUrl}": map[string]any{
+				"post": map[string]any{
+					"description": "the pet is created",
+					"parameters": []any{map[string]any{
+						"name": "body", "in": "body", "required": true,
+						"schema": map[string]any{"$ref": "#/definitions/Pet"},
+					}},
+					"responses": map[string]any{"200": map[string]any{"description": "OK"}},
+				},
+			},
+		},
+	})
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	converted := doc.Paths["/pets"].Post
+	assert.NotContains(t, converted.Extensions, "x-callbacks")
+
+	item := converted.Callbacks["onCreated"]["{$request.body#/callbackUrl}"]
+	if assert.NotNil(t, item) && assert.NotNil(t, item.Post) {
+		assert.Equal(t, "the pet is created", item.Post.Description)
+		assert.Equal(t, "#/components/schemas/Pet", item.Post.RequestBody.Content["application/json"].Schema.Ref.String())
+		assert.Equal(t, "OK", item.Post.Responses["200"].Description)
+	}
+}
+
+func TestConverter_operationServers(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	op := swagger.Paths.Paths["/pets/{id}/photo"].Put
+	op.AddExtension("x-servers", []any{
+		map[string]any{"url": "https://files.example.com", "description": "the file server"},
+	})
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	converted := doc.Paths["/pets/{id}/photo"].Put
+	assert.NotContains(t, converted.Extensions, "x-servers")
+	assert.Equal(t, []Server{{URL: "https://files.example.com", Description: "the file server"}}, converted.Servers)
+	assert.Nil(t, doc.Paths["/pets"].Post.Servers)
+}
+
+func TestConverter_encoding(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	put := swagger.Paths.Paths["/pets/{id}/photo"].Put
+	put.Parameters[2].AddExtension("x-encoding-content-type", "image/png, image/jpeg")
+	metadata := spec.FormDataParam("metadata").Typed("string", "")
+	metadata.AddExtension("x-encoding-content-type", "application/json")
+	metadata.AddExtension("x-encoding-headers", []string{"X-Checksum"})
+	put.Parameters = append(put.Parameters, *metadata)
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	multipart := doc.Paths["/pets/{id}/photo"].Put.RequestBody.Content["multipart/form-data"]
+	b, err := json.Marshal(multipart.Encoding)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"photo": {"contentType": "image/png, image/jpeg"},
+		"metadata": {"contentType": "application/json", "headers": {"X-Checksum": {"schema": {"type": "string"}}}}
+	}`, string(b))
+
+	b, err = json.Marshal(multipart.Schema)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "x-encoding")
+}
+
+func TestConverter_unions(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	pet := swagger.Definitions["Pet"]
+	pet.Properties["owner"] = spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+		"x-oneOf": []spec.Schema{*spec.RefSchema("#/definitions/Pet"), *spec.StringProperty()},
+	}}}
+	swagger.Definitions["Pet"] = pet
+
+	response := swagger.Paths.Paths["/api/v1/pets"].Post.Responses.StatusCodeResponses[200]
+	response.Schema = &spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{
+		"x-anyOf": []spec.Schema{*spec.RefSchema("#/definitions/Pet"), *spec.Int64Property()},
+	}}}
+	swagger.Paths.Paths["/api/v1/pets"].Post.Responses.StatusCodeResponses[200] = response
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(doc.Components.Schemas["Pet"].Properties["owner"])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"oneOf":[{"$ref":"#/components/schemas/Pet"},{"type":"string"}]}`, string(b))
+
+	b, err = json.Marshal(doc.Paths["/pets"].Post.Responses["200"].Content["application/json"].Schema)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"anyOf":[{"$ref":"#/components/schemas/Pet"},{"type":"integer","format":"int64"}]}`, string(b))
+}
+
+func TestConverter_writeOnly(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	pet := swagger.Definitions["Pet"]
+	password := *spec.StringProperty()
+	password.Extensions = spec.Extensions{"x-writeOnly": true}
+	pet.Properties["password"] = password
+	swagger.Definitions["Pet"] = pet
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(doc.Components.Schemas["Pet"].Properties["password"])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"string","writeOnly":true}`, string(b))
+}
+
+func TestConverter_deprecated(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	pet := swagger.Definitions["Pet"]
+	nickname := *spec.StringProperty()
+	nickname.Extensions = spec.Extensions{"x-deprecated": true, "x-sunset": "2025-06-01"}
+	pet.Properties["nickname"] = nickname
+	swagger.Definitions["Pet"] = pet
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(doc.Components.Schemas["Pet"].Properties["nickname"])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"string","x-sunset":"2025-06-01","deprecated":true}`, string(b))
+}
+
+func TestConverter_nullable(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	pet := swagger.Definitions["Pet"]
+	nickname := *spec.StringProperty()
+	nickname.Extensions = spec.Extensions{"x-nullable": true}
+	pet.Properties["nickname"] = nickname
+	swagger.Definitions["Pet"] = pet
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(doc.Components.Schemas["Pet"].Properties["nickname"])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"string","nullable":true}`, string(b))
+}
+
+func TestConverter_fileArrays(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+
+	put := swagger.Paths.Paths["/pets/{id}/photo"].Put
+	put.Consumes = []string{"application/x-www-form-urlencoded", "multipart/form-data"}
+	put.Parameters[2] = *spec.FileParam("photos").CollectionOf(spec.NewItems().Typed("file", ""), "multi")
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	content := doc.Paths["/pets/{id}/photo"].Put.RequestBody.Content
+	assert.Equal(t, []string{"multipart/form-data"}, sortedKeys(content))
+
+	b, err := json.Marshal(content["multipart/form-data"].Schema.Properties["photos"])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"array","items":{"type":"string","format":"binary"}}`, string(b))
+}
+
+func sortedKeys(content map[string]*MediaType) []string {
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func TestConverter_cookieAPIKey(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+	scheme := spec.APIKeyAuth("Cookie", "header")
+	scheme.AddExtension("x-in", "cookie")
+	scheme.AddExtension("x-name", "session")
+	swagger.SecurityDefinitions["CookieAuth"] = scheme
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(doc.Components.SecuritySchemes["CookieAuth"])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"apiKey","name":"session","in":"cookie"}`, string(b))
+}
+
+func TestConverter_bearer(t *testing.T) {
+	t.Parallel()
+
+	swagger := newTestSwagger()
+	scheme := spec.APIKeyAuth("Authorization", "header")
+	scheme.AddExtension("x-scheme", "bearer")
+	scheme.AddExtension("x-bearer-format", "JWT")
+	scheme.AddExtension("x-jwt-claims", map[string]any{"sub": map[string]any{"type": "string"}})
+	swagger.SecurityDefinitions["BearerAuth"] = scheme
+
+	doc, err := NewConverter().Convert(swagger)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(doc.Components.SecuritySchemes["BearerAuth"])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"http","scheme":"bearer","bearerFormat":"JWT","x-jwt-claims":{"sub":{"type":"string"}}}`, string(b))
+}
@@ -0,0 +1,225 @@
+// Package openapi3 converts Swagger 2.0 documents generated by swag into OpenAPI 3.0 documents.
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/go-openapi/spec"
+)
+
+// Version is the OpenAPI version of converted documents.
+const Version = "3.0.3"
+
+// Extensions vendor extensions, marshaled inline with their owner.
+type Extensions map[string]any
+
+// Document the root document object of an OpenAPI 3.0 specification.
+type Document struct {
+	OpenAPI      string                      `json:"openapi"`
+	Info         *spec.Info                  `json:"info"`
+	Servers      []Server                    `json:"servers,omitempty"`
+	Paths        map[string]*PathItem        `json:"paths"`
+	Components   *Components                 `json:"components,omitempty"`
+	Security     []map[string][]string       `json:"security,omitempty"`
+	Tags         []spec.Tag                  `json:"tags,omitempty"`
+	ExternalDocs *spec.ExternalDocumentation `json:"externalDocs,omitempty"`
+	Extensions   Extensions                  `json:"-"`
+}
+
+// Server an object representing a server.
+type Server struct {
+	URL         string                    `json:"url"`
+	Description string                    `json:"description,omitempty"`
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable an object representing a server variable for server URL template substitution.
+type ServerVariable struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
+}
+
+// PathItem describes the operations available on a single path.
+type PathItem struct {
+	Get        *Operation `json:"get,omitempty"`
+	Put        *Operation `json:"put,omitempty"`
+	Post       *Operation `json:"post,omitempty"`
+	Delete     *Operation `json:"delete,omitempty"`
+	Options    *Operation `json:"options,omitempty"`
+	Head       *Operation `json:"head,omitempty"`
+	Patch      *Operation `json:"patch,omitempty"`
+	Extensions Extensions `json:"-"`
+}
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	Tags         []string                    `json:"tags,omitempty"`
+	Summary      string                      `json:"summary,omitempty"`
+	Description  string                      `json:"description,omitempty"`
+	ExternalDocs *spec.ExternalDocumentation `json:"externalDocs,omitempty"`
+	OperationID  string                      `json:"operationId,omitempty"`
+	Parameters   []*Parameter                `json:"parameters,omitempty"`
+	RequestBody  *RequestBody                `json:"requestBody,omitempty"`
+	Responses    map[string]*Response        `json:"responses"`
+	Callbacks    map[string]Callback         `json:"callbacks,omitempty"`
+	Deprecated   bool                        `json:"deprecated,omitempty"`
+	Security     *[]map[string][]string      `json:"security,omitempty"`
+	Servers      []Server                    `json:"servers,omitempty"`
+	Extensions   Extensions                  `json:"-"`
+}
+
+// Callback the requests of a callback by the runtime expression of their URL.
+type Callback map[string]*PathItem
+
+// Parameter describes a single operation parameter.
+type Parameter struct {
+	Name        string       `json:"name"`
+	In          string       `json:"in"`
+	Description string       `json:"description,omitempty"`
+	Required    bool         `json:"required,omitempty"`
+	Deprecated  bool         `json:"deprecated,omitempty"`
+	Style       string       `json:"style,omitempty"`
+	Explode     *bool        `json:"explode,omitempty"`
+	Schema      *spec.Schema `json:"schema,omitempty"`
+	Example     any          `json:"example,omitempty"`
+	Extensions  Extensions   `json:"-"`
+}
+
+// RequestBody describes a single request body.
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+	Required    bool                  `json:"required,omitempty"`
+}
+
+// MediaType provides schema and examples for the media type identified by its key.
+type MediaType struct {
+	Schema   *spec.Schema         `json:"schema,omitempty"`
+	Example  any                  `json:"example,omitempty"`
+	Examples map[string]*Example  `json:"examples,omitempty"`
+	Encoding map[string]*Encoding `json:"encoding,omitempty"`
+}
+
+// Encoding the encoding of a part of a multipart request body.
+type Encoding struct {
+	ContentType string             `json:"contentType,omitempty"`
+	Headers     map[string]*Header `json:"headers,omitempty"`
+}
+
+// Example a named example of a media type.
+type Example struct {
+	Summary string `json:"summary,omitempty"`
+	Value   any    `json:"value"`
+}
+
+// Response describes a single response from an API operation.
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+	Extensions  Extensions            `json:"-"`
+}
+
+// Header describes a single response header.
+type Header struct {
+	Description string       `json:"description,omitempty"`
+	Schema      *spec.Schema `json:"schema,omitempty"`
+}
+
+// Components holds a set of reusable objects.
+type Components struct {
+	Schemas         map[string]spec.Schema     `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme defines a security scheme that can be used by the operations.
+type SecurityScheme struct {
+	Type         string      `json:"type"`
+	Description  string      `json:"description,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	In           string      `json:"in,omitempty"`
+	Scheme       string      `json:"scheme,omitempty"`
+	BearerFormat string      `json:"bearerFormat,omitempty"`
+	Flows        *OAuthFlows `json:"flows,omitempty"`
+	Extensions   Extensions  `json:"-"`
+}
+
+// OAuthFlows allows configuration of the supported OAuth Flows.
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow configuration details for a supported OAuth Flow.
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// MarshalJSON marshal Document with its extensions.
+func (d Document) MarshalJSON() ([]byte, error) {
+	type document Document
+
+	return marshalWithExtensions(document(d), d.Extensions)
+}
+
+// MarshalJSON marshal PathItem with its extensions.
+func (p PathItem) MarshalJSON() ([]byte, error) {
+	type pathItem PathItem
+
+	return marshalWithExtensions(pathItem(p), p.Extensions)
+}
+
+// MarshalJSON marshal Operation with its extensions.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+
+	return marshalWithExtensions(operation(o), o.Extensions)
+}
+
+// MarshalJSON marshal Parameter with its extensions.
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	type parameter Parameter
+
+	return marshalWithExtensions(parameter(p), p.Extensions)
+}
+
+// MarshalJSON marshal Response with its extensions.
+func (r Response) MarshalJSON() ([]byte, error) {
+	type response Response
+
+	return marshalWithExtensions(response(r), r.Extensions)
+}
+
+// MarshalJSON marshal SecurityScheme with its extensions.
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	type securityScheme SecurityScheme
+
+	return marshalWithExtensions(securityScheme(s), s.Extensions)
+}
+
+// marshalWithExtensions appends the extensions to the JSON object of v.
+func marshalWithExtensions(v any, extensions Extensions) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return b, err
+	}
+
+	ext, err := json.Marshal(map[string]any(extensions))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(b, []byte("{}")) {
+		return ext, nil
+	}
+
+	// merge {"a":1} and {"x-b":2} into {"a":1,"x-b":2}
+	return append(append(b[:len(b)-1], ','), ext[1:]...), nil
+}
@@ -2,6 +2,7 @@ package swag
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/ast"
 	goparser "go/parser"
@@ -32,6 +33,25 @@ type Operation struct {
 	spec.Operation
 	RouterProperties []RouteProperties
 	State            string
+
+	// heredoc is the description block being read, started by @Description <<EOF
+	heredoc *heredocBlock
+
+	// noDefaultResponses opts out of the @defaultResponse annotations of the general API info
+	noDefaultResponses bool
+
+	// collectionFormat the @query.collection.format of the operation, overriding the one of the general API info
+	collectionFormat string
+
+	// defaultCollectionFormats the array parameters, by location and name, without collectionFormat of their own
+	defaultCollectionFormats map[string]bool
+}
+
+// heredocBlock collects the lines of a description block until its delimiter.
+type heredocBlock struct {
+	delimiter string
+	lang      string
+	lines     []string
 }
 
 var mimeTypeAliases = map[string]string{
@@ -51,7 +71,10 @@ var mimeTypeAliases = map[string]string{
 }
 
 var mimeTypePattern = regexp.MustCompile("^[^/]+/[^/]+$")
-var securityPairSepPattern = regexp.MustCompile(`\|\||&&`) // || for compatibility with old version, && for clarity
+var (
+	securityOrSepPattern   = regexp.MustCompile(`\|\|`)    // alternative security requirements, with SecurityAlternatives
+	securityPairSepPattern = regexp.MustCompile(`\|\||&&`) // || for compatibility with old version, && for clarity
+)
 
 // NewOperation creates a new Operation with default properties.
 // map[int]Response.
@@ -109,6 +132,12 @@ func SetCodeExampleFilesDirectory(directoryPath string) func(*Operation) {
 
 // ParseComment parses comment for given comment string and returns error if error occurs.
 func (operation *Operation) ParseComment(comment string, astFile *ast.File) error {
+	if operation.heredoc != nil {
+		operation.parseHeredocLine(comment)
+
+		return nil
+	}
+
 	commentLine := strings.TrimSpace(strings.TrimLeft(comment, "/"))
 	if len(commentLine) == 0 {
 		return nil
@@ -121,13 +150,28 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 	if len(fields) > 1 {
 		lineRemainder = fields[1]
 	}
+	base, lang, localized := SplitLocalizedAttribute(attribute)
+	if base == descriptionAttr {
+		if delimiter, ok := heredocDelimiter(lineRemainder); ok {
+			operation.heredoc = &heredocBlock{delimiter: delimiter, lang: lang}
+
+			return nil
+		}
+	}
+
+	if localized && base == descriptionAttr {
+		operation.ParseLocalizedDescriptionComment(lang, lineRemainder)
+
+		return nil
+	}
+
 	switch lowerAttribute {
 	case stateAttr:
 		operation.ParseStateComment(lineRemainder)
 	case descriptionAttr:
 		operation.ParseDescriptionComment(lineRemainder)
 	case descriptionMarkdownAttr:
-		commentInfo, err := getMarkdownForTag(lineRemainder, operation.parser.markdownFileDir)
+		commentInfo, err := operation.parser.getMarkdown(lineRemainder)
 		if err != nil {
 			return err
 		}
@@ -145,10 +189,26 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 		return operation.ParseProduceComment(lineRemainder)
 	case paramAttr:
 		return operation.ParseParamComment(lineRemainder, astFile)
+	case collectionFormatAttr:
+		return operation.ParseCollectionFormatComment(lineRemainder)
+	case requestBodyAttr:
+		return operation.ParseRequestBodyComment(lineRemainder, astFile)
 	case successAttr, failureAttr, responseAttr:
 		return operation.ParseResponseComment(lineRemainder, astFile)
 	case headerAttr:
 		return operation.ParseResponseHeaderComment(lineRemainder, astFile)
+	case exampleAttr:
+		return operation.ParseExampleComment(lineRemainder, astFile)
+	case successExampleAttr:
+		return operation.ParseSuccessExampleComment(lineRemainder, astFile)
+	case requestExampleAttr:
+		return operation.ParseRequestExampleComment(lineRemainder, astFile)
+	case callbackAttr:
+		return operation.ParseCallbackComment(lineRemainder, astFile)
+	case serverAttr:
+		return operation.ParseServerComment(lineRemainder)
+	case extDocsURLAttr, extDocsDescAttr:
+		operation.ParseExternalDocsComment(lowerAttribute, lineRemainder)
 	case routerAttr:
 		return operation.ParseRouterComment(lineRemainder, false)
 	case deprecatedRouterAttr:
@@ -156,7 +216,9 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 	case securityAttr:
 		return operation.ParseSecurityComment(lineRemainder)
 	case deprecatedAttr:
-		operation.Deprecate()
+		return operation.ParseDeprecatedComment(lineRemainder)
+	case noDefaultResponsesAttr:
+		operation.noDefaultResponses = true
 	case xCodeSamplesAttr:
 		return operation.ParseCodeSample(attribute, commentLine, lineRemainder)
 	default:
@@ -166,6 +228,29 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 	return nil
 }
 
+// parseHeredocLine adds a line to the description block, the block is added to the description
+// with blank lines and indentation preserved when its delimiter is reached.
+func (operation *Operation) parseHeredocLine(comment string) {
+	line := trimCommentMarker(comment)
+	if strings.TrimSpace(line) != operation.heredoc.delimiter {
+		operation.heredoc.lines = append(operation.heredoc.lines, line)
+
+		return
+	}
+
+	block := operation.heredoc
+	operation.heredoc = nil
+
+	description := strings.Join(block.lines, "\n")
+	if block.lang != "" {
+		operation.ParseLocalizedDescriptionComment(block.lang, description)
+
+		return
+	}
+
+	operation.ParseDescriptionComment(description)
+}
+
 // ParseCodeSample parse code sample.
 func (operation *Operation) ParseCodeSample(attribute, _, lineRemainder string) error {
 	if lineRemainder == "file" {
@@ -191,6 +276,20 @@ func (operation *Operation) ParseCodeSample(attribute, _, lineRemainder string)
 	return operation.ParseMetadata(attribute, strings.ToLower(attribute), lineRemainder)
 }
 
+// ParseExternalDocsComment parses @externalDocs.url and @externalDocs.description of the operation.
+func (operation *Operation) ParseExternalDocsComment(attribute, lineRemainder string) {
+	if operation.ExternalDocs == nil {
+		operation.ExternalDocs = new(spec.ExternalDocumentation)
+	}
+
+	switch attribute {
+	case extDocsURLAttr:
+		operation.ExternalDocs.URL = lineRemainder
+	case extDocsDescAttr:
+		operation.ExternalDocs.Description = lineRemainder
+	}
+}
+
 // ParseStateComment parse state comment.
 func (operation *Operation) ParseStateComment(lineRemainder string) {
 	operation.State = lineRemainder
@@ -207,6 +306,11 @@ func (operation *Operation) ParseDescriptionComment(lineRemainder string) {
 	operation.Description = AppendDescription(operation.Description, lineRemainder)
 }
 
+// ParseLocalizedDescriptionComment parse description comment for a specific language, eg: @Description[zh].
+func (operation *Operation) ParseLocalizedDescriptionComment(lang, lineRemainder string) {
+	operation.Extensions = AppendLocalizedDescription(operation.Extensions, lang, lineRemainder)
+}
+
 // ParseMetadata parse metadata.
 func (operation *Operation) ParseMetadata(attribute, lowerAttribute, lineRemainder string) error {
 	// parsing specific meta data extensions
@@ -215,9 +319,22 @@ func (operation *Operation) ParseMetadata(attribute, lowerAttribute, lineRemaind
 			return fmt.Errorf("annotation %s need a value", attribute)
 		}
 
+		value := []byte(lineRemainder)
+
+		if matches := extensionFilePattern.FindStringSubmatch(lineRemainder); matches != nil {
+			fileName := filepath.Join(operation.parser.extensionFilesDir, matches[1])
+
+			data, err := os.ReadFile(fileName)
+			if err != nil {
+				return fmt.Errorf("annotation %s failed to read file %s: %v", attribute, fileName, err)
+			}
+
+			value = data
+		}
+
 		var valueJSON any
 
-		err := json.Unmarshal([]byte(lineRemainder), &valueJSON)
+		err := json.Unmarshal(value, &valueJSON)
 		if err != nil {
 			return fmt.Errorf("annotation %s need a valid json value", attribute)
 		}
@@ -229,7 +346,11 @@ func (operation *Operation) ParseMetadata(attribute, lowerAttribute, lineRemaind
 	return nil
 }
 
-var paramPattern = regexp.MustCompile(`(\S+)\s+(\w+)\s+([\S. ]+?)\s+(\w+)\s+"([^"]+)"`)
+// extensionFilePattern matches file(name.json), loading the extension value from a file.
+var extensionFilePattern = regexp.MustCompile(`^file\((.+)\)$`)
+
+// paramPattern matches name, paramType, data type, required and the quoted comment, where \" escapes a quote.
+var paramPattern = regexp.MustCompile(`(\S+)\s+(\w+)\s+([\S. ]+?)\s+(\w+)\s+"((?:[^"\\]|\\.)+)"`)
 
 func findInSlice(arr []string, target string) bool {
 	for _, str := range arr {
@@ -283,12 +404,15 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 
 	requiredText := strings.ToLower(matches[4])
 	required := requiredText == "true" || requiredText == requiredLabel
-	description := strings.Join(strings.Split(matches[5], "\\n"), "\n")
+	description := unescapeAttribute(matches[5])
 
-	param := createParameter(paramType, description, name, objectType, refType, format, required, enums, operation.parser.collectionFormatInQuery)
+	// attributes follow the comment, so that a comment can mention e.g. default(1)
+	attributes := commentLine[strings.Index(commentLine, matches[0])+len(matches[0]):]
+
+	param := createParameter(paramType, description, name, objectType, refType, format, required, enums, operation.collectionFormatInQuery())
 
 	switch paramType {
-	case "path", "header", "query", "formData":
+	case "path", "header", "query", "formData", "cookie":
 		switch objectType {
 		case ARRAY:
 			if !IsPrimitiveType(refType) && !(refType == "file" && paramType == "formData") {
@@ -348,14 +472,22 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 					if !IsSimplePrimitiveType(itemSchema.Type[0]) {
 						continue
 					}
-					collectionFormat := operation.parser.collectionFormatInQuery
-					if cfv, ok := prop.Extensions.GetString(collectionFormatTag); ok {
+					collectionFormat := operation.collectionFormatInQuery()
+					cfv, explicit := prop.Extensions.GetString(collectionFormatTag)
+					if explicit {
+						if err := checkCollectionFormat(cfv, paramType); err != nil {
+							return fmt.Errorf("%s of %s: %w", name, refType, err)
+						}
+
 						collectionFormat = cfv
 					}
 					param = createParameter(paramType, prop.Description, name, prop.Type[0], itemSchema.Type[0], format, findInSlice(schema.Required, item.Name), itemSchema.Enum, collectionFormat)
+					if !explicit {
+						operation.defaultCollectionFormat(param)
+					}
 
 				case IsSimplePrimitiveType(prop.Type[0]):
-					param = createParameter(paramType, prop.Description, name, PRIMITIVE, prop.Type[0], format, findInSlice(schema.Required, item.Name), nil, operation.parser.collectionFormatInQuery)
+					param = createParameter(paramType, prop.Description, name, PRIMITIVE, prop.Type[0], format, findInSlice(schema.Required, item.Name), nil, operation.collectionFormatInQuery())
 				default:
 					operation.parser.debug.Printf("skip field [%s] in %s is not supported type for %s", name, refType, paramType)
 					continue
@@ -378,7 +510,7 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 				param.CommonValidations.UniqueItems = prop.UniqueItems
 				param.CommonValidations.MultipleOf = prop.MultipleOf
 				param.CommonValidations.Enum = prop.Enum
-				operation.Operation.Parameters = append(operation.Operation.Parameters, param)
+				operation.addParameter(param)
 			}
 
 			return nil
@@ -398,17 +530,89 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 		return fmt.Errorf("not supported paramType: %s", paramType)
 	}
 
-	err := operation.parseParamAttribute(commentLine, objectType, refType, paramType, &param)
+	err := operation.parseParamAttribute(attributes, objectType, refType, paramType, &param)
 
 	if err != nil {
 		return err
 	}
 
-	operation.Operation.Parameters = append(operation.Operation.Parameters, param)
+	explicitFormat := regexAttributes[collectionFormatTag].MatchString(attributes)
+
+	switch {
+	case objectType == ARRAY && refType == "file":
+		// each file is a part of its own of the multipart form
+		if explicitFormat && param.CollectionFormat != "multi" {
+			return fmt.Errorf("%s is an array of files, sent with the multi collection format, got %s",
+				name, param.CollectionFormat)
+		}
+
+		param.CollectionFormat = "multi"
+	case objectType == ARRAY && paramType != "body" && !explicitFormat:
+		operation.defaultCollectionFormat(param)
+	}
+
+	operation.addParameter(param)
+
+	return nil
+}
+
+// collectionFormatInQuery returns the default collection format of the array parameters of the operation, its own
+// @query.collection.format or else the one of the general API info.
+func (operation *Operation) collectionFormatInQuery() string {
+	if operation.collectionFormat != "" {
+		return operation.collectionFormat
+	}
+
+	return operation.parser.collectionFormatInQuery
+}
+
+// defaultCollectionFormat records that the array parameter param has the default collection format, which a later
+// @query.collection.format of the operation replaces.
+func (operation *Operation) defaultCollectionFormat(param spec.Parameter) {
+	if operation.defaultCollectionFormats == nil {
+		operation.defaultCollectionFormats = make(map[string]bool)
+	}
+
+	operation.defaultCollectionFormats[param.In+" "+param.Name] = true
+}
+
+// ParseCollectionFormatComment parses the @query.collection.format of the operation, e.g. multi, the collection
+// format of its array parameters without collectionFormat of their own, whichever the order of the annotations.
+func (operation *Operation) ParseCollectionFormatComment(commentLine string) error {
+	format := strings.TrimSpace(commentLine)
+	if err := checkCollectionFormat(format, ""); err != nil {
+		return fmt.Errorf("%s %s: %w", collectionFormatAttr, format, err)
+	}
+
+	for i := range operation.Parameters {
+		param := &operation.Parameters[i]
+		if !operation.defaultCollectionFormats[param.In+" "+param.Name] {
+			continue
+		}
+
+		if err := checkCollectionFormat(format, param.In); err != nil {
+			return fmt.Errorf("%s %s of %s: %w", collectionFormatAttr, format, param.Name, err)
+		}
+
+		param.CollectionFormat = format
+	}
+
+	operation.collectionFormat = format
 
 	return nil
 }
 
+// addParameter adds param to the operation, parameters only OpenAPI 3 can express are downgraded.
+func (operation *Operation) addParameter(param spec.Parameter) {
+	if param.In == "cookie" {
+		operation.downgradeCookieParameter(param)
+
+		return
+	}
+
+	operation.Operation.Parameters = append(operation.Operation.Parameters, param)
+}
+
 const (
 	formTag             = "form"
 	jsonTag             = "json"
@@ -428,33 +632,39 @@ const (
 	maxLengthTag        = "maxLength"
 	multipleOfTag       = "multipleOf"
 	readOnlyTag         = "readonly"
+	writeOnlyTag        = "writeonly"
 	extensionsTag       = "extensions"
 	collectionFormatTag = "collectionFormat"
+	unitTag             = "unit"
+	currencyTag         = "currency"
+	deprecatedTag       = "deprecated"
 )
 
 var regexAttributes = map[string]*regexp.Regexp{
 	// for Enums(A, B)
-	enumsTag: regexp.MustCompile(`(?i)\s+enums\(.*?\)(?:\s|$)`),
+	enumsTag: regexp.MustCompile(`(?i)\s+enums\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for maximum(0)
-	maximumTag: regexp.MustCompile(`(?i)\s+(?:maxinum|maximum)\(.*?\)(?:\s|$)`),
+	maximumTag: regexp.MustCompile(`(?i)\s+(?:maxinum|maximum)\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for minimum(0)
-	minimumTag: regexp.MustCompile(`(?i)\s+(?:mininum|minimum)\(.*?\)(?:\s|$)`),
+	minimumTag: regexp.MustCompile(`(?i)\s+(?:mininum|minimum)\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for default(0)
-	defaultTag: regexp.MustCompile(`(?i)\s+default\(.*?\)(?:\s|$)`),
+	defaultTag: regexp.MustCompile(`(?i)\s+default\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for minlength(0)
-	minLengthTag: regexp.MustCompile(`(?i)\s+minlength\(.*?\)(?:\s|$)`),
+	minLengthTag: regexp.MustCompile(`(?i)\s+minlength\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for maxlength(0)
-	maxLengthTag: regexp.MustCompile(`(?i)\s+maxlength\(.*?\)(?:\s|$)`),
+	maxLengthTag: regexp.MustCompile(`(?i)\s+maxlength\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for format(email)
-	formatTag: regexp.MustCompile(`(?i)\s+format\(.*?\)(?:\s|$)`),
+	formatTag: regexp.MustCompile(`(?i)\s+format\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for extensions(x-example=test)
-	extensionsTag: regexp.MustCompile(`(?i)\s+extensions\(.*?\)(?:\s|$)`),
+	extensionsTag: regexp.MustCompile(`(?i)\s+extensions\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// for collectionFormat(csv)
-	collectionFormatTag: regexp.MustCompile(`(?i)\s+collectionFormat\(.*?\)(?:\s|$)`),
+	collectionFormatTag: regexp.MustCompile(`(?i)\s+collectionFormat\((?:\\.|[^\\])*?\)(?:\s|$)`),
+	// for mime(image/png)
+	mimeTag: regexp.MustCompile(`(?i)\s+mime\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// example(0)
-	exampleTag: regexp.MustCompile(`(?i)\s+example\(.*?\)(?:\s|$)`),
+	exampleTag: regexp.MustCompile(`(?i)\s+example\((?:\\.|[^\\])*?\)(?:\s|$)`),
 	// schemaExample(0)
-	schemaExampleTag: regexp.MustCompile(`(?i)\s+schemaExample\(.*?\)(?:\s|$)`),
+	schemaExampleTag: regexp.MustCompile(`(?i)\s+schemaExample\((?:\\.|[^\\])*?\)(?:\s|$)`),
 }
 
 func (operation *Operation) parseParamAttribute(comment, objectType, schemaType, paramType string, param *spec.Parameter) error {
@@ -466,6 +676,11 @@ func (operation *Operation) parseParamAttribute(comment, objectType, schemaType,
 			continue
 		}
 
+		// enums and extensions are lists, their values are unescaped after splitting
+		if attrKey != enumsTag && attrKey != extensionsTag {
+			attr = unescapeAttribute(attr)
+		}
+
 		switch attrKey {
 		case enumsTag:
 			err = setEnumParam(param, attr, objectType, schemaType, paramType)
@@ -482,7 +697,16 @@ func (operation *Operation) parseParamAttribute(comment, objectType, schemaType,
 		case schemaExampleTag:
 			err = setSchemaExample(param, schemaType, attr)
 		case extensionsTag:
-			param.Extensions = setExtensionParam(attr)
+			// merged, the mime attribute adds an extension too
+			for key, value := range setExtensionParam(attr) {
+				if param.Extensions == nil {
+					param.Extensions = make(spec.Extensions)
+				}
+
+				param.Extensions[key] = value
+			}
+		case mimeTag:
+			err = setEncodingParam(param, paramType, attr)
 		case collectionFormatTag:
 			err = setCollectionFormatParam(param, attrKey, objectType, attr, comment)
 		}
@@ -495,6 +719,70 @@ func (operation *Operation) parseParamAttribute(comment, objectType, schemaType,
 	return nil
 }
 
+// unescapeAttribute resolves the escapes of attribute values and comments:
+// \" for a quote, \( and \) for parentheses, \, for a comma, \\ for a backslash and \n for a new line.
+func unescapeAttribute(value string) string {
+	if !strings.Contains(value, "\\") {
+		return value
+	}
+
+	var result strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			result.WriteByte(value[i])
+
+			continue
+		}
+
+		i++
+
+		switch value[i] {
+		case '"', '(', ')', ',', '\\':
+			result.WriteByte(value[i])
+		case 'n':
+			result.WriteByte('\n')
+		default:
+			result.WriteByte('\\')
+			result.WriteByte(value[i])
+		}
+	}
+
+	return result.String()
+}
+
+// unquoteAttribute removes the quotes around a comment and resolves its escapes.
+func unquoteAttribute(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		value = value[1 : len(value)-1]
+	} else {
+		value = strings.Trim(value, "\"")
+	}
+
+	return unescapeAttribute(value)
+}
+
+// splitEscaped splits value at every sep which is not escaped by a backslash, escapes are kept.
+func splitEscaped(value string, sep byte) []string {
+	var (
+		result []string
+		start  int
+	)
+
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case sep:
+			result = append(result, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(result, value[start:])
+}
+
 func findAttr(re *regexp.Regexp, commentLine string) (string, error) {
 	attr := re.FindString(commentLine)
 
@@ -548,8 +836,8 @@ func setNumberParam(param *spec.Parameter, name, schemaType, attr, commentLine s
 }
 
 func setEnumParam(param *spec.Parameter, attr, objectType, schemaType, paramType string) error {
-	for _, e := range strings.Split(attr, ",") {
-		e = strings.TrimSpace(e)
+	for _, e := range splitEscaped(attr, ',') {
+		e = unescapeAttribute(strings.TrimSpace(e))
 
 		value, err := defineType(schemaType, e)
 		if err != nil {
@@ -572,13 +860,19 @@ func setEnumParam(param *spec.Parameter, attr, objectType, schemaType, paramType
 	return nil
 }
 
+// setExtension sets the extension key of ext to v. Unlike spec.Extensions.Add, it keeps the case of key, e.g.
+// x-codeSamples, which the consumers of the extensions match exactly.
+func setExtension(ext spec.Extensions, key string, v any) {
+	ext[key] = v
+}
+
 func setExtensionParam(attr string) spec.Extensions {
 	extensions := spec.Extensions{}
 
 	for _, val := range splitNotWrapped(attr, ',') {
 		parts := strings.SplitN(val, "=", 2)
 		if len(parts) == 2 {
-			extensions.Add(parts[0], parts[1])
+			extensions.Add(parts[0], unescapeAttribute(parts[1]))
 
 			continue
 		}
@@ -597,7 +891,11 @@ func setExtensionParam(attr string) spec.Extensions {
 
 func setCollectionFormatParam(param *spec.Parameter, name, schemaType, attr, commentLine string) error {
 	if schemaType == ARRAY {
-		param.CollectionFormat = TransToValidCollectionFormat(attr)
+		if err := checkCollectionFormat(attr, param.In); err != nil {
+			return fmt.Errorf("%s(%s): %w. comment=%s", name, attr, err, commentLine)
+		}
+
+		param.CollectionFormat = attr
 
 		return nil
 	}
@@ -605,6 +903,20 @@ func setCollectionFormatParam(param *spec.Parameter, name, schemaType, attr, com
 	return fmt.Errorf("%s is attribute to set to an array. comment=%s got=%s", name, commentLine, schemaType)
 }
 
+// checkCollectionFormat returns an error when format is no collection format of Swagger 2.0 arrays, or is multi for
+// a parameter in neither query nor formData. in is empty when the location is not known yet.
+func checkCollectionFormat(format, in string) error {
+	if TransToValidCollectionFormat(format) == "" {
+		return errors.New("the collection format is one of csv, ssv, tsv, pipes and multi")
+	}
+
+	if format == "multi" && in != "" && in != "query" && in != "formData" {
+		return fmt.Errorf("the multi collection format is only valid in query and formData, not in %s", in)
+	}
+
+	return nil
+}
+
 func setDefault(param *spec.Parameter, schemaType string, value string) error {
 	val, err := defineType(schemaType, value)
 	if err != nil {
@@ -747,34 +1059,9 @@ func (operation *Operation) ParseSecurityComment(commentLine string) error {
 		return nil
 	}
 
-	var (
-		securityMap    = make(map[string][]string)
-		securitySource = commentLine[strings.Index(commentLine, "@Security")+1:]
-	)
-
-	for _, securityOption := range securityPairSepPattern.Split(securitySource, -1) {
-		securityOption = strings.TrimSpace(securityOption)
-
-		left, right := strings.Index(securityOption, "["), strings.Index(securityOption, "]")
-
-		if !(left == -1 && right == -1) {
-			scopes := securityOption[left+1 : right]
-
-			var options []string
+	securitySource := commentLine[strings.Index(commentLine, "@Security")+1:]
 
-			for _, scope := range strings.Split(scopes, ",") {
-				options = append(options, strings.TrimSpace(scope))
-			}
-
-			securityKey := securityOption[0:left]
-			securityMap[securityKey] = append(securityMap[securityKey], options...)
-		} else {
-			securityKey := strings.TrimSpace(securityOption)
-			securityMap[securityKey] = []string{}
-		}
-	}
-
-	operation.Security = append(operation.Security, securityMap)
+	operation.Security = append(operation.Security, operation.parser.parseSecurity(securitySource)...)
 
 	return nil
 }
@@ -836,7 +1123,7 @@ func findTypeDef(importPath, typeName string) (*ast.TypeSpec, error) {
 	return nil, fmt.Errorf("type spec not found")
 }
 
-var responsePattern = regexp.MustCompile(`^([\w,]+)\s+([\w{}]+)\s+([\w\-.\\{}=,\[\s\]]+)\s*(".*)?`)
+var responsePattern = regexp.MustCompile(`^([\w,]+)\s+([\w{}]+)\s+([\w\-.\\{}=,():/#\[\s\]]+)\s*(".*)?`)
 
 // ResponseType{data1=Type1,data2=Type2}.
 var combinedPattern = regexp.MustCompile(`^([\w\-./\[\]]+){(.*)}$`)
@@ -882,6 +1169,17 @@ func parseObjectSchema(parser *Parser, refType string, astFile *ast.File) (*spec
 		}
 
 		return spec.MapProperty(schema), nil
+	case isRemoteRef(refType):
+		return spec.RefSchema(refType), nil
+	case isFileRef(refType):
+		return parser.parseFileRef(refType, astFile)
+	case strings.HasSuffix(refType, ")"):
+		keyword, types, ok := unionKeyword(refType)
+		if !ok {
+			return nil, fmt.Errorf("invalid type: %s", refType)
+		}
+
+		return parseUnionSchema(parser, keyword, types, astFile)
 	case strings.Contains(refType, "{"):
 		return parseCombinedObjectSchema(parser, refType, astFile)
 	default:
@@ -902,11 +1200,12 @@ func parseFields(s string) []string {
 	nestLevel := 0
 
 	return strings.FieldsFunc(s, func(char rune) bool {
-		if char == '{' {
+		switch char {
+		case '{', '(', '[':
 			nestLevel++
 
 			return false
-		} else if char == '}' {
+		case '}', ')', ']':
 			nestLevel--
 
 			return false
@@ -1005,7 +1304,7 @@ func (operation *Operation) ParseResponseComment(commentLine string, astFile *as
 		return err
 	}
 
-	description := strings.Trim(matches[4], "\"")
+	description := unquoteAttribute(matches[4])
 
 	schema, err := operation.parseAPIObjectSchema(commentLine, strings.Trim(matches[2], "{}"), strings.TrimSpace(matches[3]), astFile)
 	if err != nil {
@@ -1064,24 +1363,41 @@ func newHeaderSpec(schemaType, description string) spec.Header {
 }
 
 // ParseResponseHeaderComment parses comment for given `response header` comment string.
-func (operation *Operation) ParseResponseHeaderComment(commentLine string, _ *ast.File) error {
+func (operation *Operation) ParseResponseHeaderComment(commentLine string, astFile *ast.File) error {
 	matches := responsePattern.FindStringSubmatch(commentLine)
 	if len(matches) != 5 {
 		return fmt.Errorf("can not parse response comment \"%s\"", commentLine)
 	}
 
-	header := newHeaderSpec(strings.Trim(matches[2], "{}"), strings.Trim(matches[4], "\""))
-
+	schemaType := strings.Trim(matches[2], "{}")
 	headerKey := strings.TrimSpace(matches[3])
 
+	headers := map[string]spec.Header{headerKey: newHeaderSpec(schemaType, unquoteAttribute(matches[4]))}
+
+	// @Header 200 {object} PaginationHeaders declares a header by field of the struct
+	if schemaType == OBJECT && headerStructPattern.MatchString(headerKey) {
+		var err error
+
+		headers, err = operation.parseHeaderStruct(headerKey, astFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	setHeaders := func(response *spec.Response) {
+		for name, header := range headers {
+			response.Headers[name] = header
+		}
+	}
+
 	if strings.EqualFold(matches[1], "all") {
 		if operation.Responses.Default != nil {
-			operation.Responses.Default.Headers[headerKey] = header
+			setHeaders(operation.Responses.Default)
 		}
 
 		if operation.Responses.StatusCodeResponses != nil {
 			for code, response := range operation.Responses.StatusCodeResponses {
-				response.Headers[headerKey] = header
+				setHeaders(&response)
 				operation.Responses.StatusCodeResponses[code] = response
 			}
 		}
@@ -1092,7 +1408,7 @@ func (operation *Operation) ParseResponseHeaderComment(commentLine string, _ *as
 	for _, codeStr := range strings.Split(matches[1], ",") {
 		if strings.EqualFold(codeStr, defaultTag) {
 			if operation.Responses.Default != nil {
-				operation.Responses.Default.Headers[headerKey] = header
+				setHeaders(operation.Responses.Default)
 			}
 
 			continue
@@ -1106,7 +1422,7 @@ func (operation *Operation) ParseResponseHeaderComment(commentLine string, _ *as
 		if operation.Responses.StatusCodeResponses != nil {
 			response, responseExist := operation.Responses.StatusCodeResponses[code]
 			if responseExist {
-				response.Headers[headerKey] = header
+				setHeaders(&response)
 
 				operation.Responses.StatusCodeResponses[code] = response
 			}
@@ -1116,7 +1432,86 @@ func (operation *Operation) ParseResponseHeaderComment(commentLine string, _ *as
 	return nil
 }
 
-var emptyResponsePattern = regexp.MustCompile(`([\w,]+)\s+"(.*)"`)
+// headerStructPattern matches the Go type of the struct of @Header, e.g. PaginationHeaders or web.PaginationHeaders,
+// while header names like X-Rate-Limit keep declaring a single header.
+var headerStructPattern = regexp.MustCompile(`^\w+(\.\w+)*$`)
+
+// parseHeaderStruct returns the headers of the exported fields of the struct refType, named after their header tags
+// or their property names, described by their comments.
+func (operation *Operation) parseHeaderStruct(refType string, astFile *ast.File) (map[string]spec.Header, error) {
+	schema, err := operation.parser.getTypeSchema(refType, astFile, false)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]spec.Header{}
+
+	for _, item := range schema.Properties.ToOrderedSchemaItems() {
+		name, prop := item.Name, &item.Schema
+		if len(prop.Type) == 0 {
+			prop = operation.parser.getUnderlyingSchema(prop)
+			if len(prop.Type) == 0 {
+				continue
+			}
+		}
+
+		if nameVal, ok := item.Schema.Extensions.GetString(headerTag); ok {
+			name = nameVal
+			if name == "-" {
+				continue
+			}
+		}
+
+		header := newHeaderSpec(prop.Type[0], prop.Description)
+
+		switch {
+		case prop.Type[0] == ARRAY:
+			if prop.Items == nil || prop.Items.Schema == nil {
+				continue
+			}
+
+			itemSchema := prop.Items.Schema
+			if len(itemSchema.Type) == 0 {
+				itemSchema = operation.parser.getUnderlyingSchema(itemSchema)
+			}
+
+			if itemSchema == nil || len(itemSchema.Type) == 0 || !IsSimplePrimitiveType(itemSchema.Type[0]) {
+				operation.parser.debug.Printf("skip field [%s] in %s is not supported type for header", name, refType)
+
+				continue
+			}
+
+			header.Items = spec.NewItems().Typed(itemSchema.Type[0], itemSchema.Format).WithEnum(itemSchema.Enum...)
+			header.CollectionFormat = "csv"
+		case IsSimplePrimitiveType(prop.Type[0]):
+		default:
+			operation.parser.debug.Printf("skip field [%s] in %s is not supported type for header", name, refType)
+
+			continue
+		}
+
+		header.Format = prop.Format
+		header.Default = prop.Default
+		header.Example = prop.Example
+		header.Maximum = prop.Maximum
+		header.Minimum = prop.Minimum
+		header.ExclusiveMaximum = prop.ExclusiveMaximum
+		header.ExclusiveMinimum = prop.ExclusiveMinimum
+		header.MaxLength = prop.MaxLength
+		header.MinLength = prop.MinLength
+		header.Pattern = prop.Pattern
+		header.MaxItems = prop.MaxItems
+		header.MinItems = prop.MinItems
+		header.UniqueItems = prop.UniqueItems
+		header.MultipleOf = prop.MultipleOf
+		header.Enum = prop.Enum
+		headers[name] = header
+	}
+
+	return headers, nil
+}
+
+var emptyResponsePattern = regexp.MustCompile(`([\w,]+)\s+(".*")`)
 
 // ParseEmptyResponseComment parse only comment out status code and description,eg: @Success 200 "it's ok".
 func (operation *Operation) ParseEmptyResponseComment(commentLine string) error {
@@ -1125,7 +1520,7 @@ func (operation *Operation) ParseEmptyResponseComment(commentLine string) error
 		return fmt.Errorf("can not parse response comment \"%s\"", commentLine)
 	}
 
-	description := strings.Trim(matches[2], "\"")
+	description := unquoteAttribute(matches[2])
 
 	for _, codeStr := range strings.Split(matches[1], ",") {
 		if strings.EqualFold(codeStr, defaultTag) {
@@ -1188,6 +1583,11 @@ func (operation *Operation) AddResponse(code int, response *spec.Response) {
 	operation.Responses.StatusCodeResponses[code] = *response
 }
 
+// isFileParameter reports whether param uploads files, a file or an array of files of a multipart form.
+func isFileParameter(param *spec.Parameter) bool {
+	return param.Type == "file" || param.Type == ARRAY && param.Items != nil && param.Items.Type == "file"
+}
+
 // createParameter returns swagger spec.Parameter for given  paramType, description, paramName, schemaType, required.
 func createParameter(paramType, description, paramName, objectType, schemaType string, format string, required bool, enums []any, collectionFormat string) spec.Parameter {
 	// //five possible parameter types. 	query, path, body, header, form
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseEmptyComment(t *testing.T) {
@@ -1331,6 +1332,46 @@ func TestParseParamCommentQueryArrayFormat(t *testing.T) {
 	assert.Equal(t, expected, string(b))
 }
 
+func TestParseCollectionFormatComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(New(SetCollectionFormat("csv")))
+	for _, comment := range []string{
+		`@Param ids query []int true "IDs"`,
+		`@Param names query []string true "Names" collectionFormat(pipes)`,
+		`@query.collection.format multi`,
+		`@Param tags query []string false "Tags"`,
+		`@Param ids formData []int true "IDs"`,
+	} {
+		require.NoError(t, operation.ParseComment(comment, nil), comment)
+	}
+
+	formats := make(map[string]string)
+	for _, param := range operation.Parameters {
+		formats[param.In+" "+param.Name] = param.CollectionFormat
+	}
+
+	assert.Equal(t, map[string]string{
+		"query ids":    "multi",
+		"query names":  "pipes",
+		"query tags":   "multi",
+		"formData ids": "multi",
+	}, formats)
+
+	operation = NewOperation(nil)
+	assert.ErrorContains(t, operation.ParseComment(`@query.collection.format oops`, nil),
+		"the collection format is one of csv, ssv, tsv, pipes and multi")
+
+	require.NoError(t, operation.ParseComment(`@Param X-IDs header []int true "IDs"`, nil))
+	assert.ErrorContains(t, operation.ParseComment(`@query.collection.format multi`, nil),
+		"the multi collection format is only valid in query and formData, not in header")
+
+	assert.ErrorContains(t, operation.ParseComment(`@Param names query []string true "Names" collectionFormat(oops)`, nil),
+		"collectionFormat(oops)")
+	assert.ErrorContains(t, operation.ParseComment(`@Param X-Names header []string true "Names" collectionFormat(multi)`, nil),
+		"not in header")
+}
+
 // Test ParseParamComment Query Params
 func TestParseParamCommentQueryArrayFormatWithStructTag(t *testing.T) {
 	parser := New()
@@ -1472,6 +1513,40 @@ func TestParseParamCommentByBodyType(t *testing.T) {
 	assert.Equal(t, expected, string(b))
 }
 
+func TestParseParamCommentEscapes(t *testing.T) {
+	t.Parallel()
+
+	comment := `@Param q query string false "say \"hi\" (politely), default(x) is a comment\nsecond line" Enums(a\,b, c\), d) default(a\,b) extensions(x-note=1\,2)`
+	operation := NewOperation(nil)
+
+	err := operation.ParseComment(comment, nil)
+	assert.NoError(t, err)
+
+	param := operation.Parameters[0]
+	assert.Equal(t, "say \"hi\" (politely), default(x) is a comment\nsecond line", param.Description)
+	assert.Equal(t, []any{"a,b", "c)", "d"}, param.Enum)
+	assert.Equal(t, "a,b", param.Default)
+	assert.Equal(t, "1,2", param.Extensions["x-note"])
+}
+
+func TestParseResponseCommentEscapes(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	err := operation.ParseComment(`@Success 200 {string} string "a \"quoted\" word"`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `a "quoted" word`, operation.Responses.StatusCodeResponses[200].Description)
+
+	err = operation.ParseComment(`@Header 200 {string} X-Note "the \"note\""`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `the "note"`, operation.Responses.StatusCodeResponses[200].Headers["X-Note"].Description)
+
+	err = operation.ParseComment(`@Failure 404 "not \"found\""`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `not "found"`, operation.Responses.StatusCodeResponses[404].Description)
+}
+
 func TestParseParamCommentByBodyTextPlain(t *testing.T) {
 	t.Parallel()
 
@@ -1677,6 +1752,39 @@ func TestParseParamCommentByFormDataType(t *testing.T) {
 	assert.Equal(t, expected, string(b))
 }
 
+func TestParseParamCommentByFormDataFileArray(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(New(SetCollectionFormat("csv")))
+	err := operation.ParseComment(`@Param files formData []file true "multiple files"`, nil)
+	require.NoError(t, err)
+
+	b, _ := json.MarshalIndent(operation.Parameters, "", "    ")
+	expected := `[
+    {
+        "type": "array",
+        "items": {
+            "type": "file"
+        },
+        "collectionFormat": "multi",
+        "description": "multiple files",
+        "name": "files",
+        "in": "formData",
+        "required": true
+    }
+]`
+	assert.Equal(t, expected, string(b))
+	assert.True(t, isFileParameter(&operation.Parameters[0]))
+
+	// the operation default does not apply to files
+	require.NoError(t, operation.ParseComment(`@query.collection.format csv`, nil))
+	assert.Equal(t, "multi", operation.Parameters[0].CollectionFormat)
+
+	assert.ErrorContains(t, operation.ParseComment(`@Param files formData []file true "files" collectionFormat(csv)`, nil),
+		"files is an array of files, sent with the multi collection format, got csv")
+	assert.Error(t, operation.ParseComment(`@Param files query []file true "files"`, nil))
+}
+
 func TestParseParamCommentByFormDataTypeUint64(t *testing.T) {
 	t.Parallel()
 
@@ -2432,6 +2540,30 @@ func TestParseSecurityCommentAnd(t *testing.T) {
 	assert.Equal(t, operation.Security, expect)
 }
 
+func TestParseSecurityCommentOr(t *testing.T) {
+	t.Parallel()
+
+	parser := New()
+	parser.SecurityAlternatives = true
+
+	comment := `@Security ApiKeyAuth && OAuth2Application[read] || BasicAuth`
+	operation := NewOperation(parser)
+
+	err := operation.ParseComment(comment, nil)
+	assert.NoError(t, err)
+
+	expect := []map[string][]string{
+		{
+			"ApiKeyAuth":        {},
+			"OAuth2Application": {"read"},
+		},
+		{
+			"BasicAuth": {},
+		},
+	}
+	assert.Equal(t, expect, operation.Security)
+}
+
 func TestParseMultiDescription(t *testing.T) {
 	t.Parallel()
 
@@ -2455,6 +2587,60 @@ func TestParseMultiDescription(t *testing.T) {
 	assert.Contains(t, string(b), expected)
 }
 
+func TestParseHeredocDescription(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	for _, comment := range []string{
+		`// @Description <<EOF`,
+		`// | Code | Meaning |`,
+		`// |------|---------|`,
+		`//`,
+		`//     if err != nil { \`,
+		`//         return err`,
+		`//     }`,
+		`// EOF`,
+		`// @Description[zh] <<END`,
+		`//   中文`,
+		`// END`,
+		`// @Summary summary`,
+	} {
+		err := operation.ParseComment(comment, nil)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, "| Code | Meaning |\n|------|---------|\n\n    if err != nil { \\\n        return err\n    }", operation.Description)
+	assert.Equal(t, map[string]string{"zh": "  中文"}, operation.Extensions[descriptionsExtension])
+	assert.Equal(t, "summary", operation.Summary)
+}
+
+func TestParseLocalizedDescription(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	for _, comment := range []string{
+		`@Description default description`,
+		`@Description[en] english line one`,
+		`@Description[en] english line two`,
+		`@Description[zh] 中文描述`,
+		`@Description[zh-Hans] 简体中文`,
+		`@description[en-US] american english`,
+	} {
+		err := operation.ParseComment(comment, nil)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, "default description", operation.Description)
+	assert.Equal(t, map[string]string{
+		"en":      "english line one\nenglish line two",
+		"zh":      "中文描述",
+		"zh-Hans": "简体中文",
+		"en-US":   "american english",
+	}, operation.Extensions[descriptionsExtension])
+}
+
 func TestParseDescriptionMarkdown(t *testing.T) {
 	t.Parallel()
 
@@ -2500,6 +2686,23 @@ func TestParseDeprecationDescription(t *testing.T) {
 	}
 }
 
+func TestParseExternalDocsComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	err := operation.ParseComment(`@externalDocs.description  How orders are priced`, nil)
+	assert.NoError(t, err)
+
+	err = operation.ParseComment(`@externalDocs.url  https://example.com/docs/pricing`, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &spec.ExternalDocumentation{
+		Description: "How orders are priced",
+		URL:         "https://example.com/docs/pricing",
+	}, operation.ExternalDocs)
+}
+
 func TestParseExtentions(t *testing.T) {
 	t.Parallel()
 	// Fail if there are no args for attributes.
@@ -2575,6 +2778,75 @@ func TestParseResponseHeaderComment(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestParseResponseHeaderStruct(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type PaginationHeaders struct {
+	// the number of items
+	Total int ` + "`header:\"X-Total-Count\" minimum:\"0\"`" + `
+	// the relations of the pages
+	Links []string ` + "`header:\"Link\"`" + `
+	Internal string ` + "`header:\"-\"`" + `
+	Page Page ` + "`header:\"X-Page\"`" + `
+}
+
+type Page struct {
+	Number int
+}
+
+// @Success 200 {string} string "ok"
+// @Header  200 {object} PaginationHeaders
+// @Header  200 {string} X-Request-Id "the request"
+// @Router  /pets [get]
+func Pets(){
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	headers := p.swagger.Paths.Paths["/pets"].Get.Responses.StatusCodeResponses[200].Headers
+	assert.Equal(t, []string{"Link", "X-Request-Id", "X-Total-Count"}, sortedKeys(headers))
+
+	assert.Equal(t, "integer", headers["X-Total-Count"].Type)
+	assert.Equal(t, "the number of items", headers["X-Total-Count"].Description)
+	require.NotNil(t, headers["X-Total-Count"].Minimum)
+	assert.Equal(t, 0.0, *headers["X-Total-Count"].Minimum)
+
+	assert.Equal(t, "array", headers["Link"].Type)
+	assert.Equal(t, "the relations of the pages", headers["Link"].Description)
+	require.NotNil(t, headers["Link"].Items)
+	assert.Equal(t, "string", headers["Link"].Items.Type)
+
+	assert.Equal(t, "string", headers["X-Request-Id"].Type)
+
+	operation := NewOperation(p)
+	require.NoError(t, operation.ParseResponseComment(`200 {string} string "ok"`, nil))
+	assert.Error(t, operation.ParseResponseHeaderComment(`200 {object} Missing`, nil))
+}
+
+func TestParseRemoteRefComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	require.NoError(t, operation.ParseComment(`@Param user body https://schemas.example.com/user.json true "the new user"`, nil))
+	require.NoError(t, operation.ParseComment(`@Success 200 {object} https://schemas.example.com/user.json#/definitions/Address "the address"`, nil))
+
+	assert.Equal(t, spec.RefSchema("https://schemas.example.com/user.json"), operation.Parameters[0].Schema)
+
+	response := operation.Responses.StatusCodeResponses[200]
+	assert.Equal(t, spec.RefSchema("https://schemas.example.com/user.json#/definitions/Address"), response.Schema)
+	assert.Equal(t, "the address", response.Description)
+}
+
 func TestParseObjectSchema(t *testing.T) {
 	t.Parallel()
 
@@ -2597,6 +2869,10 @@ func TestParseObjectSchema(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, schema, PrimitiveSchema(INTEGER))
 
+	schema, err = operation.parseObjectSchema("https://schemas.example.com/user.json#/definitions/Address", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, schema, spec.RefSchema("https://schemas.example.com/user.json#/definitions/Address"))
+
 	schema, err = operation.parseObjectSchema("[]string", nil)
 	assert.NoError(t, err)
 	assert.Equal(t, schema, spec.ArrayProperty(PrimitiveSchema(STRING)))
@@ -2652,6 +2928,19 @@ func TestParseObjectSchema(t *testing.T) {
 	assert.Equal(t, schema, RefSchema("user.Model"))
 }
 
+func TestParseExtensionFromFile(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(New(SetExtensionFilesDirectory("testdata/extension_files")))
+
+	err := operation.ParseComment(`@x-gateway file(gateway.json)`, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"timeout": "30s", "retries": float64(3)}, operation.Extensions["x-gateway"])
+
+	err = operation.ParseComment(`@x-gateway file(missing.json)`, nil)
+	assert.ErrorContains(t, err, "annotation @x-gateway failed to read file")
+}
+
 func TestParseCodeSamples(t *testing.T) {
 	t.Parallel()
 	const comment = `@x-codeSamples file`
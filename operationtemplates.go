@@ -0,0 +1,119 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// CRUDTemplate is the name of the default operation template expanded by @crud.
+const CRUDTemplate = "crud"
+
+// defaultOperationTemplates are the operation templates when none is set by SetOperationTemplates.
+// Each template is a list of annotation blocks with the placeholders {type}, {name} and {path}.
+var defaultOperationTemplates = map[string][]string{
+	CRUDTemplate: {
+		`@Summary  List {name}s
+@ID       list{name}s
+@Produce  json
+@Success  200  {array}  {type}
+@Router   {path} [get]`,
+		`@Summary  Create a {name}
+@ID       create{name}
+@Accept   json
+@Produce  json
+@Param    {name}  body  {type}  true  "{name}"
+@Success  201  {object}  {type}
+@Router   {path} [post]`,
+		`@Summary  Get a {name}
+@ID       get{name}
+@Produce  json
+@Param    id  path  string  true  "{name} ID"
+@Success  200  {object}  {type}
+@Router   {path}/{id} [get]`,
+		`@Summary  Update a {name}
+@ID       update{name}
+@Accept   json
+@Produce  json
+@Param    id  path  string  true  "{name} ID"
+@Param    {name}  body  {type}  true  "{name}"
+@Success  200  {object}  {type}
+@Router   {path}/{id} [put]`,
+		`@Summary  Delete a {name}
+@ID       delete{name}
+@Param    id  path  string  true  "{name} ID"
+@Success  204  "No Content"
+@Router   {path}/{id} [delete]`,
+	},
+}
+
+// SetOperationTemplates sets the operation templates by name, which @crud expands, replacing the default crud
+// template when one is named crud. Each template is a list of annotation blocks, one for each operation, where
+// {type} is replaced by the type of the annotation, {name} by the type without its package and {path} by its path.
+func SetOperationTemplates(templates map[string][]string) func(*Parser) {
+	return func(p *Parser) {
+		for name, blocks := range templates {
+			p.operationTemplates[name] = blocks
+		}
+	}
+}
+
+// expandOperationTemplate returns the annotation lines of the operations of a @crud annotation, each followed by
+// the other lines of its block, which are common to the operations.
+func (parser *Parser) expandOperationTemplate(commentLine string, lines []string) ([][]string, error) {
+	fields := FieldsByAnySpace(strings.TrimSpace(commentLine), 4)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("%s expects a type and a path, e.g. %s model.User /users", crudAttr, crudAttr)
+	}
+
+	templateName := CRUDTemplate
+	if len(fields) == 4 {
+		templateName = strings.TrimSpace(fields[3])
+	}
+
+	blocks, ok := parser.operationTemplates[templateName]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation template %s", templateName)
+	}
+
+	typeName, path := fields[1], fields[2]
+	replacer := strings.NewReplacer(
+		"{type}", typeName,
+		"{name}", typeName[strings.LastIndexByte(typeName, '.')+1:],
+		"{path}", strings.TrimSuffix(path, "/"),
+	)
+
+	operations := make([][]string, 0, len(blocks))
+
+	for _, block := range blocks {
+		var operationLines []string
+		for _, line := range strings.Split(replacer.Replace(block), "\n") {
+			operationLines = append(operationLines, "// "+line)
+		}
+
+		operations = append(operations, append(operationLines, lines...))
+	}
+
+	return operations, nil
+}
+
+// crudLine returns the @crud line of a comment block and the other lines, or an empty line if there is none.
+func crudLine(comments []*ast.Comment) (string, []string) {
+	var (
+		crud  string
+		lines = make([]string, 0, len(comments))
+	)
+
+	for _, comment := range comments {
+		commentLine := strings.TrimSpace(strings.TrimLeft(comment.Text, "/"))
+		if fields := strings.Fields(commentLine); len(fields) > 0 && strings.ToLower(fields[0]) == crudAttr {
+			crud = commentLine
+
+			continue
+		}
+
+		lines = append(lines, comment.Text)
+	}
+
+	return crud, lines
+}
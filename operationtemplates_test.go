@@ -0,0 +1,78 @@
+package swag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_OperationTemplates(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name string
+}
+
+type HTTPError struct {
+	Message string
+}
+
+// @crud     User /users
+// @Tags     users
+// @Security ApiKeyAuth
+// @Failure  500 {object} HTTPError
+func Users(){
+}
+
+// @crud User /admin/users audit
+func AuditUsers(){
+}
+`
+	p := New(SetOperationTemplates(map[string][]string{
+		"audit": {"@Summary Audit {name}s\n@ID audit{name}s\n@Success 200 {array} {type}\n@Router {path}/audit [get]"},
+	}))
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	paths := p.swagger.Paths.Paths
+	require.Len(t, paths, 3)
+
+	list, create := paths["/users"].Get, paths["/users"].Post
+	require.NotNil(t, list)
+	require.NotNil(t, create)
+	assert.Equal(t, "listUsers", list.ID)
+	assert.Equal(t, "#/definitions/api.User", list.Responses.StatusCodeResponses[200].Schema.Items.Schema.Ref.String())
+	assert.Equal(t, "createUser", create.ID)
+	assert.Equal(t, "body", create.Parameters[0].In)
+
+	item := paths["/users/{id}"]
+	require.NotNil(t, item.Get)
+	require.NotNil(t, item.Put)
+	require.NotNil(t, item.Delete)
+	assert.Equal(t, "No Content", item.Delete.Responses.StatusCodeResponses[204].Description)
+
+	// the other lines of the block apply to every operation
+	for _, operation := range []*spec.Operation{list, create, item.Get, item.Put, item.Delete} {
+		assert.Equal(t, []string{"users"}, operation.Tags, operation.ID)
+		assert.Len(t, operation.Security, 1, operation.ID)
+		assert.Equal(t, "#/definitions/api.HTTPError", operation.Responses.StatusCodeResponses[500].Schema.Ref.String(), operation.ID)
+	}
+
+	assert.True(t, strings.HasSuffix(p.Source("/paths/~1users~1{id}/put"), "api/api.go:12"))
+
+	audit := paths["/admin/users/audit"].Get
+	require.NotNil(t, audit)
+	assert.Equal(t, "auditUsers", audit.ID)
+	assert.Empty(t, audit.Tags)
+}
@@ -0,0 +1,163 @@
+package swag
+
+import (
+	"context"
+	"go/ast"
+	"go/build"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PackageCache shares the parsed Go files and the packages found by go list between the parsers of
+// one process, so that builds of several instances, or tests, do not parse the same dependencies again.
+// Files are parsed again when their size or modification time changes, the results of go list are
+// kept for the lifetime of the cache. It is safe for concurrent use.
+type PackageCache struct {
+	mu           sync.Mutex
+	files        map[string]*cachedFile
+	packageNames map[string]string
+	lists        map[string][]*build.Package
+}
+
+type cachedFile struct {
+	size    int64
+	modTime time.Time
+	fileSet *token.FileSet
+	file    *ast.File
+}
+
+var sharedPackages = NewPackageCache()
+
+// NewPackageCache creates an empty PackageCache.
+func NewPackageCache() *PackageCache {
+	return &PackageCache{
+		files:        make(map[string]*cachedFile),
+		packageNames: make(map[string]string),
+		lists:        make(map[string][]*build.Package),
+	}
+}
+
+// SharedPackages returns the PackageCache shared by the whole process.
+func SharedPackages() *PackageCache {
+	return sharedPackages
+}
+
+// SetPackageCache sets the cache of parsed packages shared with other parsers, none by default.
+func SetPackageCache(cache *PackageCache) func(*Parser) {
+	return func(p *Parser) {
+		p.packages.cache = cache
+	}
+}
+
+// parseFile parses the Go file at path, or returns the file parsed before if it did not change since.
+func (cache *PackageCache) parseFile(path string) (*token.FileSet, *ast.File, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cache.mu.Lock()
+	cached, ok := cache.files[absPath]
+	cache.mu.Unlock()
+
+	if ok && cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+		return cached.fileSet, cached.file, nil
+	}
+
+	src, err := readSourceFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileSet := token.NewFileSet()
+
+	file, err := goparser.ParseFile(fileSet, path, src, goparser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cache.mu.Lock()
+	cache.files[absPath] = &cachedFile{size: info.Size(), modTime: info.ModTime(), fileSet: fileSet, file: file}
+	cache.mu.Unlock()
+
+	return fileSet, file, nil
+}
+
+// packageName returns the import path of the package in dir, running go list unless the cache knows it.
+func (cache *PackageCache) packageName(ctx context.Context, dir string) (string, error) {
+	if cache == nil {
+		return getPkgName(ctx, dir)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	cache.mu.Lock()
+	name, ok := cache.packageNames[absDir]
+	cache.mu.Unlock()
+
+	if ok {
+		return name, nil
+	}
+
+	name, err = getPkgName(ctx, dir)
+	if err != nil {
+		return "", err
+	}
+
+	cache.mu.Lock()
+	cache.packageNames[absDir] = name
+	cache.mu.Unlock()
+
+	return name, nil
+}
+
+// listPackages is listPackages, reusing the result of a previous call with the same arguments.
+func (cache *PackageCache) listPackages(ctx context.Context, dirs []string, args ...string) ([]*build.Package, error) {
+	if cache == nil {
+		return listPackages(ctx, dirs, nil, args...)
+	}
+
+	key := make([]string, 0, len(dirs)+len(args))
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		key = append(key, absDir)
+	}
+
+	key = append(key, args...)
+
+	cache.mu.Lock()
+	pkgs, ok := cache.lists[strings.Join(key, "\x00")]
+	cache.mu.Unlock()
+
+	if ok {
+		return pkgs, nil
+	}
+
+	pkgs, err := listPackages(ctx, dirs, nil, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	cache.lists[strings.Join(key, "\x00")] = pkgs
+	cache.mu.Unlock()
+
+	return pkgs, nil
+}
@@ -0,0 +1,88 @@
+package swag
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageCache_SharedBetweenParsers(t *testing.T) {
+	t.Parallel()
+
+	cache := NewPackageCache()
+	searchDir := "testdata/simple"
+
+	first := New(SetPackageCache(cache))
+	require.NoError(t, first.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+	second := New(SetPackageCache(cache))
+	require.NoError(t, second.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+	uncached := New()
+	require.NoError(t, uncached.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+	assert.NotEmpty(t, cache.files)
+	assert.Contains(t, cache.packageNames, mustAbs(t, searchDir))
+
+	for file := range second.packages.files {
+		assert.Contains(t, first.packages.files, file)
+	}
+
+	expected, err := json.Marshal(uncached.swagger)
+	require.NoError(t, err)
+
+	actual, err := json.Marshal(second.swagger)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestPackageCache_ParseFile(t *testing.T) {
+	t.Parallel()
+
+	cache := NewPackageCache()
+	path := filepath.Join(t.TempDir(), "api.go")
+	require.NoError(t, os.WriteFile(path, []byte("package api\n"), 0644))
+
+	_, file, err := cache.parseFile(path)
+	require.NoError(t, err)
+
+	_, again, err := cache.parseFile(path)
+	require.NoError(t, err)
+	assert.Same(t, file, again)
+
+	require.NoError(t, os.WriteFile(path, []byte("package changed\n"), 0644))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+	_, changed, err := cache.parseFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", changed.Name.Name)
+
+	_, _, err = cache.parseFile(filepath.Join(t.TempDir(), "missing.go"))
+	assert.Error(t, err)
+}
+
+func TestSharedPackages(t *testing.T) {
+	t.Parallel()
+
+	assert.Same(t, SharedPackages(), SharedPackages())
+
+	var cache *PackageCache
+
+	name, err := cache.packageName(t.Context(), "testdata/simple")
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/swaggo/swag/testdata/simple", name)
+}
+
+func mustAbs(t *testing.T, path string) string {
+	t.Helper()
+
+	absPath, err := filepath.Abs(path)
+	require.NoError(t, err)
+
+	return absPath
+}
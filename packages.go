@@ -13,6 +13,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/loader"
 	"golang.org/x/tools/go/packages"
@@ -25,6 +26,14 @@ type PackagesDefinitions struct {
 	uniqueDefinitions map[string]*TypeSpecDef
 	parseDependency   ParseFlag
 	debug             Debugger
+
+	// cache shares the parsed files with other parsers
+	cache *PackageCache
+
+	// maxGenericInstantiations limits the instantiations of generic types, limitErr is set when it is exceeded
+	maxGenericInstantiations int
+	genericInstantiations    int
+	limitErr                 error
 }
 
 // NewPackagesDefinitions create object PackagesDefinitions.
@@ -57,13 +66,111 @@ func (pkgDefs *PackagesDefinitions) AddPackages(pkgs []*packages.Package) {
 
 // ParseFile parse a source file.
 func (pkgDefs *PackagesDefinitions) ParseFile(packageDir, path string, src any, flag ParseFlag) error {
+	return pkgDefs.parseFiles([]sourceFile{{packageDir: packageDir, path: path, src: src, flag: flag}}, 1)
+}
+
+// sourceFile is a file to parse by parseFiles, src is read from path when nil.
+type sourceFile struct {
+	packageDir string
+	path       string
+	src        any
+	flag       ParseFlag
+}
+
+// parsedFile is the syntax tree of a sourceFile, cached when it comes from the PackageCache.
+type parsedFile struct {
+	fileSet *token.FileSet
+	astFile *ast.File
+	cached  bool
+}
+
+// parseFiles parses files with up to jobs goroutines, then collects them one by one in the order of files,
+// so that the result does not depend on jobs. It returns the error of the first file which failed.
+func (pkgDefs *PackagesDefinitions) parseFiles(files []sourceFile, jobs int) error {
+	parsed := make([]parsedFile, len(files))
+	errs := make([]error, len(files))
+
+	concurrent := jobs > 1 && len(files) > 1
+	if concurrent {
+		indexes := make(chan int)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < min(jobs, len(files)); i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for index := range indexes {
+					parsed[index], errs[index] = pkgDefs.parseSource(files[index].path, files[index].src, true)
+				}
+			}()
+		}
+
+		for index := range files {
+			indexes <- index
+		}
+
+		close(indexes)
+		wg.Wait()
+	}
+
+	for index, file := range files {
+		if !concurrent {
+			parsed[index], errs[index] = pkgDefs.parseSource(file.path, file.src, true)
+		}
+
+		if errs[index] != nil {
+			return errs[index]
+		}
+
+		result := parsed[index]
+
+		// a file collected again, under another package path, needs its own ast.File like without cache
+		if _, collected := pkgDefs.files[result.astFile]; collected && result.cached {
+			var err error
+			if result, err = pkgDefs.parseSource(file.path, file.src, false); err != nil {
+				return err
+			}
+		}
+
+		if err := pkgDefs.CollectAstFile(result.fileSet, file.packageDir, file.path, result.astFile, file.flag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseSource parses a source file without collecting it, it is safe for concurrent use.
+func (pkgDefs *PackagesDefinitions) parseSource(path string, src any, useCache bool) (parsedFile, error) {
+	if src == nil && useCache && pkgDefs.cache != nil {
+		fileSet, astFile, err := pkgDefs.cache.parseFile(path)
+		if err != nil {
+			return parsedFile{}, fmt.Errorf("failed to parse file %s, error:%+v", path, err)
+		}
+
+		return parsedFile{fileSet: fileSet, astFile: astFile, cached: true}, nil
+	}
+
+	if src == nil {
+		content, err := readSourceFile(path)
+		if err != nil {
+			return parsedFile{}, fmt.Errorf("failed to read file %s, error:%+v", path, err)
+		}
+
+		src = content
+	}
+
 	// positions are relative to FileSet
 	fileSet := token.NewFileSet()
 	astFile, err := goparser.ParseFile(fileSet, path, src, goparser.ParseComments)
 	if err != nil {
-		return fmt.Errorf("failed to parse file %s, error:%+v", path, err)
+		return parsedFile{}, fmt.Errorf("failed to parse file %s, error:%+v", path, err)
 	}
-	return pkgDefs.CollectAstFile(fileSet, packageDir, path, astFile, flag)
+
+	return parsedFile{fileSet: fileSet, astFile: astFile}, nil
 }
 
 // CollectAstFile collect ast.file.
@@ -13,11 +13,14 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/KyleBanks/depth"
 	"github.com/go-openapi/spec"
@@ -33,14 +36,32 @@ const (
 	// SnakeCase indicates using SnakeCase strategy for struct field.
 	SnakeCase = "snakecase"
 
+	// DefaultSuccessNone leaves the operations without @Success without success response.
+	DefaultSuccessNone = "200:none"
+
+	// DefaultSuccessEmpty documents an empty 200 response for the operations without @Success.
+	DefaultSuccessEmpty = "200:empty"
+
+	// DefaultSuccessError makes the operations without @Success an error, like in strict mode.
+	DefaultSuccessError = "error"
+
+	// SLAExtension is the extension of the document holding the @sla.<name> annotations by name, e.g. uptime.
+	SLAExtension = "x-sla"
+
 	idAttr                  = "@id"
 	acceptAttr              = "@accept"
 	produceAttr             = "@produce"
 	paramAttr               = "@param"
+	requestBodyAttr         = "@requestbody"
 	successAttr             = "@success"
 	failureAttr             = "@failure"
 	responseAttr            = "@response"
 	headerAttr              = "@header"
+	exampleAttr             = "@example"
+	successExampleAttr      = "@successexample"
+	requestExampleAttr      = "@requestexample"
+	callbackAttr            = "@callback"
+	serverAttr              = "@server"
 	tagsAttr                = "@tags"
 	routerAttr              = "@router"
 	deprecatedRouterAttr    = "@deprecatedrouter"
@@ -53,11 +74,15 @@ const (
 	conEmailAttr            = "@contact.email"
 	licNameAttr             = "@license.name"
 	licURLAttr              = "@license.url"
+	licIdentifierAttr       = "@license.identifier"
 	versionAttr             = "@version"
 	descriptionAttr         = "@description"
 	descriptionMarkdownAttr = "@description.markdown"
+	tagOverviewMarkdownAttr = "@tag.overview.markdown"
+	slaAttrPrefix           = "@sla."
 	secBasicAttr            = "@securitydefinitions.basic"
 	secAPIKeyAttr           = "@securitydefinitions.apikey"
+	secBearerAttr           = "@securitydefinitions.bearer"
 	secApplicationAttr      = "@securitydefinitions.oauth2.application"
 	secImplicitAttr         = "@securitydefinitions.oauth2.implicit"
 	secPasswordAttr         = "@securitydefinitions.oauth2.password"
@@ -68,6 +93,13 @@ const (
 	xCodeSamplesAttr        = "@x-codesamples"
 	scopeAttrPrefix         = "@scope."
 	stateAttr               = "@state"
+	crudAttr                = "@crud"
+	defaultResponseAttr     = "@defaultresponse"
+	noDefaultResponsesAttr  = "@nodefaultresponses"
+	mountAttr               = "@mount"
+	collectionFormatAttr    = "@query.collection.format"
+
+	wwwAuthenticateHeader = "WWW-Authenticate"
 )
 
 // ParseFlag determine what to parse
@@ -96,6 +128,9 @@ var (
 
 	// ErrSkippedField .swaggo specifies field should be skipped.
 	ErrSkippedField = errors.New("field is skipped by global overrides")
+
+	// ErrLimitExceeded a resource limit set by SetLimits is exceeded.
+	ErrLimitExceeded = errors.New("limit exceeded")
 )
 
 var allMethod = map[string]struct{}{
@@ -140,15 +175,37 @@ type Parser struct {
 	// RequiredByDefault set validation required for all fields by default
 	RequiredByDefault bool
 
+	// SecurityAlternatives whether || separates the alternative requirements of @Security, instead of combining
+	// schemes like && as old versions do
+	SecurityAlternatives bool
+
+	// TimeFormat how swag documents time.Time: a string format like TimeFormatDateTime or TimeFormatDate, or the
+	// integer timestamps TimeFormatUnix and TimeFormatUnixMilli, a string without format when empty
+	TimeFormat string
+
+	// RawMessageType how swag documents json.RawMessage: RawMessageObject, a free-form object, when empty, or
+	// RawMessageString
+	RawMessageType string
+
+	// RequiredInResponses whether swag should make the fields without omitempty or omitzero required in the
+	// definitions only responses refer to, since they are always in the JSON of the response
+	RequiredInResponses bool
+
 	// structStack stores full names of the structures that were already parsed or are being parsed now
 	structStack []*TypeSpecDef
 
 	// markdownFileDir holds the path to the folder, where markdown files are stored
 	markdownFileDir string
 
+	// markdownBaseURL is prepended to relative links and images of markdown descriptions
+	markdownBaseURL string
+
 	// codeExampleFilesDir holds path to the folder, where code example files are stored
 	codeExampleFilesDir string
 
+	// extensionFilesDir holds path to the folder, where files loaded by @x-name file(name.json) are stored
+	extensionFilesDir string
+
 	// collectionFormatInQuery set the default collectionFormat otherwise then 'csv' for array in query params
 	collectionFormatInQuery string
 
@@ -183,14 +240,139 @@ type Parser struct {
 	// tags to filter the APIs after
 	tags map[string]struct{}
 
+	// onlyPackages restricts the parsed operations to the files in these directories
+	onlyPackages []packagePattern
+
+	// outsideRoutes the routes, e.g. GET /users, of the operations declared outside the onlyPackages
+	outsideRoutes map[string]struct{}
+
+	// onlyRoutes restricts the parsed operations to the routes matching these patterns
+	onlyRoutes []string
+
 	// HostState is the state of the host
 	HostState string
 
 	// ParseFuncBody whether swag should parse api info inside of funcs
 	ParseFuncBody bool
 
+	// IncludeGenerated whether swag should parse the operations of generated files, whose types are always parsed
+	IncludeGenerated bool
+
 	// UseStructName Dont use those ugly full-path names when using dependency flag
 	UseStructName bool
+
+	// InferInfoFromModule whether swag should fill missing license, contact and version from module metadata
+	InferInfoFromModule bool
+
+	// AuthResponses whether swag should document 401 and 403 responses for every secured operation
+	AuthResponses bool
+
+	// NullablePointers whether swag should mark the pointer fields of structs as nullable with x-nullable
+	NullablePointers bool
+
+	// InheritMimeTypes whether swag should copy the @Accept and @Produce of general API info into the operations
+	// which declare none, for the tools which ignore the global consumes and produces
+	InheritMimeTypes bool
+
+	// PruneUnused whether swag should remove the security definitions which are never required and the tags
+	// without operations
+	PruneUnused bool
+
+	// PruneUnusedDefinitions whether swag should remove the definitions which no path refers to
+	PruneUnusedDefinitions bool
+
+	// EnumsAsRefs whether swag should turn the inline enums, e.g. of the enums struct tag, into definitions
+	EnumsAsRefs bool
+
+	// StringerEnums how swag documents the integer enums whose type has a String method, since they are marshaled
+	// as their string forms: StringerEnumsVarNames or StringerEnumsString, as integers when empty
+	StringerEnums string
+
+	// propertyTags the struct tags naming the properties of the packages with an import path prefix,
+	// the longest prefix wins and json is used for the other packages
+	propertyTags map[string]string
+
+	// propertyTag the struct tag naming the properties of the struct being parsed
+	propertyTag string
+
+	// fileRefs the schemas of spec files referred to by the annotations, e.g. ./common.yaml#/components/schemas/Error
+	fileRefs *fileRefs
+
+	// modelFilters decide how the fields of structs are documented
+	modelFilters []ModelFilter
+
+	// OmitEmptyExtension whether swag should emit x-omitempty on the properties of structs, true when the
+	// JSON tag of the field has omitempty or omitzero
+	OmitEmptyExtension bool
+
+	// variables replace {{.Name}} placeholders in general API info at generation time
+	variables map[string]string
+
+	// operationSources maps the JSON pointer of each operation to the file and line of its annotations
+	operationSources map[string]string
+
+	// operationDefinitions the operations which //swag:route comments refer to, by ID
+	operationDefinitions map[string]*spec.Operation
+
+	// routeDirectives the //swag:route comments of the parsed files
+	routeDirectives []routeDirectiveRef
+
+	// defaults the annotations of the //swag:defaults comments, by package path
+	defaults map[string][]string
+
+	// operationTemplates the annotation blocks of the operations expanded by @crud, by template name
+	operationTemplates map[string][]string
+
+	// defaultSuccess the policy of operations without success response, DefaultSuccessNone by default
+	defaultSuccess string
+
+	// defaultResponses the @defaultResponse annotations of the general API info, added to every operation
+	defaultResponses []string
+
+	// generalInfoFile the main API file, which resolves the types of the default responses
+	generalInfoFile *ast.File
+
+	// defaultResponsesJSON the parsed default responses, copied into each operation
+	defaultResponsesJSON []byte
+
+	// mounts the external documents of the @mount annotations, mounted by gen
+	mounts []Mount
+
+	// tagDeclarations the tags of the document in their order, merged with the @tag annotations
+	tagDeclarations []spec.Tag
+
+	// codeSamples the tools of the code samples synthesized for the operations, see SetCodeSamples
+	codeSamples []string
+
+	// documentExtensions and infoExtensions replace the extensions of the general API info annotations
+	documentExtensions map[string]any
+	infoExtensions     map[string]any
+
+	// limits bound the resources used to parse pathological inputs
+	limits Limits
+
+	// definitionChain the names of the definitions being parsed, outermost first
+	definitionChain []string
+
+	// jobs the number of files parsed concurrently
+	jobs int
+
+	// parseOnce and parseOnceErr make ParseOnce parse a single time
+	parseOnce    sync.Once
+	parseOnceErr error
+}
+
+// Limits bound the resources used to parse pathological inputs, like deeply nested or enormous generic types,
+// so that they fail with a clear error instead of exhausting memory. A zero value disables a limit.
+type Limits struct {
+	// MaxSchemaDepth the maximum number of definitions being parsed inside each other
+	MaxSchemaDepth int
+
+	// MaxDefinitions the maximum number of definitions of the document
+	MaxDefinitions int
+
+	// MaxGenericInstantiations the maximum number of distinct instantiations of generic types
+	MaxGenericInstantiations int
 }
 
 // FieldParserFactory create FieldParser.
@@ -249,6 +431,12 @@ func New(options ...func(*Parser)) *Parser {
 		tags:               make(map[string]struct{}),
 		fieldParserFactory: newTagBaseFieldParser,
 		Overrides:          make(map[string]string),
+		operationTemplates: make(map[string][]string, len(defaultOperationTemplates)),
+		jobs:               1,
+	}
+
+	for name, blocks := range defaultOperationTemplates {
+		parser.operationTemplates[name] = blocks
 	}
 
 	for _, option := range options {
@@ -270,6 +458,28 @@ func SetParseDependency(parseDependency int) func(*Parser) {
 	}
 }
 
+// SetLimits sets the resource limits of the parser.
+func SetLimits(limits Limits) func(*Parser) {
+	return func(p *Parser) {
+		p.limits = limits
+		if p.packages != nil {
+			p.packages.maxGenericInstantiations = limits.MaxGenericInstantiations
+		}
+	}
+}
+
+// SetJobs sets the number of Go files parsed concurrently, the number of CPUs when jobs is not positive.
+// The result is the same for every number of jobs.
+func SetJobs(jobs int) func(*Parser) {
+	return func(p *Parser) {
+		if jobs < 1 {
+			jobs = runtime.GOMAXPROCS(0)
+		}
+
+		p.jobs = jobs
+	}
+}
+
 // SetUseStructName sets whether to strip the full-path definition name.
 func SetUseStructName(useStructName bool) func(*Parser) {
 	return func(p *Parser) {
@@ -284,6 +494,13 @@ func SetMarkdownFileDirectory(directoryPath string) func(*Parser) {
 	}
 }
 
+// SetMarkdownBaseURL sets the base URL relative links in markdown files are rewritten against.
+func SetMarkdownBaseURL(baseURL string) func(*Parser) {
+	return func(p *Parser) {
+		p.markdownBaseURL = baseURL
+	}
+}
+
 // SetCodeExamplesDirectory sets the directory to search for code example files.
 func SetCodeExamplesDirectory(directoryPath string) func(*Parser) {
 	return func(p *Parser) {
@@ -291,6 +508,13 @@ func SetCodeExamplesDirectory(directoryPath string) func(*Parser) {
 	}
 }
 
+// SetExtensionFilesDirectory sets the directory to search for extension value files.
+func SetExtensionFilesDirectory(directoryPath string) func(*Parser) {
+	return func(p *Parser) {
+		p.extensionFilesDir = directoryPath
+	}
+}
+
 // SetExcludedDirsAndFiles sets directories and files to be excluded when searching.
 func SetExcludedDirsAndFiles(excludes string) func(*Parser) {
 	return func(p *Parser) {
@@ -318,13 +542,88 @@ func SetPackagePrefix(packagePrefix string) func(*Parser) {
 	}
 }
 
+// SetPropertyTags sets the struct tags naming the properties instead of json, comma separated, like bson for
+// all packages or github.com/acme/store/models=bson for the packages with that import path prefix.
+func SetPropertyTags(propertyTags string) func(*Parser) {
+	return func(p *Parser) {
+		for _, entry := range strings.Split(propertyTags, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+
+			if p.propertyTags == nil {
+				p.propertyTags = make(map[string]string)
+			}
+
+			prefix, tag, found := strings.Cut(entry, "=")
+			if !found {
+				prefix, tag = "", entry
+			}
+
+			p.propertyTags[strings.TrimSuffix(strings.TrimSpace(prefix), "/...")] = strings.TrimSpace(tag)
+		}
+	}
+}
+
+// propertyTagOf returns the struct tag naming the properties of the structs declared in file.
+func (parser *Parser) propertyTagOf(file *ast.File) string {
+	var pkgPath string
+	if info, ok := parser.packages.files[file]; ok {
+		pkgPath = info.PackagePath
+	}
+
+	tag, longest := jsonTag, -1
+
+	for prefix, prefixTag := range parser.propertyTags {
+		if len(prefix) > longest && (prefix == "" || pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")) {
+			tag, longest = prefixTag, len(prefix)
+		}
+	}
+
+	return tag
+}
+
 // SetTags sets the tags to be included
 func SetTags(include string) func(*Parser) {
 	return func(p *Parser) {
-		for _, f := range strings.Split(include, ",") {
-			f = strings.TrimSpace(f)
-			if f != "" {
-				p.tags[f] = struct{}{}
+		for tag := range parseTagFilter(include) {
+			p.tags[tag] = struct{}{}
+		}
+	}
+}
+
+// SetOnlyPackages parses only the operations declared in the given directories, comma separated.
+// A directory ending with /... includes its subdirectories.
+func SetOnlyPackages(dirs string) func(*Parser) {
+	return func(p *Parser) {
+		for _, dir := range strings.Split(dirs, ",") {
+			dir = strings.TrimSpace(dir)
+			if dir == "" {
+				continue
+			}
+
+			pattern := packagePattern{recursive: strings.HasSuffix(dir, "/...")}
+
+			absDir, err := filepath.Abs(strings.TrimSuffix(dir, "/..."))
+			if err != nil {
+				absDir = dir
+			}
+
+			pattern.dir = absDir
+			p.onlyPackages = append(p.onlyPackages, pattern)
+		}
+	}
+}
+
+// SetOnlyRoutes parses only the operations of the routes matching the given patterns, comma separated.
+// Patterns are matched with path.Match, except that a trailing /* matches the rest of the path.
+func SetOnlyRoutes(patterns string) func(*Parser) {
+	return func(p *Parser) {
+		for _, pattern := range strings.Split(patterns, ",") {
+			pattern = strings.TrimSpace(pattern)
+			if pattern != "" {
+				p.onlyRoutes = append(p.onlyRoutes, pattern)
 			}
 		}
 	}
@@ -376,6 +675,36 @@ func SetCollectionFormat(collectionFormat string) func(*Parser) {
 	}
 }
 
+// SetDefaultSuccess sets the policy of operations without @Success: DefaultSuccessNone, DefaultSuccessEmpty
+// or DefaultSuccessError.
+func SetDefaultSuccess(policy string) func(*Parser) {
+	return func(p *Parser) {
+		p.defaultSuccess = policy
+	}
+}
+
+// SetDocumentExtensions sets extensions of the document and of its info, like values too deep for annotations
+// which are kept in a file. They replace the extensions of the general API info annotations with the same name.
+func SetDocumentExtensions(document, info map[string]any) func(*Parser) {
+	return func(p *Parser) {
+		p.documentExtensions = document
+		p.infoExtensions = info
+	}
+}
+
+// SetVariables sets the values of {{.Name}} placeholders used in general API info, e.g. @version {{.BuildVersion}}.
+func SetVariables(variables map[string]string) func(*Parser) {
+	return func(p *Parser) {
+		if p.variables == nil {
+			p.variables = make(map[string]string, len(variables))
+		}
+
+		for k, v := range variables {
+			p.variables[k] = v
+		}
+	}
+}
+
 // ParseUsingGoList sets whether swag use go list to parse dependency
 func ParseUsingGoList(enabled bool) func(parser *Parser) {
 	return func(p *Parser) {
@@ -385,7 +714,13 @@ func ParseUsingGoList(enabled bool) func(parser *Parser) {
 
 // ParseAPI parses general api info for given searchDir and mainAPIFile.
 func (parser *Parser) ParseAPI(searchDir string, mainAPIFile string, parseDepth int) error {
-	return parser.ParseAPIMultiSearchDir([]string{searchDir}, mainAPIFile, parseDepth)
+	return parser.ParseAPIMultiSearchDirContext(context.Background(), []string{searchDir}, mainAPIFile, parseDepth)
+}
+
+// ParseAPIContext is like ParseAPI but stops with the error of ctx when it is cancelled,
+// killing the go list commands which are running.
+func (parser *Parser) ParseAPIContext(ctx context.Context, searchDir string, mainAPIFile string, parseDepth int) error {
+	return parser.ParseAPIMultiSearchDirContext(ctx, []string{searchDir}, mainAPIFile, parseDepth)
 }
 
 // skipPackageByPrefix returns true the given pkgpath does not match
@@ -404,19 +739,28 @@ func (parser *Parser) skipPackageByPrefix(pkgpath string) bool {
 
 // ParseAPIMultiSearchDir is like ParseAPI but for multiple search dirs.
 func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile string, parseDepth int) error {
+	return parser.ParseAPIMultiSearchDirContext(context.Background(), searchDirs, mainAPIFile, parseDepth)
+}
+
+// ParseAPIMultiSearchDirContext is like ParseAPIMultiSearchDir but stops with the error of ctx when it is cancelled.
+func (parser *Parser) ParseAPIMultiSearchDirContext(ctx context.Context, searchDirs []string, mainAPIFile string, parseDepth int) error {
 	absMainAPIFilePath, err := filepath.Abs(filepath.Join(searchDirs[0], mainAPIFile))
 	if err != nil {
 		return err
 	}
 	if parser.ParseGoPackages {
-		if err := parser.loadPackagesAndDeps(searchDirs, absMainAPIFilePath); err != nil {
+		if err := parser.loadPackagesAndDeps(ctx, searchDirs, absMainAPIFilePath); err != nil {
 			return err
 		}
 	} else {
 		for _, searchDir := range searchDirs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
 			parser.debug.Printf("Generate general API Info, search dir:%s", searchDir)
 
-			packageDir, err := getPkgName(searchDir)
+			packageDir, err := parser.packages.cache.packageName(ctx, searchDir)
 			if err != nil {
 				parser.debug.Printf("warning: failed to get package name in dir: %s, error: %s", searchDir, err.Error())
 			}
@@ -432,17 +776,22 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 	if parser.ParseDependency > 0 && !parser.ParseGoPackages {
 		allDir := append([]string{filepath.Dir(absMainAPIFilePath)}, searchDirs...)
 		if parser.parseGoList {
-			pkgs, err := listPackages(context.Background(), allDir, nil, "-deps")
+			pkgs, err := parser.packages.cache.listPackages(ctx, allDir, "-deps")
 			if err != nil {
 				return err
 			}
 
-			length := len(pkgs)
-			for i := 0; i < length; i++ {
-				err := parser.getAllGoFileInfoFromDepsByList(pkgs[i], parser.ParseDependency)
-				if err != nil {
-					return err
-				}
+			var files []sourceFile
+			for i := range pkgs {
+				files = append(files, parser.goFilesOfPackage(pkgs[i], parser.ParseDependency)...)
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if err := parser.parseFiles(files); err != nil {
+				return err
 			}
 		} else {
 			dirImported := make(map[string]struct{}) // for deduplication
@@ -453,11 +802,15 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 				}
 			}
 			for index, dir := range allDir {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
 				var t depth.Tree
 				t.ResolveInternal = true
 				t.MaxDepth = parseDepth
 
-				pkgName, err := getPkgName(dir)
+				pkgName, err := parser.packages.cache.packageName(ctx, dir)
 				if err != nil {
 					if index == 0 { // ignore error when load search dir
 						return err
@@ -484,21 +837,80 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 		return err
 	}
 
+	if parser.InferInfoFromModule {
+		parser.inferInfoFromModule(filepath.Dir(absMainAPIFilePath))
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	parser.parsedSchemas, err = parser.packages.ParseTypes()
 	if err != nil {
 		return err
 	}
 
-	err = parser.packages.RangeFiles(parser.ParseRouterAPIInfo)
+	err = parser.packages.RangeFiles(func(fileInfo *AstFileInfo) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		return parser.ParseRouterAPIInfo(fileInfo)
+	})
 	if err != nil {
 		return err
 	}
 
+	if err := parser.resolveRouteDirectives(); err != nil {
+		return err
+	}
+
+	parser.mergeTagDeclarations()
+
+	if parser.packages.limitErr != nil {
+		return parser.packages.limitErr
+	}
+
+	if parser.InheritMimeTypes {
+		parser.inheritMimeTypes()
+	}
+
+	if parser.AuthResponses {
+		parser.addAuthResponses()
+	}
+
+	if parser.RequiredInResponses {
+		if err := parser.requireResponseProperties(); err != nil {
+			return err
+		}
+	}
+
+	if parser.PruneUnused {
+		parser.pruneUnused()
+	}
+
+	if parser.PruneUnusedDefinitions {
+		if err := parser.pruneUnusedDefinitions(); err != nil {
+			return err
+		}
+	}
+
+	parser.addCodeSamples()
+
+	err = parser.checkValueCoherence()
+	if err != nil {
+		return err
+	}
+
+	if parser.EnumsAsRefs {
+		parser.liftEnums()
+	}
+
 	return parser.checkOperationIDUniqueness()
 }
 
-func getPkgName(searchDir string) (string, error) {
-	cmd := exec.Command("go", "list", "-f={{.ImportPath}}")
+func getPkgName(ctx context.Context, searchDir string) (string, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-f={{.ImportPath}}")
 	cmd.Dir = searchDir
 
 	var stdout, stderr strings.Builder
@@ -525,15 +937,23 @@ func getPkgName(searchDir string) (string, error) {
 
 // ParseGeneralAPIInfo parses general api info for given mainAPIFile path.
 func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
-	fileTree, err := goparser.ParseFile(token.NewFileSet(), mainAPIFile, nil, goparser.ParseComments)
+	src, err := readSourceFile(mainAPIFile)
+	if err != nil {
+		return fmt.Errorf("cannot parse source files %s: %s", mainAPIFile, err)
+	}
+
+	fileSet := token.NewFileSet()
+
+	fileTree, err := goparser.ParseFile(fileSet, mainAPIFile, src, goparser.ParseComments)
 	if err != nil {
 		return fmt.Errorf("cannot parse source files %s: %s", mainAPIFile, err)
 	}
 
 	parser.swagger.Swagger = "2.0"
+	parser.generalInfoFile = fileTree
 
 	for _, comment := range fileTree.Comments {
-		comments := strings.Split(comment.Text(), "\n")
+		comments := commentGroupLines(comment)
 		if !isGeneralAPIComment(comments) {
 			continue
 		}
@@ -544,10 +964,32 @@ func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
 		}
 	}
 
+	if err := parser.validateGeneralAPIInfo(fileSet, mainAPIFile, fileTree); err != nil {
+		return err
+	}
+
+	// the case of the names is kept, unlike Extensions.Add
+	for name, value := range parser.documentExtensions {
+		if parser.swagger.Extensions == nil {
+			parser.swagger.Extensions = make(map[string]any)
+		}
+
+		parser.swagger.Extensions[name] = value
+	}
+
+	for name, value := range parser.infoExtensions {
+		if parser.swagger.Info.Extensions == nil {
+			parser.swagger.Info.Extensions = make(map[string]any)
+		}
+
+		parser.swagger.Info.Extensions[name] = value
+	}
+
 	return nil
 }
 
 func parseGeneralAPIInfo(parser *Parser, comments []string) error {
+	comments = JoinContinuedLines(comments)
 	previousAttribute := ""
 	var tag *spec.Tag
 	// parsing classic meta data model
@@ -562,12 +1004,43 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 		attribute := fields[0]
 		var value string
 		if len(fields) > 1 {
-			value = fields[1]
+			value = parser.expandVariables(fields[1])
+		}
+
+		if base, _, _ := SplitLocalizedAttribute(attribute); base == descriptionAttr {
+			if delimiter, ok := heredocDelimiter(value); ok {
+				block, err := readHeredoc(comments, &line, delimiter)
+				if err != nil {
+					return err
+				}
+
+				value = parser.expandVariables(block)
+			}
+		}
+
+		if base, lang, ok := SplitLocalizedAttribute(attribute); ok && base == descriptionAttr {
+			parser.swagger.Info.Extensions = AppendLocalizedDescription(parser.swagger.Info.Extensions, lang, value)
+			previousAttribute = attribute
+
+			continue
 		}
 
 		switch attr := strings.ToLower(attribute); attr {
-		case versionAttr, titleAttr, tosAttr, licNameAttr, licURLAttr, conNameAttr, conURLAttr, conEmailAttr:
+		case versionAttr, titleAttr, tosAttr, licURLAttr, conNameAttr, conURLAttr, conEmailAttr:
+			setSwaggerInfo(parser.swagger, attr, value)
+		case licNameAttr:
+			if !isSPDXExpression(value) {
+				parser.debug.Printf("warning: %s %s is not an SPDX license identifier", attribute, value)
+			}
+
 			setSwaggerInfo(parser.swagger, attr, value)
+		case licIdentifierAttr:
+			if !isSPDXExpression(value) {
+				parser.debug.Printf("warning: %s %s is not an SPDX license expression", attribute, value)
+			}
+
+			parser.swagger.Info.License = initIfEmpty(parser.swagger.Info.License)
+			parser.swagger.Info.License.AddExtension(LicenseIdentifierExtension, value)
 		case descriptionAttr:
 			if previousAttribute == attribute {
 				parser.swagger.Info.Description = AppendDescription(parser.swagger.Info.Description, value)
@@ -576,7 +1049,7 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 
 			setSwaggerInfo(parser.swagger, attr, value)
 		case descriptionMarkdownAttr:
-			commentInfo, err := getMarkdownForTag("api", parser.markdownFileDir)
+			commentInfo, err := parser.getMarkdown("api")
 			if err != nil {
 				return err
 			}
@@ -625,13 +1098,26 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 			}
 		case "@tag.description.markdown":
 			if tag != nil {
-				commentInfo, err := getMarkdownForTag(tag.TagProps.Name, parser.markdownFileDir)
+				commentInfo, err := parser.getTagMarkdown(tag.TagProps.Name, value)
 				if err != nil {
 					return err
 				}
 
 				tag.TagProps.Description = string(commentInfo)
 			}
+		case tagOverviewMarkdownAttr:
+			if tag != nil {
+				commentInfo, err := parser.getTagMarkdown(tag.TagProps.Name, value)
+				if err != nil {
+					return err
+				}
+
+				if tag.Extensions == nil {
+					tag.Extensions = make(map[string]any)
+				}
+
+				tag.Extensions[TagOverviewExtension] = renderMarkdown(commentInfo)
+			}
 		case "@tag.docs.url":
 			if tag != nil {
 				tag.TagProps.ExternalDocs = &spec.ExternalDocumentation{
@@ -646,19 +1132,38 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 
 				tag.TagProps.ExternalDocs.Description = value
 			}
-		case secBasicAttr, secAPIKeyAttr, secApplicationAttr, secImplicitAttr, secPasswordAttr, secAccessCodeAttr:
+		case secBasicAttr, secAPIKeyAttr, secBearerAttr, secApplicationAttr, secImplicitAttr, secPasswordAttr, secAccessCodeAttr:
 			scheme, err := parseSecAttributes(attribute, comments, &line)
 			if err != nil {
 				return err
 			}
 
+			if scheme.In == "cookie" {
+				parser.downgradeCookieAPIKey(value, scheme)
+			}
+
 			parser.swagger.SecurityDefinitions[value] = scheme
 
 		case securityAttr:
-			parser.swagger.Security = append(parser.swagger.Security, parseSecurity(value))
+			parser.swagger.Security = append(parser.swagger.Security, parser.parseSecurity(value)...)
+
+		case defaultResponseAttr:
+			parser.defaultResponses = append(parser.defaultResponses, value)
+
+		case mountAttr:
+			mount, err := parseMountComment(value)
+			if err != nil {
+				return err
+			}
+
+			parser.mounts = append(parser.mounts, mount)
 
-		case "@query.collection.format":
-			parser.collectionFormatInQuery = TransToValidCollectionFormat(value)
+		case collectionFormatAttr:
+			if err := checkCollectionFormat(value, ""); err != nil {
+				return fmt.Errorf("%s %s: %w", attribute, value, err)
+			}
+
+			parser.collectionFormatInQuery = value
 
 		case extDocsDescAttr, extDocsURLAttr:
 			if parser.swagger.ExternalDocs == nil {
@@ -672,7 +1177,23 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 			}
 
 		default:
-			if strings.HasPrefix(attribute, "@x-") {
+			if strings.HasPrefix(strings.ToLower(attribute), slaAttrPrefix) {
+				if len(value) == 0 {
+					return fmt.Errorf("annotation %s need a value", attribute)
+				}
+
+				if parser.swagger.Extensions == nil {
+					parser.swagger.Extensions = make(map[string]any)
+				}
+
+				sla, _ := parser.swagger.Extensions[SLAExtension].(map[string]string)
+				if sla == nil {
+					sla = make(map[string]string)
+					parser.swagger.Extensions[SLAExtension] = sla
+				}
+
+				sla[attribute[len(slaAttrPrefix):]] = value
+			} else if strings.HasPrefix(attribute, "@x-") {
 				extensionName := attribute[1:]
 
 				extExistsInSecurityDef := false
@@ -734,6 +1255,20 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 	return nil
 }
 
+// expandVariables replaces {{.Name}} placeholders of known variables, unknown ones are kept
+// so that they can be resolved at runtime.
+func (parser *Parser) expandVariables(value string) string {
+	if len(parser.variables) == 0 || !strings.Contains(value, "{{") {
+		return value
+	}
+
+	for name, variable := range parser.variables {
+		value = strings.ReplaceAll(value, "{{."+name+"}}", variable)
+	}
+
+	return value
+}
+
 func setSwaggerInfo(swagger *spec.Swagger, attribute, value string) {
 	switch attribute {
 	case versionAttr:
@@ -759,6 +1294,20 @@ func setSwaggerInfo(swagger *spec.Swagger, attribute, value string) {
 	}
 }
 
+// claimSchema returns the schema of a JWT claim of claimType, e.g. string or []string. The items of an array claim
+// are strings when their type is unknown.
+func claimSchema(claimType string) map[string]any {
+	if itemType, ok := strings.CutPrefix(claimType, "[]"); ok {
+		return map[string]any{"type": ARRAY, "items": claimSchema(itemType)}
+	}
+
+	if claimType == ARRAY {
+		return map[string]any{"type": ARRAY, "items": map[string]any{"type": STRING}}
+	}
+
+	return map[string]any{"type": claimType}
+}
+
 func parseSecAttributes(context string, lines []string, index *int) (*spec.SecurityScheme, error) {
 	const (
 		in               = "@in"
@@ -766,6 +1315,8 @@ func parseSecAttributes(context string, lines []string, index *int) (*spec.Secur
 		descriptionAttr  = "@description"
 		tokenURL         = "@tokenurl"
 		authorizationURL = "@authorizationurl"
+		bearerFormat     = "@bearerformat"
+		claims           = "@claims"
 	)
 
 	var search []string
@@ -789,6 +1340,7 @@ func parseSecAttributes(context string, lines []string, index *int) (*spec.Secur
 
 	attrMap, scopes := make(map[string]string), make(map[string]string)
 	extensions, description := make(map[string]any), ""
+	jwtClaims, format := make(map[string]any), ""
 
 loopline:
 	for ; *index < len(lines); *index++ {
@@ -824,6 +1376,29 @@ loopline:
 			continue
 		}
 
+		if attribute == secBearerAttr {
+			switch securityAttr {
+			case bearerFormat:
+				format = value
+
+				continue
+			case claims:
+				claim := FieldsByAnySpace(value, 3)
+				if len(claim) < 2 {
+					return nil, fmt.Errorf("%s %s needs a name and a type", context, claims)
+				}
+
+				property := claimSchema(claim[1])
+				if len(claim) > 2 {
+					property["description"] = strings.Trim(claim[2], "\"")
+				}
+
+				jwtClaims[claim[0]] = property
+
+				continue
+			}
+		}
+
 		// Not mandatory field
 		if securityAttr == descriptionAttr {
 			if description != "" {
@@ -849,6 +1424,12 @@ loopline:
 
 	switch attribute {
 	case secAPIKeyAttr:
+		switch attrMap[in] {
+		case "header", "query", "cookie":
+		default:
+			return nil, fmt.Errorf("%s @in must be one of header, query or cookie, got %q", context, attrMap[in])
+		}
+
 		scheme = spec.APIKeyAuth(attrMap[name], attrMap[in])
 	case secApplicationAttr:
 		scheme = spec.OAuth2Application(attrMap[tokenURL])
@@ -858,6 +1439,18 @@ loopline:
 		scheme = spec.OAuth2Password(attrMap[tokenURL])
 	case secAccessCodeAttr:
 		scheme = spec.OAuth2AccessToken(attrMap[authorizationURL], attrMap[tokenURL])
+	case secBearerAttr:
+		// Swagger 2.0 has no bearer scheme, the token is an api key in the Authorization header
+		scheme = spec.APIKeyAuth("Authorization", "header")
+		scheme.AddExtension(BearerSchemeExtension, "bearer")
+
+		if format != "" {
+			scheme.AddExtension(BearerFormatExtension, format)
+		}
+
+		if len(jwtClaims) > 0 {
+			scheme.AddExtension(JWTClaimsExtension, jwtClaims)
+		}
 	}
 
 	scheme.Description = description
@@ -873,7 +1466,29 @@ loopline:
 	return scheme, nil
 }
 
-func parseSecurity(commentLine string) map[string][]string {
+// parseSecurity parses security requirements, where && separates the schemes of one requirement. || separates
+// alternative requirements with SecurityAlternatives, e.g. ApiKeyAuth && OAuth2[read] || BasicAuth, and combines
+// schemes like && otherwise, for compatibility with old versions.
+func (parser *Parser) parseSecurity(commentLine string) []map[string][]string {
+	if !parser.SecurityAlternatives {
+		if securityOrSepPattern.MatchString(commentLine) {
+			parser.debug.Printf("warning: || in @Security %s combines schemes like &&, which is deprecated: "+
+				"use && instead, || will separate alternative requirements as with --securityAlternatives", commentLine)
+		}
+
+		return []map[string][]string{parseSecurityRequirement(commentLine)}
+	}
+
+	var requirements []map[string][]string
+
+	for _, requirement := range securityOrSepPattern.Split(commentLine, -1) {
+		requirements = append(requirements, parseSecurityRequirement(requirement))
+	}
+
+	return requirements
+}
+
+func parseSecurityRequirement(commentLine string) map[string][]string {
 	securityMap := make(map[string][]string)
 
 	for _, securityOption := range securityPairSepPattern.Split(commentLine, -1) {
@@ -919,6 +1534,39 @@ func (parser *Parser) ParseProduceComment(commentLine string) error {
 	return parseMimeTypeList(commentLine, &parser.swagger.Produces, "%v produce type can't be accepted")
 }
 
+// commentGroupLines returns the lines of a comment group, unlike CommentGroup.Text
+// blank lines and indentation after the comment markers are kept.
+func commentGroupLines(group *ast.CommentGroup) []string {
+	var lines []string
+
+	for _, comment := range group.List {
+		if strings.HasPrefix(comment.Text, "//") {
+			lines = append(lines, trimCommentMarker(comment.Text))
+
+			continue
+		}
+
+		// a /* */ comment
+		lines = append(lines, strings.Split((&ast.CommentGroup{List: []*ast.Comment{comment}}).Text(), "\n")...)
+	}
+
+	return lines
+}
+
+// readHeredoc returns the lines following index up to the delimiter, index is moved to the delimiter.
+func readHeredoc(lines []string, index *int, delimiter string) (string, error) {
+	for end := *index + 1; end < len(lines); end++ {
+		if strings.TrimSpace(lines[end]) == delimiter {
+			block := strings.Join(lines[*index+1:end], "\n")
+			*index = end
+
+			return block, nil
+		}
+	}
+
+	return "", fmt.Errorf("description block is not terminated by %s", delimiter)
+}
+
 func isGeneralAPIComment(comments []string) bool {
 	for _, commentLine := range comments {
 		commentLine = strings.TrimSpace(commentLine)
@@ -928,7 +1576,7 @@ func isGeneralAPIComment(comments []string) bool {
 		attribute := strings.ToLower(FieldsByAnySpace(commentLine, 2)[0])
 		switch attribute {
 		// The @summary, @router, @success, @failure annotation belongs to Operation
-		case summaryAttr, routerAttr, successAttr, failureAttr, responseAttr:
+		case summaryAttr, routerAttr, successAttr, failureAttr, responseAttr, crudAttr:
 			return false
 		}
 	}
@@ -944,6 +1592,27 @@ func getMarkdownForTag(tagName string, dirPath string) ([]byte, error) {
 		return make([]byte, 0), nil
 	}
 
+	expectedFileName := tagName
+	if !strings.HasSuffix(tagName, ".md") {
+		expectedFileName = tagName + ".md"
+	}
+
+	// a file in a folder of the markdown directory, e.g. users/create.md
+	if strings.Contains(filepath.ToSlash(expectedFileName), "/") {
+		if !filepath.IsLocal(expectedFileName) {
+			return nil, fmt.Errorf("markdown file %s is not inside the markdown directory", tagName)
+		}
+
+		fullPath := filepath.Join(dirPath, expectedFileName)
+
+		commentInfo, err := os.ReadFile(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read markdown file %s error: %s ", fullPath, err)
+		}
+
+		return commentInfo, nil
+	}
+
 	dirEntries, err := os.ReadDir(dirPath)
 	if err != nil {
 		return nil, err
@@ -956,11 +1625,6 @@ func getMarkdownForTag(tagName string, dirPath string) ([]byte, error) {
 
 		fileName := entry.Name()
 
-		expectedFileName := tagName
-		if !strings.HasSuffix(tagName, ".md") {
-			expectedFileName = tagName + ".md"
-		}
-
 		if fileName == expectedFileName {
 			fullPath := filepath.Join(dirPath, fileName)
 
@@ -1008,51 +1672,44 @@ func getTagsFromComment(comment string) (tags []string) {
 }
 
 func (parser *Parser) matchTag(tag string) bool {
-	if len(parser.tags) == 0 {
-		return true
-	}
+	return matchTagFilter(parser.tags, []string{tag})
+}
 
-	if _, has := parser.tags["!"+tag]; has {
-		return false
+func (parser *Parser) matchTags(comments []*ast.Comment) (match bool) {
+	var tags []string
+	for _, comment := range comments {
+		tags = append(tags, getTagsFromComment(comment.Text)...)
 	}
-	if _, has := parser.tags[tag]; has {
+
+	return matchTagFilter(parser.tags, tags)
+}
+
+// matchTagFilter reports whether the filter of tags, e.g. users,!internal, selects an operation of tags: none of its
+// tags is excluded, and one is included unless the filter only excludes.
+func matchTagFilter(filter map[string]struct{}, tags []string) bool {
+	if len(filter) == 0 {
 		return true
 	}
 
-	// If all tags are negation then we should return true
-	for key := range parser.tags {
-		if key[0] != '!' {
+	for _, tag := range tags {
+		if _, has := filter["!"+tag]; has {
 			return false
 		}
 	}
-	return true
-}
-
-func (parser *Parser) matchTags(comments []*ast.Comment) (match bool) {
-	if len(parser.tags) == 0 {
-		return true
-	}
 
-	match = false
-	for _, comment := range comments {
-		for _, tag := range getTagsFromComment(comment.Text) {
-			if _, has := parser.tags["!"+tag]; has {
-				return false
-			}
-			if _, has := parser.tags[tag]; has {
-				match = true // keep iterating as it may contain a tag that is excluded
-			}
+	for _, tag := range tags {
+		if _, has := filter[tag]; has {
+			return true
 		}
 	}
 
-	if !match {
-		// If all tags are negation then we should return true
-		for key := range parser.tags {
-			if key[0] != '!' {
-				return false
-			}
+	// If all tags are negation then we should return true
+	for key := range filter {
+		if key[0] != '!' {
+			return false
 		}
 	}
+
 	return true
 }
 
@@ -1095,69 +1752,294 @@ func getFuncDoc(decl any) (*ast.CommentGroup, bool) {
 		if len(astDecl.Values) == 0 {
 			return nil, false
 		}
-		value, ok := astDecl.Values[0].(*ast.Ident)
-		if !ok || value == nil || value.Obj == nil || value.Obj.Decl == nil {
-			return nil, false
-		}
-		_, ok = getFuncDoc(value.Obj.Decl)
-		return astDecl.Doc, ok
+		return astDecl.Doc, isHandlerValue(astDecl.Values[0])
 	}
 	return nil, false
 }
 
+// isHandlerValue reports whether the value of a variable is a function, following the value chain of the handlers
+// produced by wrappers and factories: func literals, method values like h.GetUser, functions of other packages,
+// calls of a wrapper with a handler like WithAuth(getUser), and calls of a function or method returning a handler.
+func isHandlerValue(expr ast.Expr) bool {
+	switch value := expr.(type) {
+	case *ast.ParenExpr:
+		return isHandlerValue(value.X)
+	case *ast.FuncLit, *ast.SelectorExpr:
+		return true
+	case *ast.IndexExpr: // generic function, e.g. Wrap[User]
+		return isHandlerValue(value.X)
+	case *ast.IndexListExpr:
+		return isHandlerValue(value.X)
+	case *ast.Ident:
+		if value.Obj == nil || value.Obj.Decl == nil {
+			return false
+		}
+		_, ok := getFuncDoc(value.Obj.Decl)
+		return ok
+	case *ast.CallExpr:
+		if isHandlerValue(value.Fun) {
+			return true
+		}
+		for _, arg := range value.Args {
+			if isHandlerValue(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getFuncDocs returns the doc comments of a function declaration, or of the variables holding functions of a var
+// declaration, each variable of a group having its own.
+func getFuncDocs(decl ast.Decl) []*ast.CommentGroup {
+	genDecl, ok := decl.(*ast.GenDecl)
+	if !ok || genDecl.Tok != token.VAR || !genDecl.Lparen.IsValid() {
+		if funcDoc, ok := getFuncDoc(decl); ok && funcDoc != nil {
+			return []*ast.CommentGroup{funcDoc}
+		}
+		return nil
+	}
+
+	var docs []*ast.CommentGroup
+	for _, spec := range genDecl.Specs {
+		funcDoc, ok := getFuncDoc(spec)
+		if funcDoc == nil && len(genDecl.Specs) == 1 {
+			// the doc of a group of one variable
+			funcDoc = genDecl.Doc
+		}
+		if ok && funcDoc != nil {
+			docs = append(docs, funcDoc)
+		}
+	}
+	return docs
+}
+
 // ParseRouterAPIInfo parses router api info for given astFile.
 func (parser *Parser) ParseRouterAPIInfo(fileInfo *AstFileInfo) error {
 	if (fileInfo.ParseFlag & ParseOperations) == ParseNone {
 		return nil
 	}
 
+	if !parser.matchPackage(fileInfo.Path) {
+		return parser.collectOutsideRoutes(fileInfo)
+	}
+
+	// generated wrappers route operations by directives
+	if err := parser.parseRouteDirectives(fileInfo); err != nil {
+		return err
+	}
+
+	// mocks and stubs copy the annotations of the functions they stand for
+	if !parser.IncludeGenerated && ast.IsGenerated(fileInfo.File) {
+		parser.debug.Printf("Skipping the operations of generated file %s", fileInfo.Path)
+
+		return nil
+	}
+
 	// parse File.Comments instead of File.Decls.Doc if ParseFuncBody flag set to "true"
 	if parser.ParseFuncBody {
+		routes := closureRoutes(fileInfo)
+
 		for _, astComments := range fileInfo.File.Comments {
-			if astComments.List != nil {
-				if err := parser.parseRouterAPIInfoComment(astComments.List, fileInfo); err != nil {
+			if astComments.List == nil {
+				continue
+			}
+
+			comments := astComments.List
+			if route, ok := routes[astComments]; ok {
+				comments = closureComments(comments, route)
+			}
+
+			if err := parser.parseRouterAPIInfoComment(comments, fileInfo); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, decl := range fileInfo.File.Decls {
+		for _, funcDoc := range getFuncDocs(decl) {
+			if funcDoc.List != nil {
+				if err := parser.parseRouterAPIInfoComment(funcDoc.List, fileInfo); err != nil {
 					return err
 				}
 			}
 		}
+	}
+
+	return nil
+}
+
+func (parser *Parser) parseRouterAPIInfoComment(comments []*ast.Comment, fileInfo *AstFileInfo) error {
+	comments, err := parser.withPackageDefaults(comments, fileInfo)
+	if err != nil {
+		return err
+	}
+
+	crud, lines := crudLine(comments)
+	if crud == "" {
+		return parser.parseOperationComment(comments, lines, fileInfo)
+	}
 
-		return nil
+	// the operations of the template share the other lines of the block
+	operations, err := parser.expandOperationTemplate(crud, lines)
+	if err != nil {
+		return fmt.Errorf("ParseComment error in file %s for comment: '%s': %w", fileInfo.Path, crud, err)
 	}
 
-	for _, decl := range fileInfo.File.Decls {
-		funcDoc, ok := getFuncDoc(decl)
-		if ok && funcDoc != nil && funcDoc.List != nil {
-			if err := parser.parseRouterAPIInfoComment(funcDoc.List, fileInfo); err != nil {
-				return err
-			}
+	for _, operationLines := range operations {
+		if err := parser.parseOperationComment(comments, operationLines, fileInfo); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (parser *Parser) parseRouterAPIInfoComment(comments []*ast.Comment, fileInfo *AstFileInfo) error {
+// parseOperationComment parses the annotation lines of an operation, from comments unless it is expanded
+// from a template.
+func (parser *Parser) parseOperationComment(comments []*ast.Comment, lines []string, fileInfo *AstFileInfo) error {
 	if parser.matchTags(comments) && matchExtension(parser.parseExtension, comments) {
 		// for per 'function' comment, create a new 'Operation' object
 		operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
-		for _, comment := range comments {
-			err := operation.ParseComment(comment.Text, fileInfo.File)
+
+		for _, line := range JoinContinuedLines(lines) {
+			err := operation.ParseComment(line, fileInfo.File)
 			if err != nil {
-				return fmt.Errorf("ParseComment error in file %s for comment: '%s': %+v", fileInfo.Path, comment.Text, err)
+				return fmt.Errorf("ParseComment error in file %s for comment: '%s': %w", fileInfo.Path, line, err)
 			}
 			if operation.State != "" && operation.State != parser.HostState {
 				return nil
 			}
 		}
+
+		if operation.heredoc != nil {
+			return fmt.Errorf("description block in file %s is not terminated by %s", fileInfo.Path, operation.heredoc.delimiter)
+		}
+
+		if len(operation.RouterProperties) == 0 && operation.ID != "" {
+			parser.addOperationDefinition(operation)
+		}
+
 		err := processRouterOperation(parser, operation)
 		if err != nil {
 			return err
 		}
+
+		parser.addOperationSource(operation, comments[0].Pos(), fileInfo)
+	}
+
+	return nil
+}
+
+// packagePattern is a directory of SetOnlyPackages.
+type packagePattern struct {
+	dir       string
+	recursive bool
+}
+
+// matchPackage reports whether the operations of the file are parsed according to SetOnlyPackages.
+func (parser *Parser) matchPackage(filePath string) bool {
+	if len(parser.onlyPackages) == 0 {
+		return true
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(filePath))
+	if err != nil {
+		return false
+	}
+
+	for _, pattern := range parser.onlyPackages {
+		if dir == pattern.dir || pattern.recursive && strings.HasPrefix(dir, pattern.dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchRoute reports whether the operations of the route are parsed according to SetOnlyRoutes.
+func (parser *Parser) MatchRoute(route string) bool {
+	if len(parser.onlyRoutes) == 0 {
+		return true
+	}
+
+	for _, pattern := range parser.onlyRoutes {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(route, prefix+"/") {
+			return true
+		}
+
+		if matched, _ := path.Match(pattern, route); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectOutsideRoutes records the routes of the operations which a file outside the directories of SetOnlyPackages
+// declares by @Router, @crud or //swag:route, without parsing them.
+func (parser *Parser) collectOutsideRoutes(fileInfo *AstFileInfo) error {
+	if parser.outsideRoutes == nil {
+		parser.outsideRoutes = make(map[string]struct{})
+	}
+
+	for _, group := range fileInfo.File.Comments {
+		crud, lines := crudLine(group.List)
+
+		blocks := [][]string{lines}
+		if crud != "" {
+			operations, err := parser.expandOperationTemplate(crud, lines)
+			if err != nil {
+				return fmt.Errorf("ParseComment error in file %s for comment: '%s': %w", fileInfo.Path, crud, err)
+			}
+
+			blocks = operations
+		}
+
+		for _, block := range blocks {
+			for _, line := range block {
+				if args, ok := strings.CutPrefix(line, routeDirective); ok {
+					if fields := strings.Fields(args); len(fields) == 4 {
+						parser.outsideRoutes[strings.ToUpper(fields[0])+" "+fields[1]] = struct{}{}
+					}
+
+					continue
+				}
+
+				fields := FieldsByAnySpace(strings.TrimSpace(strings.TrimLeft(line, "/")), 2)
+				if len(fields) != 2 {
+					continue
+				}
+
+				if attribute := strings.ToLower(fields[0]); attribute != routerAttr && attribute != deprecatedRouterAttr {
+					continue
+				}
+
+				if matches := routerPattern.FindStringSubmatch(fields[1]); matches != nil {
+					parser.outsideRoutes[strings.ToUpper(matches[2])+" "+matches[1]] = struct{}{}
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
+// DeclaredOutside reports whether a file outside the directories of SetOnlyPackages declares the operation of
+// method, e.g. GET, at route. The other operations of a previous document belong to the parsed directories.
+func (parser *Parser) DeclaredOutside(method, route string) bool {
+	_, ok := parser.outsideRoutes[strings.ToUpper(method)+" "+route]
+
+	return ok
+}
+
+// Selective reports whether only a part of the operations is parsed, by SetOnlyPackages or SetOnlyRoutes.
+func (parser *Parser) Selective() bool {
+	return len(parser.onlyPackages) > 0 || len(parser.onlyRoutes) > 0
+}
+
 func refRouteMethodOp(item *spec.PathItem, method string) (op **spec.Operation) {
 	switch method {
 	case http.MethodGet:
@@ -1180,7 +2062,19 @@ func refRouteMethodOp(item *spec.PathItem, method string) (op **spec.Operation)
 }
 
 func processRouterOperation(parser *Parser, operation *Operation) error {
+	if err := parser.applyDefaultResponses(operation); err != nil {
+		return err
+	}
+
+	if err := parser.applyDefaultSuccess(operation); err != nil {
+		return err
+	}
+
 	for _, routeProperties := range operation.RouterProperties {
+		if !parser.MatchRoute(routeProperties.Path) {
+			continue
+		}
+
 		var (
 			pathItem spec.PathItem
 			ok       bool
@@ -1229,6 +2123,89 @@ func processRouterOperation(parser *Parser, operation *Operation) error {
 	return nil
 }
 
+// applyDefaultSuccess applies the policy of SetDefaultSuccess to an operation without success response.
+func (parser *Parser) applyDefaultSuccess(operation *Operation) error {
+	if operation.Responses != nil {
+		for code := range operation.Responses.StatusCodeResponses {
+			if code >= 200 && code < 400 {
+				return nil
+			}
+		}
+	}
+
+	switch parser.defaultSuccess {
+	case DefaultSuccessEmpty:
+		if operation.Responses == nil {
+			operation.Responses = &spec.Responses{}
+		}
+
+		if operation.Responses.StatusCodeResponses == nil {
+			operation.Responses.StatusCodeResponses = make(map[int]spec.Response)
+		}
+
+		operation.AddResponse(http.StatusOK, spec.NewResponse().WithDescription(http.StatusText(http.StatusOK)))
+	case DefaultSuccessError:
+		var routes []string
+		for _, route := range operation.RouterProperties {
+			routes = append(routes, route.HTTPMethod+" "+route.Path)
+		}
+
+		return fmt.Errorf("operation %s has no success response, document one with @Success", strings.Join(routes, ", "))
+	}
+
+	return nil
+}
+
+// applyDefaultResponses adds the responses of the @defaultResponse annotations to an operation, unless it declares
+// the same status code or opts out with @noDefaultResponses.
+func (parser *Parser) applyDefaultResponses(operation *Operation) error {
+	if len(parser.defaultResponses) == 0 || operation.noDefaultResponses {
+		return nil
+	}
+
+	if parser.defaultResponsesJSON == nil {
+		template := NewOperation(parser)
+		for _, line := range parser.defaultResponses {
+			if err := template.ParseResponseComment(line, parser.generalInfoFile); err != nil {
+				return fmt.Errorf("@defaultResponse %s: %w", line, err)
+			}
+		}
+
+		b, err := json.Marshal(template.Responses)
+		if err != nil {
+			return err
+		}
+
+		parser.defaultResponsesJSON = b
+	}
+
+	// each operation gets its own copy, since the responses may be changed later, e.g. by the auth responses
+	var defaults spec.Responses
+	if err := json.Unmarshal(parser.defaultResponsesJSON, &defaults); err != nil {
+		return err
+	}
+
+	if operation.Responses == nil {
+		operation.Responses = &spec.Responses{}
+	}
+
+	if operation.Responses.StatusCodeResponses == nil {
+		operation.Responses.StatusCodeResponses = make(map[int]spec.Response)
+	}
+
+	if defaults.Default != nil && operation.Responses.Default == nil {
+		operation.Responses.Default = defaults.Default
+	}
+
+	for code, response := range defaults.StatusCodeResponses {
+		if _, ok := operation.Responses.StatusCodeResponses[code]; !ok {
+			operation.AddResponse(code, &response)
+		}
+	}
+
+	return nil
+}
+
 func convertFromSpecificToPrimitive(typeName string) (string, error) {
 	name := typeName
 	if strings.ContainsRune(name, '.') {
@@ -1258,6 +2235,14 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 		return TransToValidPrimitiveSchema(typeName), nil
 	}
 
+	if typeName == timeType && parser.TimeFormat != "" {
+		return timeSchema(parser.TimeFormat), nil
+	}
+
+	if typeName == rawMessageType {
+		return rawMessageSchema(parser.RawMessageType), nil
+	}
+
 	schemaType, err := convertFromSpecificToPrimitive(typeName)
 	if err == nil {
 		return PrimitiveSchema(schemaType), nil
@@ -1265,6 +2250,10 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 
 	typeSpecDef := parser.packages.FindTypeSpec(typeName, file)
 	if typeSpecDef == nil {
+		if parser.packages.limitErr != nil {
+			return nil, parser.packages.limitErr
+		}
+
 		return nil, fmt.Errorf("cannot find type definition: %s", typeName)
 	}
 
@@ -1305,7 +2294,12 @@ func (parser *Parser) getTypeSchema(typeName string, file *ast.File, ref bool) (
 
 	if ref {
 		if IsComplexSchema(schema.Schema) {
-			return parser.getRefTypeSchema(typeSpecDef, schema), nil
+			refSchema := parser.getRefTypeSchema(typeSpecDef, schema)
+			if max := parser.limits.MaxDefinitions; max > 0 && len(parser.swagger.Definitions) > max {
+				return nil, fmt.Errorf("%w: the document has more than %d definitions, adding %s", ErrLimitExceeded, max, schema.Name)
+			}
+
+			return refSchema, nil
 		}
 		// if it is a simple schema, just return a copy
 		newSchema := *schema.Schema
@@ -1384,6 +2378,15 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 
 	parser.structStack = append(parser.structStack, typeSpecDef)
 
+	parser.definitionChain = append(parser.definitionChain, typeName)
+	defer func() {
+		parser.definitionChain = parser.definitionChain[:len(parser.definitionChain)-1]
+	}()
+
+	if max := parser.limits.MaxSchemaDepth; max > 0 && len(parser.definitionChain) > max {
+		return nil, fmt.Errorf("%w: schema depth exceeds %d: %s", ErrLimitExceeded, max, strings.Join(parser.definitionChain, " > "))
+	}
+
 	parser.debug.Printf("Generating %s", typeName)
 
 	definition, err := parser.parseTypeExpr(typeSpecDef.File, typeSpecDef.TypeSpec.Type, false)
@@ -1421,6 +2424,16 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 		}
 	}
 
+	parser.applyStringerEnums(definition, typeSpecDef)
+
+	if isFlagsType(typeSpecDef) {
+		if definition.Type.Contains(INTEGER) && len(definition.Enum) > 0 {
+			definition = flagsSchema(definition)
+		} else {
+			parser.debug.Printf("warning: %s %s is not an integer type with constants", flagsDirective, typeName)
+		}
+	}
+
 	schemaName := typeName
 
 	if typeSpecDef.SchemaName != "" {
@@ -1505,7 +2518,7 @@ func (parser *Parser) extractDeclarationDescription(typeName string, commentGrou
 				if typeName == "" {
 					continue
 				}
-				desc, err := getMarkdownForTag(typeName, parser.markdownFileDir)
+				desc, err := parser.getMarkdown(typeName)
 				if err != nil {
 					return "", err
 				}
@@ -1623,7 +2636,12 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 		}
 	}
 
+	// the field parser names the properties with the tag of the package of the struct
+	parser.propertyTag = parser.propertyTagOf(file)
 	ps := parser.fieldParserFactory(parser, field)
+	if fieldParser, ok := ps.(*tagBaseFieldParser); ok {
+		fieldParser.file = file
+	}
 
 	if ps.ShouldSkip() {
 		return nil, nil, nil
@@ -1634,15 +2652,23 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 		return nil, nil, err
 	}
 
+	filteredSchema, skip := parser.filterModelField(file, field, fieldNames)
+	if skip {
+		return nil, nil, nil
+	}
+
 	if len(fieldNames) == 0 {
 		typeName, err := getFieldType(file, field.Type, nil)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		schema, err := parser.getTypeSchema(typeName, file, false)
-		if err != nil {
-			return nil, nil, err
+		schema := filteredSchema
+		if schema == nil {
+			schema, err = parser.getTypeSchema(typeName, file, false)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
 
 		if len(schema.Type) > 0 && schema.Type[0] == OBJECT {
@@ -1667,6 +2693,10 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 		return nil, nil, fmt.Errorf("%v: %w", fieldNames, err)
 	}
 
+	if schema == nil {
+		schema = filteredSchema
+	}
+
 	if schema == nil {
 		typeName, err := getFieldType(file, field.Type, nil)
 		if err == nil {
@@ -1687,6 +2717,10 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 		return nil, nil, fmt.Errorf("%v: %w", fieldNames, err)
 	}
 
+	if parser.NullablePointers {
+		markNullablePointer(field.Type, schema)
+	}
+
 	var tagRequired []string
 
 	required, err := ps.IsRequired()
@@ -1696,6 +2730,8 @@ func (parser *Parser) parseStructField(file *ast.File, field *ast.Field) (map[st
 
 	if required {
 		tagRequired = append(tagRequired, fieldNames...)
+	} else if parser.RequiredInResponses && isAlwaysMarshaled(field, parser.propertyTag) {
+		schema.AddExtension(marshaledMark, true)
 	}
 
 	if formName := ps.FormName(); len(formName) > 0 {
@@ -1878,7 +2914,10 @@ func (parser *Parser) getAllGoFileInfo(packageDir, searchDir string) error {
 	if parser.skipPackageByPrefix(packageDir) {
 		return nil // ignored by user-defined package path prefixes
 	}
-	return filepath.Walk(searchDir, func(path string, f os.FileInfo, wError error) error {
+
+	var files []sourceFile
+
+	err := filepath.Walk(searchDir, func(path string, f os.FileInfo, wError error) error {
 		if wError != nil {
 			return fmt.Errorf("failed to access path %q, err: %v\n", path, wError)
 		}
@@ -1896,8 +2935,19 @@ func (parser *Parser) getAllGoFileInfo(packageDir, searchDir string) error {
 			return err
 		}
 
-		return parser.parseFile(filepath.ToSlash(filepath.Dir(filepath.Clean(filepath.Join(packageDir, relPath)))), path, nil, ParseAll)
+		files = append(files, sourceFile{
+			packageDir: filepath.ToSlash(filepath.Dir(filepath.Clean(filepath.Join(packageDir, relPath)))),
+			path:       path,
+			flag:       ParseAll,
+		})
+
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return parser.parseFiles(files)
 }
 
 func (parser *Parser) getAllGoFileInfoFromDeps(pkg *depth.Pkg, parseFlag ParseFlag, dirImported map[string]struct{}) error {
@@ -1926,15 +2976,18 @@ func (parser *Parser) getAllGoFileInfoFromDeps(pkg *depth.Pkg, parseFlag ParseFl
 		return err
 	}
 
+	sources := make([]sourceFile, 0, len(files))
+
 	for _, f := range files {
 		if f.IsDir() {
 			continue
 		}
 
-		path := filepath.Join(srcDir, f.Name())
-		if err := parser.parseFile(pkg.Name, path, nil, parseFlag); err != nil {
-			return err
-		}
+		sources = append(sources, sourceFile{packageDir: pkg.Name, path: filepath.Join(srcDir, f.Name()), flag: parseFlag})
+	}
+
+	if err := parser.parseFiles(sources); err != nil {
+		return err
 	}
 
 	for i := 0; i < len(pkg.Deps); i++ {
@@ -1947,11 +3000,22 @@ func (parser *Parser) getAllGoFileInfoFromDeps(pkg *depth.Pkg, parseFlag ParseFl
 }
 
 func (parser *Parser) parseFile(packageDir, path string, src any, flag ParseFlag) error {
-	if strings.HasSuffix(strings.ToLower(path), "_test.go") || filepath.Ext(path) != ".go" {
-		return nil
+	return parser.parseFiles([]sourceFile{{packageDir: packageDir, path: path, src: src, flag: flag}})
+}
+
+// parseFiles parses the Go files, except tests, with parser.jobs goroutines.
+func (parser *Parser) parseFiles(files []sourceFile) error {
+	goFiles := files[:0:0]
+
+	for _, file := range files {
+		if strings.HasSuffix(strings.ToLower(file.path), "_test.go") || filepath.Ext(file.path) != ".go" {
+			continue
+		}
+
+		goFiles = append(goFiles, file)
 	}
 
-	return parser.packages.ParseFile(packageDir, path, src, flag)
+	return parser.packages.parseFiles(goFiles, parser.jobs)
 }
 
 func (parser *Parser) checkOperationIDUniqueness() error {
@@ -1989,6 +3053,169 @@ func (parser *Parser) checkOperationIDUniqueness() error {
 	return nil
 }
 
+// inheritMimeTypes copies the consumes and produces of general API info into the operations without theirs.
+func (parser *Parser) inheritMimeTypes() {
+	for path, item := range parser.swagger.Paths.Paths {
+		for method := range allMethod {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil {
+				continue
+			}
+
+			if len(op.Consumes) == 0 && len(parser.swagger.Consumes) > 0 {
+				op.Consumes = append([]string(nil), parser.swagger.Consumes...)
+			}
+
+			if len(op.Produces) == 0 && len(parser.swagger.Produces) > 0 {
+				op.Produces = append([]string(nil), parser.swagger.Produces...)
+			}
+		}
+
+		parser.swagger.Paths.Paths[path] = item
+	}
+}
+
+// addAuthResponses documents 401 and 403 responses with the WWW-Authenticate header
+// for every operation which requires authentication, declared responses are kept.
+func (parser *Parser) addAuthResponses() {
+	for path, item := range parser.swagger.Paths.Paths {
+		for method := range allMethod {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil {
+				continue
+			}
+
+			security := op.Security
+			if security == nil {
+				security = parser.swagger.Security
+			}
+
+			if !requiresAuthentication(security) {
+				continue
+			}
+
+			if op.Responses == nil {
+				op.Responses = &spec.Responses{}
+			}
+
+			if op.Responses.StatusCodeResponses == nil {
+				op.Responses.StatusCodeResponses = make(map[int]spec.Response)
+			}
+
+			unauthorized, ok := op.Responses.StatusCodeResponses[http.StatusUnauthorized]
+			if !ok {
+				unauthorized = *spec.NewResponse().WithDescription(http.StatusText(http.StatusUnauthorized))
+			}
+
+			if _, ok = unauthorized.Headers[wwwAuthenticateHeader]; !ok {
+				unauthorized.AddHeader(wwwAuthenticateHeader,
+					spec.ResponseHeader().Typed(STRING, "").WithDescription(parser.authenticationChallenges(security)))
+			}
+
+			op.Responses.StatusCodeResponses[http.StatusUnauthorized] = unauthorized
+
+			if _, ok = op.Responses.StatusCodeResponses[http.StatusForbidden]; !ok {
+				op.Responses.StatusCodeResponses[http.StatusForbidden] = *spec.NewResponse().
+					WithDescription(http.StatusText(http.StatusForbidden))
+			}
+		}
+
+		parser.swagger.Paths.Paths[path] = item
+	}
+}
+
+// requiresAuthentication reports whether no security requirement allows anonymous access.
+func requiresAuthentication(security []map[string][]string) bool {
+	for _, requirement := range security {
+		if len(requirement) == 0 {
+			return false
+		}
+	}
+
+	return len(security) > 0
+}
+
+// authenticationChallenges describes the WWW-Authenticate challenges of the security requirements.
+func (parser *Parser) authenticationChallenges(security []map[string][]string) string {
+	var challenges []string
+
+	for _, requirement := range security {
+		for name := range requirement {
+			var challenge string
+
+			if scheme, ok := parser.swagger.SecurityDefinitions[name]; ok {
+				switch {
+				case scheme.Type == "basic":
+					challenge = "Basic"
+				case scheme.Type == "oauth2", scheme.Extensions[BearerSchemeExtension] == "bearer":
+					challenge = "Bearer"
+				}
+			}
+
+			if challenge != "" && !findInSlice(challenges, challenge) {
+				challenges = append(challenges, challenge)
+			}
+		}
+	}
+
+	sort.Strings(challenges)
+
+	if len(challenges) == 0 {
+		return "Authentication is required"
+	}
+
+	return "Authentication challenges: " + strings.Join(challenges, ", ")
+}
+
+func (parser *Parser) addOperationSource(operation *Operation, pos token.Pos, fileInfo *AstFileInfo) {
+	source := fileInfo.Path
+	if fileInfo.FileSet != nil {
+		source = fmt.Sprintf("%s:%d", fileInfo.Path, fileInfo.FileSet.Position(pos).Line)
+	}
+
+	if parser.operationSources == nil {
+		parser.operationSources = make(map[string]string)
+	}
+
+	for _, route := range operation.RouterProperties {
+		pointer := "/paths/" + escapePointer(route.Path) + "/" + strings.ToLower(route.HTTPMethod)
+		parser.operationSources[pointer] = source
+	}
+}
+
+// Source returns the file and line of the annotations which produced the element at the JSON pointer,
+// or an empty string if it is unknown. Operations and definitions, or schemas of the OpenAPI 3.0 components,
+// are tracked.
+func (parser *Parser) Source(pointer string) string {
+	segments := strings.SplitN(pointer, "/", 5)
+
+	if len(segments) >= 4 && segments[1] == "components" && segments[2] == "schemas" {
+		segments = append([]string{"", "definitions"}, segments[3:]...)
+	}
+
+	switch {
+	case len(segments) >= 4 && segments[1] == "paths":
+		return parser.operationSources[strings.Join(segments[:4], "/")]
+	case len(segments) >= 3 && segments[1] == "definitions":
+		name := strings.NewReplacer("~1", "/", "~0", "~").Replace(segments[2])
+
+		for typeSpecDef, schema := range parser.outputSchemas {
+			if schema.Name != name || typeSpecDef.File == nil || typeSpecDef.TypeSpec == nil {
+				continue
+			}
+
+			fileInfo, ok := parser.packages.files[typeSpecDef.File]
+			if !ok || fileInfo.FileSet == nil {
+				return ""
+			}
+
+			return fmt.Sprintf("%s:%d", fileInfo.Path, fileInfo.FileSet.Position(typeSpecDef.TypeSpec.Pos()).Line)
+		}
+	}
+
+	return ""
+}
+
 // Skip returns filepath.SkipDir error if match vendor and hidden folder.
 func (parser *Parser) Skip(path string, f os.FileInfo) error {
 	return walkWith(parser.excludes, parser.ParseVendor)(path, f)
@@ -2,12 +2,14 @@ package swag
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"go/ast"
 	goparser "go/parser"
 	"go/token"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const defaultParseDepth = 100
@@ -461,6 +464,129 @@ func TestParser_ParseGeneralAPIInfoMarkdown(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestParser_ParseGeneralAPILocalizedDescription(t *testing.T) {
+	t.Parallel()
+
+	parser := New()
+	err := parseGeneralAPIInfo(parser, []string{
+		"@title Swagger Example API",
+		"@description default description",
+		"@description[en] english description",
+		"@description[zh] 中文描述",
+		"@description[zh] 第二行",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "default description", parser.swagger.Info.Description)
+	assert.Equal(t, map[string]string{
+		"en": "english description",
+		"zh": "中文描述\n第二行",
+	}, parser.swagger.Info.Extensions[descriptionsExtension])
+}
+
+func TestParser_ParseGeneralAPISLA(t *testing.T) {
+	t.Parallel()
+
+	parser := New()
+	err := parseGeneralAPIInfo(parser, []string{
+		"@title Swagger Example API",
+		"@sla.uptime 99.9%",
+		"@sla.latency-p99 200ms",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"uptime":      "99.9%",
+		"latency-p99": "200ms",
+	}, parser.swagger.Extensions[SLAExtension])
+
+	assert.Error(t, parseGeneralAPIInfo(parser, []string{"@sla.uptime"}))
+}
+
+func TestParser_ParseGeneralAPILicense(t *testing.T) {
+	t.Parallel()
+
+	logger := &testLogger{}
+	parser := New(SetDebugger(logger))
+	err := parseGeneralAPIInfo(parser, []string{
+		"@license.name Apache 2.0",
+		"@license.identifier Apache-2.0 OR MIT",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Apache 2.0", parser.swagger.Info.License.Name)
+	assert.Equal(t, "Apache-2.0 OR MIT", parser.swagger.Info.License.Extensions[LicenseIdentifierExtension])
+	assert.Equal(t, []string{"warning: @license.name Apache 2.0 is not an SPDX license identifier"}, logger.Messages)
+}
+
+func TestParser_ParseGeneralAPIInfoValidation(t *testing.T) {
+	t.Parallel()
+
+	mainAPIFile := filepath.Join(t.TempDir(), "main.go")
+	src := `package main
+
+// @title Swagger Example API
+// @host http://petstore.swagger.io
+// @BasePath v2
+// @contact.url www.swagger.io/support
+// @license.url http://www.apache.org/licenses/LICENSE-2.0.html
+// @tag.name pets
+// @tag.docs.url /docs/pets
+func main() {}
+`
+	require.NoError(t, os.WriteFile(mainAPIFile, []byte(src), 0o644))
+
+	// the findings are warnings unless strict
+	logger := &testLogger{}
+	require.NoError(t, New(SetDebugger(logger)).ParseGeneralAPIInfo(mainAPIFile))
+	require.Len(t, logger.Messages, 4)
+	assert.Contains(t, logger.Messages[0], "main.go:4: @host http://petstore.swagger.io: must not contain a scheme; declare it with @schemes")
+	assert.Contains(t, logger.Messages[1], "main.go:5: @BasePath v2: must start with /")
+
+	err := New(SetStrict(true)).ParseGeneralAPIInfo(mainAPIFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "main.go:4: @host http://petstore.swagger.io: must not contain a scheme; declare it with @schemes")
+	assert.Contains(t, err.Error(), "main.go:5: @BasePath v2: must start with /")
+	assert.Contains(t, err.Error(), "main.go:6: @contact.url www.swagger.io/support: must be an absolute http or https URL")
+	assert.Contains(t, err.Error(), "main.go:9: @tag.docs.url /docs/pets")
+	assert.NotContains(t, err.Error(), "@license.url")
+
+	parser := New(SetVariables(map[string]string{"Host": "localhost:8080"}))
+	require.NoError(t, os.WriteFile(mainAPIFile, []byte(`package main
+
+// @title Swagger Example API
+// @host {{.Host}}
+// @BasePath {{.BasePath}}
+func main() {}
+`), 0o644))
+	assert.NoError(t, parser.ParseGeneralAPIInfo(mainAPIFile))
+	assert.Equal(t, "localhost:8080", parser.swagger.Host)
+
+	for _, host := range []string{"localhost:8080", "petstore.swagger.io", "127.0.0.1:80", "[::1]:8080", "product_info.swagger.io"} {
+		assert.NoError(t, validateHost(host), host)
+	}
+
+	for _, host := range []string{"https://petstore.swagger.io", "petstore.swagger.io/v2", "localhost:0", "localhost:http", ":8080"} {
+		assert.Error(t, validateHost(host), host)
+	}
+}
+
+func TestParser_ParseGeneralAPIInfoVariables(t *testing.T) {
+	t.Parallel()
+
+	parser := New(SetVariables(map[string]string{"BuildVersion": "v1.2.3", "Env": "staging"}))
+	err := parseGeneralAPIInfo(parser, []string{
+		"@title Petstore {{.Env}}",
+		"@version {{.BuildVersion}}",
+		"@description Unknown {{.Commit}} is kept",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Petstore staging", parser.swagger.Info.Title)
+	assert.Equal(t, "v1.2.3", parser.swagger.Info.Version)
+	assert.Equal(t, "Unknown {{.Commit}} is kept", parser.swagger.Info.Description)
+}
+
 func TestParser_ParseGeneralApiInfoFailed(t *testing.T) {
 	t.Parallel()
 
@@ -551,6 +677,10 @@ func TestParser_ParseGeneralAPIInfoCollectionFormat(t *testing.T) {
 		"@query.collection.format tsv",
 	}))
 	assert.Equal(t, parser.collectionFormatInQuery, "tsv")
+
+	assert.Error(t, parseGeneralAPIInfo(parser, []string{
+		"@query.collection.format oops",
+	}))
 }
 
 func TestParser_ParseGeneralAPITagGroups(t *testing.T) {
@@ -3120,6 +3250,23 @@ func TestParseTagMarkdownDescription(t *testing.T) {
 	}
 }
 
+func TestParseTagMarkdownOverview(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/tags_overview"
+	p := New(SetMarkdownFileDirectory(searchDir))
+	assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+	assert.Len(t, p.swagger.Tags, 2)
+
+	users := p.swagger.Tags[0]
+	assert.Equal(t, "Users of the shop.\n\nRequests need an API key.\n", users.Description)
+	assert.Equal(t, "<h1>Users</h1>\n\n<p>Create a user before its orders.</p>\n", users.Extensions[TagOverviewExtension])
+
+	orders := p.swagger.Tags[1]
+	assert.Empty(t, orders.Description)
+	assert.Equal(t, "<p>Orders of <em>users</em>.</p>\n", orders.Extensions[TagOverviewExtension])
+}
+
 func TestParseApiMarkdownDescription(t *testing.T) {
 	t.Parallel()
 
@@ -4062,6 +4209,69 @@ func TestGetFuncDoc_NilPointerSafety(t *testing.T) {
 	}
 }
 
+func TestParser_WrappedHandlers(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Handler struct{}
+
+func (h *Handler) getOrder() {}
+
+func (h *Handler) listHandler() func() { return nil }
+
+func getUser() {}
+
+func WithAuth(f func()) func() { return f }
+
+func Wrap[T any](f func()) func() { return f }
+
+func newDeleteUser(db string) func() { return nil }
+
+var h = &Handler{}
+
+// @Router /users/{id} [get]
+var GetUser = WithAuth(getUser)
+
+// @Router /users/{id} [put]
+var UpdateUser = WithAuth(Wrap[int](func() {}))
+
+// @Router /users/{id} [delete]
+var DeleteUser = newDeleteUser("users")
+
+var (
+	// @Router /orders/{id} [get]
+	GetOrder = h.getOrder
+
+	// @Router /orders [get]
+	ListOrders = (h.listHandler())
+
+	// not a handler
+	timeout = 3
+)
+
+// @Router /config [get]
+var config = map[string]string{}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("api", "testdata/wrappers/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	paths := p.swagger.Paths.Paths
+	assert.NotNil(t, paths["/users/{id}"].Get)
+	assert.NotNil(t, paths["/users/{id}"].Put)
+	assert.NotNil(t, paths["/users/{id}"].Delete)
+	assert.NotNil(t, paths["/orders/{id}"].Get)
+	assert.NotNil(t, paths["/orders"].Get)
+	assert.NotContains(t, paths, "/config")
+}
+
 func TestGetFieldType(t *testing.T) {
 	t.Parallel()
 
@@ -4442,6 +4652,57 @@ func Test(){
 	assert.NotNil(t, val2.Get)
 }
 
+func TestParser_ParseRouterApiInfoGenerated(t *testing.T) {
+	t.Parallel()
+
+	generated := `
+// Code generated by MockGen. DO NOT EDIT.
+
+package test
+
+type Pet struct {
+	Name string
+}
+
+// @Success 200 {object} Pet
+// @Router /api/pets [get]
+func (m *MockPets) List(){
+}
+`
+	src := `
+package test
+
+// @Success 200 {object} Pet
+// @Router /api/pet [get]
+func Get(){
+}
+`
+	for _, includeGenerated := range []bool{false, true} {
+		p := New()
+		p.IncludeGenerated = includeGenerated
+
+		err := p.packages.ParseFile("api", "api/mock_pets.go", generated, ParseAll)
+		assert.NoError(t, err)
+
+		err = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+		assert.NoError(t, err)
+
+		_, err = p.packages.ParseTypes()
+		assert.NoError(t, err)
+
+		err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+		assert.NoError(t, err)
+
+		_, ok := p.swagger.Paths.Paths["/api/pets"]
+		assert.Equal(t, includeGenerated, ok)
+
+		// the types of generated files are still parsed
+		_, ok = p.swagger.Paths.Paths["/api/pet"]
+		assert.True(t, ok)
+		assert.Contains(t, p.swagger.Definitions, "test.Pet")
+	}
+}
+
 func TestParser_EmbeddedStructAsOtherAliasGoListNested(t *testing.T) {
 	t.Parallel()
 
@@ -4566,3 +4827,451 @@ type LinkedNode struct {
 		assert.NotContains(t, name, "api.LinkedNode")
 	}
 }
+
+func TestParser_ParseGeneralAPISecurityBearer(t *testing.T) {
+	t.Parallel()
+
+	parser := New()
+	err := parseGeneralAPIInfo(parser, []string{
+		"@securityDefinitions.bearer BearerAuth",
+		"@bearerFormat JWT",
+		"@claims sub string \"User ID\"",
+		"@claims roles array",
+		"@claims scopes []integer \"Scope IDs\"",
+		"@description JWT issued by the login endpoint",
+	})
+	assert.NoError(t, err)
+
+	b, _ := json.MarshalIndent(parser.GetSwagger().SecurityDefinitions, "", "    ")
+	expected := `{
+    "BearerAuth": {
+        "description": "JWT issued by the login endpoint",
+        "type": "apiKey",
+        "name": "Authorization",
+        "in": "header",
+        "x-bearer-format": "JWT",
+        "x-jwt-claims": {
+            "roles": {
+                "items": {
+                    "type": "string"
+                },
+                "type": "array"
+            },
+            "scopes": {
+                "description": "Scope IDs",
+                "items": {
+                    "type": "integer"
+                },
+                "type": "array"
+            },
+            "sub": {
+                "description": "User ID",
+                "type": "string"
+            }
+        },
+        "x-scheme": "bearer"
+    }
+}`
+	assert.Equal(t, expected, string(b))
+
+	assert.Error(t, parseGeneralAPIInfo(New(), []string{
+		"@securityDefinitions.bearer BearerAuth",
+		"@claims sub",
+	}))
+}
+
+func TestParser_AddAuthResponses(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package test
+
+// @Security BasicAuth || OAuth2
+// @Failure 403 {string} string "Not your resource"
+// @Router /secured [get]
+func Secured(){
+}
+
+// @Security
+// @Router /public [get]
+func Public(){
+}
+
+// @Router /global [post]
+func Global(){
+}
+`
+	p := New()
+	p.AuthResponses = true
+	p.swagger.SecurityDefinitions = spec.SecurityDefinitions{
+		"BasicAuth": spec.BasicAuth(),
+		"OAuth2":    spec.OAuth2Application("https://example.com/token"),
+		"ApiKey":    spec.APIKeyAuth("X-API-KEY", "header"),
+	}
+	p.swagger.Security = []map[string][]string{{"ApiKey": {}}}
+
+	err := p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	p.addAuthResponses()
+
+	secured := p.swagger.Paths.Paths["/secured"].Get.Responses.StatusCodeResponses
+	assert.Equal(t, "Authentication challenges: Basic, Bearer", secured[http.StatusUnauthorized].Headers["WWW-Authenticate"].Description)
+	assert.Equal(t, "Not your resource", secured[http.StatusForbidden].Description)
+
+	assert.Empty(t, p.swagger.Paths.Paths["/public"].Get.Responses.StatusCodeResponses)
+
+	global := p.swagger.Paths.Paths["/global"].Post.Responses.StatusCodeResponses
+	assert.Equal(t, "Authentication is required", global[http.StatusUnauthorized].Headers["WWW-Authenticate"].Description)
+	assert.Equal(t, "Forbidden", global[http.StatusForbidden].Description)
+}
+
+func TestParser_InheritMimeTypes(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package test
+
+// @Router /users [get]
+func ListUsers(){
+}
+
+// @Accept  xml
+// @Produce xml
+// @Router /users [post]
+func CreateUser(){
+}
+`
+	p := New()
+	require.NoError(t, parseGeneralAPIInfo(p, []string{"@accept json", "@produce json,plain"}))
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	p.inheritMimeTypes()
+
+	list := p.swagger.Paths.Paths["/users"].Get
+	assert.Equal(t, []string{"application/json"}, list.Consumes)
+	assert.Equal(t, []string{"application/json", "text/plain"}, list.Produces)
+
+	create := p.swagger.Paths.Paths["/users"].Post
+	assert.Equal(t, []string{"text/xml"}, create.Consumes)
+	assert.Equal(t, []string{"text/xml"}, create.Produces)
+}
+
+func TestParser_ParseLineContinuation(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package test
+
+// @Param status query string false "order status" \
+//        Enums(placed, \
+//        approved)
+// @Router /orders [get]
+func Test(){
+}
+`
+	p := New()
+	err := parseGeneralAPIInfo(p, []string{
+		"@title Orders \\",
+		"       API",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Orders API", p.swagger.Info.Title)
+
+	err = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.NoError(t, err)
+
+	param := p.swagger.Paths.Paths["/orders"].Get.Parameters[0]
+	assert.Equal(t, []any{"placed", "approved"}, param.Enum)
+}
+
+func TestParser_ParseGeneralAPIHeredocDescription(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	err := parseGeneralAPIInfo(p, []string{
+		"@title Heredoc",
+		"@description <<EOF",
+		"First line",
+		"",
+		"",
+		"    indented code",
+		"EOF",
+		"@version 1.0",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "First line\n\n\n    indented code", p.swagger.Info.Description)
+	assert.Equal(t, "1.0", p.swagger.Info.Version)
+
+	err = parseGeneralAPIInfo(New(), []string{
+		"@description <<EOF",
+		"never terminated",
+	})
+	assert.Error(t, err)
+
+	src := `
+package test
+
+// @Description <<EOF
+// never terminated
+// @Router /test [get]
+func Test(){
+}
+`
+	err = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
+	assert.NoError(t, err)
+
+	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	assert.ErrorContains(t, err, "is not terminated by EOF")
+}
+
+func TestParser_MatchRoute(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	assert.True(t, p.MatchRoute("/anything"))
+	assert.False(t, p.Selective())
+
+	p = New(SetOnlyRoutes("/billing/*, /users/{id}"))
+	assert.True(t, p.Selective())
+	assert.True(t, p.MatchRoute("/billing/invoices"))
+	assert.True(t, p.MatchRoute("/billing/invoices/{id}"))
+	assert.True(t, p.MatchRoute("/users/{id}"))
+	assert.False(t, p.MatchRoute("/billing"))
+	assert.False(t, p.MatchRoute("/users/{id}/orders"))
+}
+
+func TestParser_MatchPackage(t *testing.T) {
+	t.Parallel()
+
+	p := New(SetOnlyPackages("testdata/simple/api,testdata/nested/..."))
+	assert.True(t, p.Selective())
+	assert.True(t, p.matchPackage("testdata/simple/api/api.go"))
+	assert.False(t, p.matchPackage("testdata/simple/web/handler.go"))
+	assert.True(t, p.matchPackage("testdata/nested/main.go"))
+	assert.True(t, p.matchPackage("testdata/nested/api/api.go"))
+	assert.False(t, p.matchPackage("testdata/nested2/main.go"))
+
+	p = New(SetOnlyPackages("testdata/simple/api"))
+	assert.NoError(t, p.ParseAPI("testdata/simple", mainAPIFile, defaultParseDepth))
+	assert.Contains(t, p.swagger.Paths.Paths, "/testapi/get-string-by-int/{some_id}")
+	assert.False(t, p.DeclaredOutside("GET", "/testapi/get-string-by-int/{some_id}"))
+
+	p = New(SetOnlyPackages("testdata/simple/web"))
+	assert.NoError(t, p.ParseAPI("testdata/simple", mainAPIFile, defaultParseDepth))
+	assert.Empty(t, p.swagger.Paths.Paths)
+	assert.True(t, p.DeclaredOutside("get", "/testapi/get-string-by-int/{some_id}"))
+	assert.True(t, p.DeclaredOutside("POST", "/file/upload"))
+	assert.False(t, p.DeclaredOutside("GET", "/testapi/stale"))
+}
+
+func TestParser_ParseAPIContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := New()
+	err := p.ParseAPIContext(ctx, "testdata/simple", mainAPIFile, defaultParseDepth)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, p.swagger.Paths.Paths)
+
+	p = New(SetParseDependency(1), ParseUsingGoList(true))
+	err = p.ParseAPIContext(ctx, "testdata/simple", mainAPIFile, defaultParseDepth)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	p = New()
+	p.ParseGoPackages = true
+	err = p.ParseAPIContext(ctx, "testdata/simple", mainAPIFile, defaultParseDepth)
+	assert.Error(t, err)
+
+	_, err = getPkgName(ctx, "testdata/simple")
+	assert.Error(t, err)
+}
+
+func TestParser_Limits(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/limits"
+
+	p := New()
+	assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+	assert.Len(t, p.swagger.Definitions, 6)
+
+	p = New(SetLimits(Limits{MaxSchemaDepth: 3, MaxDefinitions: 6, MaxGenericInstantiations: 3}))
+	assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+
+	p = New(SetLimits(Limits{MaxSchemaDepth: 2}))
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.ErrorContains(t, err, "schema depth exceeds 2: main.Root > main.Middle > main.Leaf")
+
+	p = New(SetLimits(Limits{MaxDefinitions: 2}))
+	err = p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.ErrorContains(t, err, "the document has more than 2 definitions")
+
+	p = New(SetLimits(Limits{MaxGenericInstantiations: 2}))
+	err = p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.ErrorContains(t, err, "more than 2 instantiations of generic types")
+}
+
+func TestParser_Jobs(t *testing.T) {
+	t.Parallel()
+
+	for _, searchDir := range []string{"testdata/simple", "testdata/generics_package_alias/internal"} {
+		p := New(SetParseDependency(1))
+		assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+		expected, err := json.MarshalIndent(p.swagger, "", "    ")
+		assert.NoError(t, err)
+
+		for _, jobs := range []int{0, 2, 8} {
+			p := New(SetParseDependency(1), SetJobs(jobs), SetPackageCache(NewPackageCache()))
+			assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+			assert.True(t, p.jobs > 0)
+
+			actual, err := json.MarshalIndent(p.swagger, "", "    ")
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual), "%s with %d jobs", searchDir, jobs)
+		}
+	}
+
+	p := New(SetJobs(4))
+	err := p.ParseAPI("testdata/simple", "missing.go", defaultParseDepth)
+	assert.Error(t, err)
+}
+
+func TestParser_PropertyTags(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/bson"
+
+	p := New()
+	assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+	assert.Contains(t, p.swagger.Definitions["models.Document"].Properties, "name")
+	assert.Contains(t, p.swagger.Definitions["main.Request"].Properties, "draft")
+
+	p = New(SetPropertyTags("bson"))
+	assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+	document := p.swagger.Definitions["models.Document"]
+	assert.Len(t, document.Properties, 5)
+	for _, name := range []string{"_id", "title", "tags", "created_at", "owner"} {
+		assert.Contains(t, document.Properties, name)
+	}
+	assert.NotContains(t, p.swagger.Definitions["main.Request"].Properties, "draft")
+
+	p = New(SetPropertyTags("github.com/swaggo/swag/testdata/bson/models/...=bson"))
+	p.RequiredByDefault = true
+	assert.NoError(t, p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth))
+	document = p.swagger.Definitions["models.Document"]
+	assert.Contains(t, document.Properties, "_id")
+	assert.NotContains(t, document.Properties, "revision")
+	assert.Equal(t, []string{"_id", "created_at", "owner", "title"}, document.Required)
+	assert.Contains(t, p.swagger.Definitions["main.Request"].Properties, "draft")
+}
+
+func TestParser_DefaultSuccess(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Failure 400
+// @Router  /users [delete]
+func DeleteUsers(){
+}
+
+// @Success 204
+// @Router  /users [put]
+func PutUsers(){
+}
+`
+	parse := func(policy string) (*Parser, error) {
+		p := New(SetDefaultSuccess(policy))
+
+		require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		return p, p.packages.RangeFiles(p.ParseRouterAPIInfo)
+	}
+
+	p, err := parse(DefaultSuccessNone)
+	require.NoError(t, err)
+	assert.Len(t, p.swagger.Paths.Paths["/users"].Delete.Responses.StatusCodeResponses, 1)
+
+	p, err = parse(DefaultSuccessEmpty)
+	require.NoError(t, err)
+	assert.Equal(t, "OK", p.swagger.Paths.Paths["/users"].Delete.Responses.StatusCodeResponses[200].Description)
+	assert.NotContains(t, p.swagger.Paths.Paths["/users"].Put.Responses.StatusCodeResponses, 200)
+
+	_, err = parse(DefaultSuccessError)
+	assert.EqualError(t, err, "operation DELETE /users has no success response, document one with @Success")
+}
+
+func TestParser_DefaultResponses(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type APIError struct {
+	Message string ` + "`json:\"message\"`" + `
+}
+
+// @Success 200
+// @Failure 404 {object} APIError "no such user"
+// @Router  /users [get]
+func GetUsers(){
+}
+
+// @Success            204
+// @NoDefaultResponses
+// @Router             /users [delete]
+func DeleteUsers(){
+}
+`
+	p := New()
+	require.NoError(t, parseGeneralAPIInfo(p, []string{
+		`@defaultResponse 404,500 {object} api.APIError "internal error"`,
+		`@defaultResponse default {string} string "unexpected error"`,
+	}))
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	get := p.swagger.Paths.Paths["/users"].Get.Responses
+	assert.Equal(t, "no such user", get.StatusCodeResponses[404].Description)
+	assert.Equal(t, "internal error", get.StatusCodeResponses[500].Description)
+	assert.Equal(t, "#/definitions/api.APIError", get.StatusCodeResponses[500].Schema.Ref.String())
+	require.NotNil(t, get.Default)
+	assert.Equal(t, "unexpected error", get.Default.Description)
+
+	del := p.swagger.Paths.Paths["/users"].Delete.Responses
+	assert.Len(t, del.StatusCodeResponses, 1)
+	assert.Contains(t, del.StatusCodeResponses, 204)
+	assert.Nil(t, del.Default)
+
+	p = New()
+	require.NoError(t, parseGeneralAPIInfo(p, []string{`@defaultResponse 500 {object} api.Missing "internal error"`}))
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err = p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	assert.ErrorContains(t, p.packages.RangeFiles(p.ParseRouterAPIInfo), "@defaultResponse 500 {object} api.Missing")
+}
@@ -1,6 +1,7 @@
 package swag
 
 import (
+	"context"
 	"go/token"
 	"os"
 	"path/filepath"
@@ -9,7 +10,7 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
-func (parser *Parser) loadPackagesAndDeps(searchDirs []string, absMainAPIFilePath string) error {
+func (parser *Parser) loadPackagesAndDeps(ctx context.Context, searchDirs []string, absMainAPIFilePath string) error {
 	mode := packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports |
 		packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo
 	if parser.ParseDependency > 0 {
@@ -29,8 +30,9 @@ func (parser *Parser) loadPackagesAndDeps(searchDirs []string, absMainAPIFilePat
 
 	fset := token.NewFileSet()
 	pkgs, err := packages.Load(&packages.Config{
-		Mode: mode,
-		Fset: fset,
+		Context: ctx,
+		Mode:    mode,
+		Fset:    fset,
 	}, absDirs...)
 	if err != nil {
 		return err
@@ -0,0 +1,88 @@
+package swag
+
+import (
+	"sort"
+)
+
+// pruneUnused removes the security definitions which neither the document nor its operations require, and the
+// tags without operations. Each removal is reported to the debugger.
+func (parser *Parser) pruneUnused() {
+	usedSchemes := make(map[string]bool)
+	usedTags := make(map[string]bool)
+
+	for _, requirement := range parser.swagger.Security {
+		for name := range requirement {
+			usedSchemes[name] = true
+		}
+	}
+
+	if parser.swagger.Paths != nil {
+		for _, item := range parser.swagger.Paths.Paths {
+			for _, method := range sortedMethods() {
+				op := *refRouteMethodOp(&item, method)
+				if op == nil {
+					continue
+				}
+
+				for _, requirement := range op.Security {
+					for name := range requirement {
+						usedSchemes[name] = true
+					}
+				}
+
+				for _, tag := range op.Tags {
+					usedTags[tag] = true
+				}
+			}
+		}
+	}
+
+	var removed []string
+
+	for name := range parser.swagger.SecurityDefinitions {
+		if !usedSchemes[name] {
+			removed = append(removed, name)
+		}
+	}
+
+	sort.Strings(removed)
+
+	for _, name := range removed {
+		delete(parser.swagger.SecurityDefinitions, name)
+		parser.debug.Printf("prune: removed the unused security definition %s", name)
+	}
+
+	if len(parser.swagger.Tags) == 0 {
+		return
+	}
+
+	kept := parser.swagger.Tags[:0]
+
+	for _, tag := range parser.swagger.Tags {
+		if usedTags[tag.Name] {
+			kept = append(kept, tag)
+
+			continue
+		}
+
+		parser.debug.Printf("prune: removed the tag %s without operations", tag.Name)
+	}
+
+	parser.swagger.Tags = kept
+}
+
+// pruneUnusedDefinitions removes the definitions which no path refers to, directly or through other definitions,
+// e.g. the models of dependencies which are parsed but never used. Each removal is reported to the debugger.
+func (parser *Parser) pruneUnusedDefinitions() error {
+	unused, err := unusedDefinitions(parser.swagger)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range unused {
+		delete(parser.swagger.Definitions, name)
+		parser.debug.Printf("prune: removed the unused definition %s", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_PruneUnused(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Tags     users
+// @Security ApiKeyAuth
+// @Success  200
+// @Router   /users [get]
+func Users(){
+}
+`
+	logger := &testLogger{}
+	p := New(SetDebugger(logger))
+	p.swagger.Security = []map[string][]string{{"BasicAuth": {}}}
+	p.swagger.SecurityDefinitions = spec.SecurityDefinitions{
+		"ApiKeyAuth":     spec.APIKeyAuth("X-API-Key", "header"),
+		"BasicAuth":      spec.BasicAuth(),
+		"OAuth2Implicit": spec.OAuth2Implicit("https://example.com/oauth/authorize"),
+	}
+	p.swagger.Tags = []spec.Tag{
+		{TagProps: spec.TagProps{Name: "legacy"}},
+		{TagProps: spec.TagProps{Name: "users"}},
+	}
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	p.pruneUnused()
+
+	assert.Len(t, p.swagger.SecurityDefinitions, 2)
+	assert.NotContains(t, p.swagger.SecurityDefinitions, "OAuth2Implicit")
+	require.Len(t, p.swagger.Tags, 1)
+	assert.Equal(t, "users", p.swagger.Tags[0].Name)
+	assert.Equal(t, []string{
+		"prune: removed the unused security definition OAuth2Implicit",
+		"prune: removed the tag legacy without operations",
+	}, logger.Messages)
+}
+
+func TestParser_PruneUnusedDefinitions(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Address Address ` + "`json:\"address\"`" + `
+}
+
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+// @Success 200 {object} User
+// @Router  /users [get]
+func Users(){
+}
+`
+	logger := &testLogger{}
+	p := New(SetDebugger(logger))
+	p.swagger.Definitions = spec.Definitions{
+		"legacy.Invoice": *spec.MapProperty(nil).SetProperty("line", *spec.RefSchema("#/definitions/legacy.Line")),
+		"legacy.Line":    *spec.StringProperty(),
+	}
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	logger.Messages = nil
+
+	require.NoError(t, p.pruneUnusedDefinitions())
+
+	assert.Equal(t, []string{"api.Address", "api.User"}, sortedKeys(p.swagger.Definitions))
+	assert.Equal(t, []string{
+		"prune: removed the unused definition legacy.Invoice",
+		"prune: removed the unused definition legacy.Line",
+	}, logger.Messages)
+}
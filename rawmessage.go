@@ -0,0 +1,29 @@
+package swag
+
+import "github.com/go-openapi/spec"
+
+// Types of Parser.RawMessageType, how json.RawMessage is documented.
+const (
+	// RawMessageObject documents a free-form object, the default.
+	RawMessageObject = "object"
+
+	// RawMessageString documents a string.
+	RawMessageString = "string"
+)
+
+// rawMessageType the name of the type documented by Parser.RawMessageType, instead of the array of integers of
+// its underlying []byte.
+const rawMessageType = "json.RawMessage"
+
+// rawMessageSchema returns the schema of a json.RawMessage documented as typ.
+func rawMessageSchema(typ string) *spec.Schema {
+	if typ == RawMessageString {
+		return spec.StringProperty()
+	}
+
+	schema := &spec.Schema{}
+	schema.Typed(OBJECT, "")
+	schema.AdditionalProperties = &spec.SchemaOrBool{Allows: true}
+
+	return schema
+}
@@ -0,0 +1,60 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_RawMessage(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import "encoding/json"
+
+type Event struct {
+	Payload  json.RawMessage   ` + "`json:\"payload\"`" + `
+	Previous *json.RawMessage  ` + "`json:\"previous\"`" + `
+	Batch    []json.RawMessage ` + "`json:\"batch\"`" + `
+	Raw      json.RawMessage   ` + "`json:\"raw\" swaggertype:\"string\"`" + `
+}
+`
+	parse := func(t *testing.T, rawMessageType string) map[string]spec.Schema {
+		p := New()
+		p.RawMessageType = rawMessageType
+
+		require.NoError(t, p.packages.ParseFile("api", "testdata/rawmessage/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		schema, err := p.getTypeSchema("api.Event", nil, false)
+		require.NoError(t, err)
+
+		return schema.Properties
+	}
+
+	marshal := func(schema spec.Schema) string {
+		b, err := json.Marshal(schema)
+		require.NoError(t, err)
+
+		return string(b)
+	}
+
+	properties := parse(t, "")
+
+	assert.Equal(t, `{"type":"object","additionalProperties":true}`, marshal(properties["payload"]))
+	assert.Equal(t, `{"type":"object","additionalProperties":true}`, marshal(properties["previous"]))
+	assert.Equal(t, `{"type":"array","items":{"type":"object","additionalProperties":true}}`, marshal(properties["batch"]))
+	assert.Equal(t, `{"type":"string"}`, marshal(properties["raw"]))
+
+	properties = parse(t, RawMessageString)
+
+	assert.Equal(t, `{"type":"string"}`, marshal(properties["payload"]))
+	assert.Equal(t, `{"type":"array","items":{"type":"string"}}`, marshal(properties["batch"]))
+}
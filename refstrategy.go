@@ -0,0 +1,311 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+)
+
+// Strategies of ApplyRefStrategy, how the schemas of a document refer to each other.
+const (
+	// RefStrategyFlatten inlines the definitions where they are referenced, except the recursive ones.
+	RefStrategyFlatten = "flatten"
+
+	// RefStrategyBundle extracts the inline object schemas into named definitions.
+	RefStrategyBundle = "bundle"
+)
+
+// ApplyRefStrategy rewrites the schemas of doc with strategy, RefStrategyFlatten or RefStrategyBundle, for the
+// tools which only understand one of these styles.
+func ApplyRefStrategy(doc *spec.Swagger, strategy string) error {
+	switch strategy {
+	case RefStrategyFlatten:
+		return flattenRefs(doc)
+	case RefStrategyBundle:
+		bundleSchemas(doc)
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported ref strategy %q, expected %s or %s", strategy, RefStrategyFlatten, RefStrategyBundle)
+	}
+}
+
+// documentSchema a schema of the parameters or responses of a document, with the name a definition extracted
+// from it gets.
+type documentSchema struct {
+	schema *spec.Schema
+	name   string
+}
+
+// documentSchemas returns the schemas of the parameters and responses of doc, outside of its definitions.
+func documentSchemas(doc *spec.Swagger) []documentSchema {
+	var schemas []documentSchema
+
+	addParameters := func(parameters []spec.Parameter, name string) {
+		for i := range parameters {
+			if parameters[i].Schema != nil {
+				schemas = append(schemas, documentSchema{parameters[i].Schema, name + pascalName(parameters[i].Name)})
+			}
+		}
+	}
+
+	addResponse := func(response *spec.Response, name string) {
+		if response != nil && response.Schema != nil {
+			schemas = append(schemas, documentSchema{response.Schema, name})
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Parameters) {
+		parameter := doc.Parameters[name]
+		if parameter.Schema != nil {
+			schemas = append(schemas, documentSchema{parameter.Schema, pascalName(name)})
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Responses) {
+		response := doc.Responses[name]
+		addResponse(&response, pascalName(name))
+	}
+
+	if doc.Paths == nil {
+		return schemas
+	}
+
+	for _, path := range sortedKeys(doc.Paths.Paths) {
+		item := doc.Paths.Paths[path]
+
+		addParameters(item.Parameters, pascalName(path))
+
+		for _, method := range sortedMethods() {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil {
+				continue
+			}
+
+			name := pascalName(op.ID)
+			if name == "" {
+				name = pascalName(strings.ToLower(method) + " " + path)
+			}
+
+			addParameters(op.Parameters, name)
+
+			if op.Responses == nil {
+				continue
+			}
+
+			addResponse(op.Responses.Default, name+"Default")
+
+			codes := make([]int, 0, len(op.Responses.StatusCodeResponses))
+			for code := range op.Responses.StatusCodeResponses {
+				codes = append(codes, code)
+			}
+
+			sort.Ints(codes)
+
+			for _, code := range codes {
+				response := op.Responses.StatusCodeResponses[code]
+				addResponse(&response, name+"Response"+strconv.Itoa(code))
+			}
+		}
+	}
+
+	return schemas
+}
+
+// flattenRefs inlines the definitions referenced by the schemas of doc and removes the definitions which are
+// not referenced anymore. The references of a definition to itself, directly or not, are kept.
+func flattenRefs(doc *spec.Swagger) error {
+	for _, documentSchema := range documentSchemas(doc) {
+		if err := flattenSchema(doc, documentSchema.schema, map[string]bool{}); err != nil {
+			return err
+		}
+	}
+
+	return pruneDefinitions(doc)
+}
+
+// flattenSchema inlines the definitions referenced by schema, stack holding the definitions being inlined.
+func flattenSchema(doc *spec.Swagger, schema *spec.Schema, stack map[string]bool) error {
+	name, ok := definitionName(schema)
+	if !ok {
+		return eachChildSchema(schema, func(child *spec.Schema, _ string, _ bool) error {
+			return flattenSchema(doc, child, stack)
+		})
+	}
+
+	definition, found := doc.Definitions[name]
+	if !found || stack[name] {
+		return nil
+	}
+
+	// the definition may be inlined several times, each place gets its own copy
+	b, err := json.Marshal(definition)
+	if err != nil {
+		return err
+	}
+
+	var inlined spec.Schema
+	if err := json.Unmarshal(b, &inlined); err != nil {
+		return err
+	}
+
+	stack[name] = true
+	defer delete(stack, name)
+
+	if err := flattenSchema(doc, &inlined, stack); err != nil {
+		return err
+	}
+
+	*schema = inlined
+
+	return nil
+}
+
+// bundleSchemas extracts the inline object schemas of doc into definitions named after where they are, e.g.
+// main.PetOwner for the owner property of main.Pet, or CreatePetBody for the body parameter of createPet.
+// Identical schemas share a definition.
+func bundleSchemas(doc *spec.Swagger) {
+	if doc.Definitions == nil {
+		doc.Definitions = make(spec.Definitions)
+	}
+
+	extracted := make(map[string]string)
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		definition := doc.Definitions[name]
+		bundleChildren(doc, &definition, name, extracted)
+		doc.Definitions[name] = definition
+	}
+
+	for _, documentSchema := range documentSchemas(doc) {
+		bundleSchema(doc, documentSchema.schema, documentSchema.name, extracted)
+	}
+}
+
+// bundleSchema extracts schema into a definition named name when it is an inline object.
+func bundleSchema(doc *spec.Swagger, schema *spec.Schema, name string, extracted map[string]string) {
+	bundleChildren(doc, schema, name, extracted)
+
+	if !isInlineObject(schema) {
+		return
+	}
+
+	b, _ := json.Marshal(schema)
+
+	definition, ok := extracted[string(b)]
+	if !ok {
+		definition = name
+		for i := 2; ; i++ {
+			if _, exists := doc.Definitions[definition]; !exists {
+				break
+			}
+
+			definition = name + strconv.Itoa(i)
+		}
+
+		doc.Definitions[definition] = *schema
+		extracted[string(b)] = definition
+	}
+
+	*schema = *spec.RefSchema("#/definitions/" + escapePointer(definition))
+}
+
+// bundleChildren extracts the inline objects nested in schema, named after name.
+func bundleChildren(doc *spec.Swagger, schema *spec.Schema, name string, extracted map[string]string) {
+	_ = eachChildSchema(schema, func(child *spec.Schema, suffix string, allOf bool) error {
+		if allOf {
+			// the inline parts of a composition override the other ones, they stay inline
+			bundleChildren(doc, child, name, extracted)
+		} else {
+			bundleSchema(doc, child, name+suffix, extracted)
+		}
+
+		return nil
+	})
+}
+
+// eachChildSchema calls visit with the schemas nested in schema, the suffix of their name, and whether they are
+// a part of allOf. The changes visit makes to the properties are kept.
+func eachChildSchema(schema *spec.Schema, visit func(child *spec.Schema, suffix string, allOf bool) error) error {
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		if err := visit(&property, pascalName(name), false); err != nil {
+			return err
+		}
+
+		schema.Properties[name] = property
+	}
+
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			if err := visit(schema.Items.Schema, "Item", false); err != nil {
+				return err
+			}
+		}
+
+		for i := range schema.Items.Schemas {
+			if err := visit(&schema.Items.Schemas[i], "Item"+strconv.Itoa(i+1), false); err != nil {
+				return err
+			}
+		}
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		if err := visit(schema.AdditionalProperties.Schema, "Value", false); err != nil {
+			return err
+		}
+	}
+
+	for i := range schema.AllOf {
+		if err := visit(&schema.AllOf[i], "", true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// definitionName returns the name of the definition schema refers to.
+func definitionName(schema *spec.Schema) (string, bool) {
+	ref := schema.Ref.String()
+	if !strings.HasPrefix(ref, "#/definitions/") {
+		return "", false
+	}
+
+	return unescapePointer(strings.TrimPrefix(ref, "#/definitions/")), true
+}
+
+// isInlineObject reports whether schema is an object with properties, which is not a reference.
+func isInlineObject(schema *spec.Schema) bool {
+	return schema.Ref.String() == "" && len(schema.Properties) > 0 && (len(schema.Type) == 0 || schema.Type.Contains("object"))
+}
+
+// pascalName turns a name like create_pet, /pets/{id} or get /pets into PascalCase, e.g. GetPets.
+func pascalName(name string) string {
+	var (
+		builder strings.Builder
+		upper   = true
+	)
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
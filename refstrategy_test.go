@@ -0,0 +1,101 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const refStrategyDoc = `{
+	"swagger": "2.0",
+	"paths": {
+		"/pets": {
+			"post": {
+				"operationId": "createPet",
+				"parameters": [{"in": "body", "name": "pet", "schema": {"$ref": "#/definitions/main.Pet"}}],
+				"responses": {
+					"200": {"description": "OK", "schema": {"type": "object", "properties": {"id": {"type": "integer"}}}}
+				}
+			}
+		},
+		"/pets/{id}": {
+			"get": {
+				"responses": {
+					"200": {"description": "OK", "schema": {"type": "object", "properties": {"id": {"type": "integer"}}}}
+				}
+			}
+		}
+	},
+	"definitions": {
+		"main.Node": {
+			"type": "object",
+			"properties": {"children": {"type": "array", "items": {"$ref": "#/definitions/main.Node"}}}
+		},
+		"main.Owner": {"type": "object", "properties": {"name": {"type": "string"}}},
+		"main.Pet": {
+			"type": "object",
+			"properties": {
+				"owner": {"$ref": "#/definitions/main.Owner"},
+				"tree": {"$ref": "#/definitions/main.Node"},
+				"tags": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": "string"}}}}
+			}
+		},
+		"main.Unused": {"type": "object", "properties": {"name": {"type": "string"}}}
+	}
+}`
+
+func readRefStrategyDoc(t *testing.T) *spec.Swagger {
+	var doc spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(refStrategyDoc), &doc))
+
+	return &doc
+}
+
+func TestApplyRefStrategy_Flatten(t *testing.T) {
+	t.Parallel()
+
+	doc := readRefStrategyDoc(t)
+	require.NoError(t, ApplyRefStrategy(doc, RefStrategyFlatten))
+
+	body := doc.Paths.Paths["/pets"].Post.Parameters[0].Schema
+	assert.Empty(t, body.Ref.String())
+	assert.Equal(t, spec.StringOrArray{"string"}, body.Properties["owner"].Properties["name"].Type)
+
+	// the recursive type is inlined once, then referenced
+	tree := body.Properties["tree"]
+	assert.Empty(t, tree.Ref.String())
+	assert.Equal(t, "#/definitions/main.Node", tree.Properties["children"].Items.Schema.Ref.String())
+
+	assert.Equal(t, []string{"main.Node"}, sortedKeys(doc.Definitions))
+}
+
+func TestApplyRefStrategy_Bundle(t *testing.T) {
+	t.Parallel()
+
+	doc := readRefStrategyDoc(t)
+	require.NoError(t, ApplyRefStrategy(doc, RefStrategyBundle))
+
+	assert.Equal(t, "#/definitions/main.PetTagsItem",
+		doc.Definitions["main.Pet"].Properties["tags"].Items.Schema.Ref.String())
+	assert.Equal(t, "#/definitions/main.Pet", doc.Paths.Paths["/pets"].Post.Parameters[0].Schema.Ref.String())
+
+	// identical objects share a definition
+	assert.Equal(t, "#/definitions/CreatePetResponse200",
+		doc.Paths.Paths["/pets"].Post.Responses.StatusCodeResponses[200].Schema.Ref.String())
+	assert.Equal(t, "#/definitions/CreatePetResponse200",
+		doc.Paths.Paths["/pets/{id}"].Get.Responses.StatusCodeResponses[200].Schema.Ref.String())
+
+	assert.Equal(t, []string{"CreatePetResponse200", "main.Node", "main.Owner", "main.Pet", "main.PetTagsItem", "main.Unused"},
+		sortedKeys(doc.Definitions))
+	assert.Equal(t, spec.StringOrArray{"integer"}, doc.Definitions["CreatePetResponse200"].Properties["id"].Type)
+}
+
+func TestApplyRefStrategy_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	err := ApplyRefStrategy(readRefStrategyDoc(t), "inline")
+	assert.EqualError(t, err, `unsupported ref strategy "inline", expected flatten or bundle`)
+}
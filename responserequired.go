@@ -0,0 +1,118 @@
+package swag
+
+import (
+	"go/ast"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// marshaledMark marks the properties of the fields marshaled whatever their value, for RequiredInResponses. Like the
+// formData and header marks, it is no x- extension, so it is never emitted.
+const marshaledMark = "marshaled"
+
+// isAlwaysMarshaled reports whether field is marshaled whatever its value: its propertyTag has neither omitempty nor
+// omitzero, and its binding and validate tags do not make it optional.
+func isAlwaysMarshaled(field *ast.Field, propertyTag string) bool {
+	if field.Tag == nil {
+		return true
+	}
+
+	tag := reflect.StructTag(strings.ReplaceAll(field.Tag.Value, "`", ""))
+
+	for _, option := range strings.Split(tag.Get(propertyTag), ",")[1:] {
+		if option == omitEmptyLabel || option == omitZeroLabel {
+			return false
+		}
+	}
+
+	for _, name := range []string{bindingTag, validateTag} {
+		for _, option := range strings.Split(tag.Get(name), ",") {
+			if option == optionalLabel {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// requireResponseProperties makes the marked properties of the definitions of responses required, since their
+// fields are always in the JSON of the response. The definitions also of request parameters are left as they are,
+// the client may leave out the same fields.
+func (parser *Parser) requireResponseProperties() error {
+	var requests, responses []any
+
+	requests = append(requests, parser.swagger.Parameters)
+	responses = append(responses, parser.swagger.Responses)
+
+	if parser.swagger.Paths != nil {
+		for _, item := range parser.swagger.Paths.Paths {
+			requests = append(requests, item.Parameters)
+
+			for method := range allMethod {
+				op := *refRouteMethodOp(&item, method)
+				if op == nil {
+					continue
+				}
+
+				requests = append(requests, op.Parameters)
+				responses = append(responses, op.Responses)
+			}
+		}
+	}
+
+	requested, err := referencedDefinitions(parser.swagger, requests)
+	if err != nil {
+		return err
+	}
+
+	responded, err := referencedDefinitions(parser.swagger, responses)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(parser.swagger.Definitions) {
+		if !responded[name] {
+			continue
+		}
+
+		if requested[name] {
+			parser.debug.Printf("required in responses: %s is also in requests, its required properties are kept", name)
+
+			continue
+		}
+
+		schema := parser.swagger.Definitions[name]
+		requireMarshaledProperties(&schema)
+		parser.swagger.Definitions[name] = schema
+	}
+
+	return nil
+}
+
+// requireMarshaledProperties adds the marked properties of schema and of its inline schemas to their required.
+func requireMarshaledProperties(schema *spec.Schema) {
+	for name, property := range schema.Properties {
+		if marshaled, _ := property.Extensions[marshaledMark].(bool); marshaled && !findInSlice(schema.Required, name) {
+			schema.Required = append(schema.Required, name)
+		}
+
+		requireMarshaledProperties(&property)
+		schema.Properties[name] = property
+	}
+
+	if len(schema.Required) > 0 {
+		sort.Strings(schema.Required)
+	}
+
+	for i := range schema.AllOf {
+		requireMarshaledProperties(&schema.AllOf[i])
+	}
+
+	if schema.Items != nil && schema.Items.Schema != nil {
+		requireMarshaledProperties(schema.Items.Schema)
+	}
+}
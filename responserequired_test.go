@@ -0,0 +1,87 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_RequiredInResponses(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Base struct {
+	ID int ` + "`json:\"id\"`" + `
+}
+
+type Address struct {
+	City string ` + "`json:\"city\"`" + `
+	Zip  string ` + "`json:\"zip,omitempty\"`" + `
+}
+
+type User struct {
+	Base
+	Name     string   ` + "`json:\"name\"`" + `
+	Nickname string   ` + "`json:\"nickname,omitempty\"`" + `
+	Avatar   string   ` + "`json:\"avatar\" binding:\"optional\"`" + `
+	Email    string   ` + "`json:\"email\" binding:\"required\"`" + `
+	Address  *Address ` + "`json:\"address,omitzero\"`" + `
+	Meta     struct {
+		Version int ` + "`json:\"version\"`" + `
+	} ` + "`json:\"meta\"`" + `
+}
+
+type Login struct {
+	Email    string ` + "`json:\"email\"`" + `
+	Password string ` + "`json:\"password\"`" + `
+}
+
+// @Param   body body Login true "credentials"
+// @Success 200 {object} User
+// @Failure 400 {object} Login
+// @Router  /login [post]
+func Login(){
+}
+`
+	parse := func(t *testing.T, requiredInResponses bool) *spec.Swagger {
+		p := New()
+		p.RequiredInResponses = requiredInResponses
+
+		require.NoError(t, p.packages.ParseFile("api", "testdata/responserequired/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+		require.NoError(t, p.requireResponseProperties())
+
+		return p.swagger
+	}
+
+	definitions := parse(t, true).Definitions
+
+	user := definitions["api.User"]
+	assert.Equal(t, []string{"email", "id", "meta", "name"}, user.Required)
+	assert.Equal(t, []string{"version"}, user.Properties["meta"].Required)
+
+	address := definitions["api.Address"]
+	assert.Equal(t, []string{"city"}, address.Required)
+
+	// also a request body, the client may leave the fields out
+	assert.Empty(t, definitions["api.Login"].Required)
+
+	// the mark is never emitted
+	b, err := json.Marshal(user)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), marshaledMark)
+
+	definitions = parse(t, false).Definitions
+
+	assert.Equal(t, []string{"email"}, definitions["api.User"].Required)
+	assert.Empty(t, definitions["api.Address"].Required)
+}
@@ -68,6 +68,12 @@ func IsPrimitiveType(typeName string) bool {
 	return false
 }
 
+// isRemoteRef reports whether the type name is the URL of a remote schema, e.g. https://schemas.example.com/user.json,
+// which is referred to as it is.
+func isRemoteRef(typeName string) bool {
+	return strings.HasPrefix(typeName, "https://") || strings.HasPrefix(typeName, "http://")
+}
+
 // IsInterfaceLike determines whether the swagger type name is an go named interface type like error type.
 func IsInterfaceLike(typeName string) bool {
 	return typeName == ERROR || typeName == ANY
@@ -214,6 +220,11 @@ func IsComplexSchema(schema *spec.Schema) bool {
 		return true
 	}
 
+	// so is a flags type, an array of its enum
+	if _, ok := schema.Extensions[BitmaskExtension]; ok {
+		return true
+	}
+
 	// a deep array type is complex, how to determine deep? here more than 2 ,for example: [][]object,[][][]int
 	if len(schema.Type) > 2 {
 		return true
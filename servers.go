@@ -0,0 +1,48 @@
+package swag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ServersExtension keeps the servers of an operation, which Swagger 2.0 can not express, the servers of the
+// operation in OpenAPI 3.0, e.g. the file server of a download.
+const ServersExtension = "x-servers"
+
+// Server a server of an operation, overriding the servers of the document.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// ParseServerComment parses a server of the operation, an absolute URL or a path, and an optional description:
+// @Server https://files.example.com "the file server".
+func (operation *Operation) ParseServerComment(commentLine string) error {
+	fields := FieldsByAnySpace(commentLine, 2)
+	if len(fields) == 0 {
+		return fmt.Errorf("can not parse server comment \"%s\", expected a URL", commentLine)
+	}
+
+	server := Server{URL: fields[0]}
+	if len(fields) == 2 {
+		server.Description = unquoteAttribute(fields[1])
+	}
+
+	// URL templates, e.g. https://{region}.example.com, are not checked
+	if !strings.HasPrefix(server.URL, "/") && !strings.Contains(server.URL, "{") {
+		if err := validateAbsoluteURL(server.URL); err != nil {
+			return fmt.Errorf("server %s: %w", server.URL, err)
+		}
+	}
+
+	servers, _ := operation.Extensions[ServersExtension].([]Server)
+	for _, declared := range servers {
+		if declared.URL == server.URL {
+			return fmt.Errorf("server %s is declared several times", server.URL)
+		}
+	}
+
+	setExtension(operation.Extensions, ServersExtension, append(servers, server))
+
+	return nil
+}
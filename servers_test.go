@@ -0,0 +1,46 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServerComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	for _, comment := range []string{
+		`@Server https://files.example.com "the file server"`,
+		`@Server https://{region}.cdn.example.com`,
+		`@server /downloads`,
+	} {
+		require.NoError(t, operation.ParseComment(comment, nil), comment)
+	}
+
+	assert.Equal(t, []Server{
+		{URL: "https://files.example.com", Description: "the file server"},
+		{URL: "https://{region}.cdn.example.com"},
+		{URL: "/downloads"},
+	}, operation.Extensions[ServersExtension])
+
+	b, err := json.Marshal(operation)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"x-servers":[{"url":"https://files.example.com","description":"the file server"}`)
+}
+
+func TestParseServerComment_errors(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+
+	assert.ErrorContains(t, operation.ParseComment(`@Server`, nil), "expected a URL")
+	assert.ErrorContains(t, operation.ParseComment(`@Server files.example.com`, nil),
+		"server files.example.com: must be an absolute http or https URL")
+
+	require.NoError(t, operation.ParseComment(`@Server https://files.example.com`, nil))
+	assert.ErrorContains(t, operation.ParseComment(`@Server https://files.example.com "again"`, nil),
+		"server https://files.example.com is declared several times")
+}
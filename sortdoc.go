@@ -0,0 +1,99 @@
+package swag
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// SortDocument sorts the lists of doc whose order comes from the order of the annotations: the tags, the
+// parameters, the required properties, the mime types and schemes, the security requirements and their scopes,
+// so that moving annotations around does not change the document. The maps, e.g. paths, definitions, properties
+// and extensions, are always written sorted by name.
+func SortDocument(doc *spec.Swagger) {
+	sort.SliceStable(doc.Tags, func(i, j int) bool {
+		return doc.Tags[i].Name < doc.Tags[j].Name
+	})
+
+	sort.Strings(doc.Consumes)
+	sort.Strings(doc.Produces)
+	sort.Strings(doc.Schemes)
+	sortSecurity(doc.Security)
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		definition := doc.Definitions[name]
+		sortSchema(&definition)
+		doc.Definitions[name] = definition
+	}
+
+	for _, schema := range documentSchemas(doc) {
+		sortSchema(schema.schema)
+	}
+
+	if doc.Paths == nil {
+		return
+	}
+
+	for _, path := range sortedKeys(doc.Paths.Paths) {
+		item := doc.Paths.Paths[path]
+		sortParameters(item.Parameters)
+
+		for _, method := range sortedMethods() {
+			op := *refRouteMethodOp(&item, method)
+			if op == nil {
+				continue
+			}
+
+			sort.Strings(op.Tags)
+			sort.Strings(op.Consumes)
+			sort.Strings(op.Produces)
+			sort.Strings(op.Schemes)
+			sortParameters(op.Parameters)
+			sortSecurity(op.Security)
+		}
+
+		doc.Paths.Paths[path] = item
+	}
+}
+
+// sortParameters sorts parameters by location and name, the references last in their order.
+func sortParameters(parameters []spec.Parameter) {
+	sort.SliceStable(parameters, func(i, j int) bool {
+		a, b := parameters[i], parameters[j]
+
+		if refA, refB := a.Ref.String() != "", b.Ref.String() != ""; refA || refB {
+			return !refA && refB
+		}
+
+		if a.In != b.In {
+			return a.In < b.In
+		}
+
+		return a.Name < b.Name
+	})
+}
+
+// sortSecurity sorts the scopes of each security requirement, then the requirements by the names of their schemes.
+func sortSecurity(requirements []map[string][]string) {
+	for _, requirement := range requirements {
+		for _, scopes := range requirement {
+			sort.Strings(scopes)
+		}
+	}
+
+	sort.SliceStable(requirements, func(i, j int) bool {
+		return strings.Join(sortedKeys(requirements[i]), ",") < strings.Join(sortedKeys(requirements[j]), ",")
+	})
+}
+
+// sortSchema sorts the required properties of schema and of its children.
+func sortSchema(schema *spec.Schema) {
+	sort.Strings(schema.Required)
+
+	_ = eachChildSchema(schema, func(child *spec.Schema, _ string, _ bool) error {
+		sortSchema(child)
+
+		return nil
+	})
+}
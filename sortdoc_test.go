@@ -0,0 +1,63 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortDocument(t *testing.T) {
+	t.Parallel()
+
+	pet := *spec.MapProperty(nil).WithRequired("name", "id").
+		SetProperty("owner", *spec.MapProperty(nil).WithRequired("phone", "email"))
+
+	body := spec.BodyParam("pet", spec.ArrayProperty(spec.MapProperty(nil).WithRequired("z", "a")))
+
+	op := &spec.Operation{OperationProps: spec.OperationProps{
+		Tags:     []string{"pets", "admin"},
+		Produces: []string{"application/xml", "application/json"},
+		Parameters: []spec.Parameter{
+			*spec.ParamRef("#/parameters/limit"),
+			*spec.QueryParam("sort"),
+			*body,
+			*spec.PathParam("id"),
+			*spec.QueryParam("page"),
+		},
+		Security: []map[string][]string{
+			{"OAuth2": {"write", "read"}},
+			{"ApiKey": {}},
+		},
+	}}
+
+	doc := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Tags:        []spec.Tag{spec.NewTag("pets", "", nil), spec.NewTag("admin", "", nil)},
+		Schemes:     []string{"https", "http"},
+		Definitions: spec.Definitions{"Pet": pet},
+		Paths:       &spec.Paths{Paths: map[string]spec.PathItem{"/pets/{id}": {PathItemProps: spec.PathItemProps{Put: op}}}},
+	}}
+
+	SortDocument(doc)
+
+	assert.Equal(t, "admin", doc.Tags[0].Name)
+	assert.Equal(t, []string{"http", "https"}, doc.Schemes)
+	assert.Equal(t, []string{"id", "name"}, doc.Definitions["Pet"].Required)
+	assert.Equal(t, []string{"email", "phone"}, doc.Definitions["Pet"].Properties["owner"].Required)
+
+	assert.Equal(t, []string{"admin", "pets"}, op.Tags)
+	assert.Equal(t, []string{"application/json", "application/xml"}, op.Produces)
+
+	var parameters []string
+	for _, param := range op.Parameters {
+		parameters = append(parameters, param.In+":"+param.Name+param.Ref.String())
+	}
+
+	assert.Equal(t, []string{"body:pet", "path:id", "query:page", "query:sort", ":#/parameters/limit"}, parameters)
+	assert.Equal(t, []string{"a", "z"}, op.Parameters[0].Schema.Items.Schema.Required)
+
+	assert.Equal(t, []map[string][]string{
+		{"ApiKey": {}},
+		{"OAuth2": {"read", "write"}},
+	}, op.Security)
+}
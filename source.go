@@ -0,0 +1,38 @@
+package swag
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// readSourceFile reads a go source file and normalizes its encoding to UTF-8.
+func readSourceFile(path string) ([]byte, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeSourceEncoding(path, src)
+}
+
+// normalizeSourceEncoding strips a leading UTF-8 BOM and transcodes GBK encoded
+// sources (mostly comments written on Chinese Windows setups) to UTF-8.
+func normalizeSourceEncoding(path string, src []byte) ([]byte, error) {
+	src = bytes.TrimPrefix(src, utf8BOM)
+	if utf8.Valid(src) {
+		return src, nil
+	}
+
+	decoded, err := simplifiedchinese.GBK.NewDecoder().Bytes(src)
+	if err != nil || bytes.ContainsRune(decoded, utf8.RuneError) {
+		return nil, fmt.Errorf("file %s is neither UTF-8 nor GBK encoded", path)
+	}
+
+	return decoded, nil
+}
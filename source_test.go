@@ -0,0 +1,38 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestNormalizeSourceEncoding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UTF-8", func(t *testing.T) {
+		src, err := normalizeSourceEncoding("main.go", []byte("// @description 描述\npackage main\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "// @description 描述\npackage main\n", string(src))
+	})
+
+	t.Run("UTF-8 BOM", func(t *testing.T) {
+		src, err := normalizeSourceEncoding("main.go", append([]byte{0xEF, 0xBB, 0xBF}, "package main\n"...))
+		assert.NoError(t, err)
+		assert.Equal(t, "package main\n", string(src))
+	})
+
+	t.Run("GBK", func(t *testing.T) {
+		gbk, err := simplifiedchinese.GBK.NewEncoder().Bytes([]byte("// @description 中文描述\npackage main\n"))
+		assert.NoError(t, err)
+
+		src, err := normalizeSourceEncoding("main.go", gbk)
+		assert.NoError(t, err)
+		assert.Equal(t, "// @description 中文描述\npackage main\n", string(src))
+	})
+
+	t.Run("Unknown encoding", func(t *testing.T) {
+		_, err := normalizeSourceEncoding("main.go", []byte{'/', '/', ' ', 0xFF, 0xFF, '\n'})
+		assert.EqualError(t, err, "file main.go is neither UTF-8 nor GBK encoded")
+	})
+}
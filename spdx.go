@@ -0,0 +1,132 @@
+package swag
+
+import (
+	"strings"
+)
+
+// LicenseIdentifierExtension is the license extension holding @license.identifier, the SPDX expression of the
+// license. It stands for the identifier field of OpenAPI 3.1, which 2.0 and 3.0 documents do not have.
+const LicenseIdentifierExtension = "x-identifier"
+
+// spdxLicenses the identifiers of the SPDX license list which APIs are most likely published under.
+var spdxLicenses = map[string]struct{}{}
+
+// spdxExceptions the identifiers of the SPDX license exceptions, which follow WITH in expressions.
+var spdxExceptions = map[string]struct{}{}
+
+func init() {
+	for _, id := range strings.Fields(`
+		0BSD AFL-3.0 AGPL-1.0-only AGPL-1.0-or-later AGPL-3.0 AGPL-3.0-only AGPL-3.0-or-later Apache-1.0 Apache-1.1
+		Apache-2.0 APSL-2.0 Artistic-1.0 Artistic-2.0 BlueOak-1.0.0 BSD-1-Clause BSD-2-Clause BSD-2-Clause-Patent
+		BSD-3-Clause BSD-3-Clause-Clear BSD-4-Clause BSL-1.0 BUSL-1.1 CC0-1.0 CC-BY-3.0 CC-BY-4.0 CC-BY-NC-4.0
+		CC-BY-NC-ND-4.0 CC-BY-NC-SA-4.0 CC-BY-ND-4.0 CC-BY-SA-3.0 CC-BY-SA-4.0 CDDL-1.0 CDDL-1.1 CECILL-2.1
+		CPAL-1.0 CPL-1.0 ECL-2.0 EFL-2.0 Elastic-2.0 EPL-1.0 EPL-2.0 EUPL-1.1 EUPL-1.2 GFDL-1.3-only
+		GFDL-1.3-or-later GPL-2.0 GPL-2.0-only GPL-2.0-or-later GPL-3.0 GPL-3.0-only GPL-3.0-or-later HPND ISC
+		LGPL-2.0-only LGPL-2.0-or-later LGPL-2.1 LGPL-2.1-only LGPL-2.1-or-later LGPL-3.0 LGPL-3.0-only
+		LGPL-3.0-or-later LPPL-1.3c MIT MIT-0 MPL-1.1 MPL-2.0 MPL-2.0-no-copyleft-exception MS-PL MS-RL MulanPSL-2.0
+		NCSA ODbL-1.0 OFL-1.1 OpenSSL OSL-3.0 PHP-3.01 PostgreSQL Python-2.0 Ruby SSPL-1.0 Unicode-DFS-2016
+		Unlicense UPL-1.0 Vim W3C WTFPL X11 Zlib ZPL-2.1`) {
+		spdxLicenses[strings.ToLower(id)] = struct{}{}
+	}
+
+	for _, id := range strings.Fields(`
+		Autoconf-exception-3.0 Bison-exception-2.2 Classpath-exception-2.0 GCC-exception-3.1 LLVM-exception
+		OpenJDK-assembly-exception-1.0 Qt-LGPL-exception-1.1 Universal-FOSS-exception-1.0`) {
+		spdxExceptions[strings.ToLower(id)] = struct{}{}
+	}
+}
+
+// isSPDXExpression reports whether expression is an SPDX license expression of known identifiers, like
+// MIT, Apache-2.0 OR MIT or (GPL-2.0-only WITH Classpath-exception-2.0). Identifiers are case-insensitive,
+// and LicenseRef- identifiers are always valid.
+func isSPDXExpression(expression string) bool {
+	tokens := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))
+
+	index := 0
+	if !parseSPDXOr(tokens, &index) {
+		return false
+	}
+
+	return index == len(tokens)
+}
+
+// parseSPDXOr parses a sequence of and-expressions separated by OR.
+func parseSPDXOr(tokens []string, index *int) bool {
+	for {
+		if !parseSPDXAnd(tokens, index) {
+			return false
+		}
+
+		if *index == len(tokens) || !strings.EqualFold(tokens[*index], "OR") {
+			return true
+		}
+
+		*index++
+	}
+}
+
+// parseSPDXAnd parses a sequence of simple expressions separated by AND.
+func parseSPDXAnd(tokens []string, index *int) bool {
+	for {
+		if !parseSPDXSimple(tokens, index) {
+			return false
+		}
+
+		if *index == len(tokens) || !strings.EqualFold(tokens[*index], "AND") {
+			return true
+		}
+
+		*index++
+	}
+}
+
+// parseSPDXSimple parses a license, optionally followed by WITH and an exception, or a parenthesized expression.
+func parseSPDXSimple(tokens []string, index *int) bool {
+	if *index == len(tokens) {
+		return false
+	}
+
+	if tokens[*index] == "(" {
+		*index++
+		if !parseSPDXOr(tokens, index) || *index == len(tokens) || tokens[*index] != ")" {
+			return false
+		}
+
+		*index++
+
+		return true
+	}
+
+	if !isSPDXLicense(tokens[*index]) {
+		return false
+	}
+
+	*index++
+
+	if *index < len(tokens) && strings.EqualFold(tokens[*index], "WITH") {
+		*index++
+		if *index == len(tokens) {
+			return false
+		}
+
+		if _, ok := spdxExceptions[strings.ToLower(tokens[*index])]; !ok {
+			return false
+		}
+
+		*index++
+	}
+
+	return true
+}
+
+// isSPDXLicense reports whether id is a known license identifier, optionally followed by + for later versions.
+func isSPDXLicense(id string) bool {
+	id = strings.ToLower(id)
+	if strings.HasPrefix(id, "licenseref-") {
+		return len(id) > len("licenseref-")
+	}
+
+	_, ok := spdxLicenses[strings.TrimSuffix(id, "+")]
+
+	return ok
+}
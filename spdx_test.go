@@ -0,0 +1,29 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSPDXExpression(t *testing.T) {
+	t.Parallel()
+
+	for expression, valid := range map[string]bool{
+		"MIT":                                  true,
+		"apache-2.0":                           true,
+		"GPL-2.0+":                             true,
+		"Apache-2.0 OR MIT":                    true,
+		"(MIT AND BSD-3-Clause) OR Apache-2.0": true,
+		"GPL-2.0-only WITH Classpath-exception-2.0": true,
+		"LicenseRef-Proprietary":                    true,
+		"":                                          false,
+		"Apache 2.0":                                false,
+		"MIT OR":                                    false,
+		"(MIT":                                      false,
+		"MIT WITH Unknown-exception":                false,
+		"LicenseRef-":                               false,
+	} {
+		assert.Equal(t, valid, isSPDXExpression(expression), expression)
+	}
+}
@@ -2,11 +2,24 @@ package swag
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
 	"strings"
 	"text/template"
 )
 
+// BuildVersionPlaceholder can be used as @version to resolve the version of the served document at runtime.
+const BuildVersionPlaceholder = "{{.BuildVersion}}"
+
+// BuildVersion is the version that replaces BuildVersionPlaceholder, it can be set with
+// -ldflags "-X github.com/swaggo/swag.BuildVersion=v1.2.3". When empty, the main module
+// version from the binary build info is used.
+var BuildVersion string
+
 // Spec holds exported Swagger Info so clients can modify it.
 type Spec struct {
 	Version          string
@@ -19,11 +32,31 @@ type Spec struct {
 	SwaggerTemplate  string
 	LeftDelim        string
 	RightDelim       string
+
+	// CompressedTemplate is SwaggerTemplate compressed by gzip and encoded in base64, which Register decompresses
+	// into SwaggerTemplate when it is empty, at init. ReadDoc decompresses it on every call otherwise.
+	CompressedTemplate string
+
+	// Static is set when SwaggerTemplate is the final document, which ReadDoc returns with BuildVersionPlaceholder
+	// resolved instead of executing it as a template. The other fields do not change it then.
+	Static bool
 }
 
 // ReadDoc parses SwaggerTemplate into swagger document.
 func (i *Spec) ReadDoc() string {
+	swaggerTemplate := i.SwaggerTemplate
+	if swaggerTemplate == "" && i.CompressedTemplate != "" {
+		// a spec which is not registered, decompressed into a local variable since docs may be read concurrently
+		swaggerTemplate = i.mustDecompressTemplate()
+	}
+
+	if i.Static {
+		// the only placeholder of a static document, the build version is known at runtime only
+		return strings.ReplaceAll(swaggerTemplate, BuildVersionPlaceholder, buildVersion())
+	}
+
 	i.Description = strings.ReplaceAll(i.Description, "\n", "\\n")
+	i.Version = strings.ReplaceAll(i.Version, BuildVersionPlaceholder, buildVersion())
 
 	tpl := template.New("swagger_info").Funcs(template.FuncMap{
 		"marshal": func(v any) string {
@@ -45,20 +78,56 @@ func (i *Spec) ReadDoc() string {
 		tpl = tpl.Delims(i.LeftDelim, i.RightDelim)
 	}
 
-	parsed, err := tpl.Parse(i.SwaggerTemplate)
+	parsed, err := tpl.Parse(swaggerTemplate)
 	if err != nil {
-		return i.SwaggerTemplate
+		return swaggerTemplate
 	}
 
 	var doc bytes.Buffer
 	if err = parsed.Execute(&doc, i); err != nil {
-		return i.SwaggerTemplate
+		return swaggerTemplate
 	}
 
 	return doc.String()
 }
 
+// mustDecompressTemplate decompresses CompressedTemplate, and panics when it is corrupt, since docs.go is generated.
+func (i *Spec) mustDecompressTemplate() string {
+	swaggerTemplate, err := decompressTemplate(i.CompressedTemplate)
+	if err != nil {
+		panic(fmt.Sprintf("cannot decompress the template of swag instance %s: %v", i.InfoInstanceName, err))
+	}
+
+	return swaggerTemplate
+}
+
+func decompressTemplate(compressed string) (string, error) {
+	zr, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(compressed)))
+	if err != nil {
+		return "", err
+	}
+
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
 // InstanceName returns Spec instance name.
 func (i *Spec) InstanceName() string {
 	return i.InfoInstanceName
 }
+
+func buildVersion() string {
+	if BuildVersion != "" {
+		return BuildVersion
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Version
+	}
+
+	return ""
+}
@@ -1,9 +1,13 @@
 package swag
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSpec_InstanceName(t *testing.T) {
@@ -186,3 +190,81 @@ func TestSpec_ReadDoc(t *testing.T) {
 		})
 	}
 }
+
+func TestSpec_ReadDocBuildVersion(t *testing.T) {
+	buildVersion := BuildVersion
+	defer func() { BuildVersion = buildVersion }()
+
+	BuildVersion = "v1.2.3"
+
+	doc := Spec{
+		Version:         BuildVersionPlaceholder,
+		SwaggerTemplate: `{"info": {"version": "{{.Version}}"}}`,
+	}
+
+	assert.Equal(t, `{"info": {"version": "v1.2.3"}}`, doc.ReadDoc())
+	assert.Equal(t, "v1.2.3", doc.Version)
+}
+
+func TestSpec_ReadDocStatic(t *testing.T) {
+	t.Parallel()
+
+	doc := Spec{
+		Title:           "Stores",
+		SwaggerTemplate: `{"info": {"title": "Pets {{.Title}}"}}`,
+		Static:          true,
+	}
+
+	assert.Equal(t, `{"info": {"title": "Pets {{.Title}}"}}`, doc.ReadDoc())
+}
+
+func TestSpec_ReadDocStaticBuildVersion(t *testing.T) {
+	buildVersion := BuildVersion
+	defer func() { BuildVersion = buildVersion }()
+
+	BuildVersion = "v1.2.3"
+
+	doc := Spec{
+		Version:         BuildVersionPlaceholder,
+		SwaggerTemplate: `{"info": {"version": "{{.BuildVersion}}"}}`,
+		Static:          true,
+	}
+
+	assert.Equal(t, `{"info": {"version": "v1.2.3"}}`, doc.ReadDoc())
+}
+
+func TestSpec_ReadDocCompressed(t *testing.T) {
+	t.Parallel()
+
+	doc := Spec{
+		Title:              "Pets",
+		CompressedTemplate: compressTemplate(t, `{"info": {"title": "{{.Title}}"}}`),
+	}
+
+	assert.Equal(t, `{"info": {"title": "Pets"}}`, doc.ReadDoc())
+	assert.Empty(t, doc.SwaggerTemplate)
+
+	doc.Title = "Stores"
+	assert.Equal(t, `{"info": {"title": "Stores"}}`, doc.ReadDoc())
+
+	invalid := Spec{InfoInstanceName: "pets", CompressedTemplate: "not base64"}
+	assert.PanicsWithValue(t, "cannot decompress the template of swag instance pets: illegal base64 data at input byte 3", func() {
+		invalid.ReadDoc()
+	})
+}
+
+// compressTemplate compresses swaggerTemplate like swag init --compressDoc.
+func compressTemplate(t *testing.T, swaggerTemplate string) string {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	encoder := base64.NewEncoder(base64.StdEncoding, &buffer)
+	zw := gzip.NewWriter(encoder)
+	_, err := zw.Write([]byte(swaggerTemplate))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, encoder.Close())
+
+	return buffer.String()
+}
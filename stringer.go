@@ -0,0 +1,331 @@
+package swag
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/go-openapi/spec"
+)
+
+// Modes of Parser.StringerEnums, how the integer enums whose type implements fmt.Stringer are documented.
+const (
+	// StringerEnumsVarNames documents the string forms in the x-enum-varnames extension.
+	StringerEnumsVarNames = "varnames"
+
+	// StringerEnumsString makes the enums string enums of the string forms, with the EnumMappingExtension.
+	StringerEnumsString = "string"
+)
+
+// EnumMappingExtension maps the string forms of the StringerEnumsString enums to their integer values.
+const EnumMappingExtension = "x-enum-mapping"
+
+// applyStringerEnums documents the string forms of the enum of definition, an integer type of typeSpecDef, whose
+// String method is found, according to the StringerEnums mode. The definition is left unchanged when the string form
+// of a value can not be found.
+func (parser *Parser) applyStringerEnums(definition *spec.Schema, typeSpecDef *TypeSpecDef) {
+	if parser.StringerEnums == "" || len(typeSpecDef.Enums) == 0 || !definition.Type.Contains(INTEGER) {
+		return
+	}
+
+	pkg := parser.packages.packages[typeSpecDef.PkgPath]
+	if pkg == nil {
+		return
+	}
+
+	method := stringMethod(pkg, typeSpecDef.Name())
+	if method == nil {
+		return
+	}
+
+	stringOf := stringerForms(pkg, typeSpecDef, method)
+
+	names := make([]string, 0, len(typeSpecDef.Enums))
+
+	for _, value := range typeSpecDef.Enums {
+		name, ok := stringOf(value)
+		if !ok {
+			parser.debug.Printf("warning: the string form of %s of %s is not found", value.key, typeSpecDef.TypeName())
+
+			return
+		}
+
+		names = append(names, name)
+	}
+
+	if definition.Extensions == nil {
+		definition.Extensions = make(spec.Extensions)
+	}
+
+	switch parser.StringerEnums {
+	case StringerEnumsVarNames:
+		definition.Extensions[enumVarNamesExtension] = names
+	case StringerEnumsString:
+		mapping := make(map[string]any, len(names))
+		enum := make([]any, 0, len(names))
+
+		for i, name := range names {
+			mapping[name] = typeSpecDef.Enums[i].Value
+			enum = append(enum, name)
+		}
+
+		definition.Type = spec.StringOrArray{STRING}
+		definition.Format = ""
+		definition.Enum = enum
+		definition.Extensions[EnumMappingExtension] = mapping
+	}
+}
+
+// stringMethod returns the String() string method of the type named typeName of pkg, nil without it.
+func stringMethod(pkg *PackageDefinitions, typeName string) *ast.FuncDecl {
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != "String" || funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 ||
+				funcDecl.Body == nil || funcDecl.Type.Params.NumFields() != 0 || funcDecl.Type.Results.NumFields() != 1 {
+				continue
+			}
+
+			if result, ok := funcDecl.Type.Results.List[0].Type.(*ast.Ident); !ok || result.Name != STRING {
+				continue
+			}
+
+			recv := funcDecl.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+
+			if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+				return funcDecl
+			}
+		}
+	}
+
+	return nil
+}
+
+// stringerForms returns the string form of a value of the enum of typeSpecDef, found in its String method: the
+// string literals returned by the cases of a switch, the values of a map or an array literal indexed by the
+// receiver, or the _T_name and _T_index tables generated by golang.org/x/tools/cmd/stringer.
+func stringerForms(pkg *PackageDefinitions, typeSpecDef *TypeSpecDef, method *ast.FuncDecl) func(EnumValue) (string, bool) {
+	byName := make(map[string]string)
+	byIndex := make(map[int64]string)
+
+	ast.Inspect(method.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.CaseClause:
+			returned, ok := returnedString(node.Body)
+			if !ok {
+				return true
+			}
+
+			for _, expr := range node.List {
+				if name := constName(expr); name != "" {
+					byName[name] = returned
+				} else if index, ok := intLiteral(expr); ok {
+					byIndex[index] = returned
+				}
+			}
+		case *ast.IndexExpr:
+			table := node.X
+			if ident, ok := table.(*ast.Ident); ok {
+				table = packageValue(pkg, ident.Name)
+			}
+
+			if lit, ok := table.(*ast.CompositeLit); ok {
+				addLiteralForms(lit, byName, byIndex)
+			}
+		}
+
+		return true
+	})
+
+	addStringerTableForms(pkg, typeSpecDef, byIndex)
+
+	return func(value EnumValue) (string, bool) {
+		if name, ok := byName[value.key]; ok {
+			return name, true
+		}
+
+		index, ok := intValue(value.Value)
+		if !ok {
+			return "", false
+		}
+
+		name, ok := byIndex[index]
+
+		return name, ok
+	}
+}
+
+// addLiteralForms adds the string elements of a map, array or slice literal, keyed by constants or integers, or by
+// their position.
+func addLiteralForms(lit *ast.CompositeLit, byName map[string]string, byIndex map[int64]string) {
+	var position int64
+
+	for _, elt := range lit.Elts {
+		value, key := elt, ast.Expr(nil)
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			value, key = kv.Value, kv.Key
+		}
+
+		s, ok := stringLiteral(value)
+		if !ok {
+			return
+		}
+
+		switch {
+		case key == nil:
+			byIndex[position] = s
+		case constName(key) != "":
+			byName[constName(key)] = s
+		default:
+			index, ok := intLiteral(key)
+			if !ok {
+				return
+			}
+
+			position = index
+			byIndex[index] = s
+		}
+
+		position++
+	}
+}
+
+// addStringerTableForms adds the string forms of the single run of consecutive values generated by stringer, the
+// substrings of the _T_name constant between the offsets of the _T_index array.
+func addStringerTableForms(pkg *PackageDefinitions, typeSpecDef *TypeSpecDef, byIndex map[int64]string) {
+	name, ok := stringLiteral(packageValue(pkg, "_"+typeSpecDef.Name()+"_name"))
+	if !ok {
+		return
+	}
+
+	lit, ok := packageValue(pkg, "_"+typeSpecDef.Name()+"_index").(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+
+	offsets := make([]int, 0, len(lit.Elts))
+
+	for _, elt := range lit.Elts {
+		offset, ok := intLiteral(elt)
+		if !ok || offset < 0 || offset > int64(len(name)) {
+			return
+		}
+
+		offsets = append(offsets, int(offset))
+	}
+
+	values := make([]int64, 0, len(typeSpecDef.Enums))
+
+	for _, enum := range typeSpecDef.Enums {
+		value, ok := intValue(enum.Value)
+		if !ok {
+			return
+		}
+
+		values = append(values, value)
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	for _, value := range values {
+		i := value - values[0]
+		if i+1 >= int64(len(offsets)) || offsets[i] > offsets[i+1] {
+			return
+		}
+
+		byIndex[value] = name[offsets[i]:offsets[i+1]]
+	}
+}
+
+// returnedString returns the string literal returned by the statements of a case.
+func returnedString(stmts []ast.Stmt) (string, bool) {
+	for _, stmt := range stmts {
+		if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+			return stringLiteral(ret.Results[0])
+		}
+	}
+
+	return "", false
+}
+
+// packageValue returns the value of the constant or variable named name declared at the top of the files of pkg.
+func packageValue(pkg *PackageDefinitions, name string) ast.Expr {
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || (genDecl.Tok != token.CONST && genDecl.Tok != token.VAR) {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+
+				for i, ident := range valueSpec.Names {
+					if ident.Name == name && i < len(valueSpec.Values) {
+						return valueSpec.Values[i]
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// constName returns the name of the constant of expr, e.g. Active or status.Active, empty for other expressions.
+func constName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// stringLiteral returns the value of a string literal.
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+
+	s, err := strconv.Unquote(lit.Value)
+
+	return s, err == nil
+}
+
+// intLiteral returns the value of an integer literal.
+func intLiteral(expr ast.Expr) (int64, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+
+	i, err := strconv.ParseInt(lit.Value, 0, 64)
+
+	return i, err == nil
+}
+
+// intValue returns the value of an integer constant evaluated by the parser.
+func intValue(value any) (int64, bool) {
+	v := reflect.ValueOf(value)
+
+	switch {
+	case v.CanInt():
+		return v.Int(), true
+	case v.CanUint():
+		return int64(v.Uint()), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,138 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_StringerEnums(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Status int
+
+const (
+	Active Status = iota
+	Suspended
+)
+
+func (s Status) String() string {
+	switch s {
+	case Active:
+		return "active"
+	case Suspended:
+		return "suspended"
+	}
+	return "unknown"
+}
+
+type Level uint8
+
+const (
+	Low Level = iota + 1
+	High
+)
+
+var levelNames = map[Level]string{Low: "low", High: "high"}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+type Color int
+
+const (
+	Red Color = iota + 1
+	Green
+	Blue
+)
+
+const _Color_name = "RedGreenBlue"
+
+var _Color_index = [...]uint8{0, 3, 8, 12}
+
+func (i Color) String() string {
+	i -= 1
+	return _Color_name[_Color_index[i]:_Color_index[i+1]]
+}
+
+type Kind int
+
+const (
+	Small Kind = iota
+	Large
+)
+
+func (k Kind) String() string {
+	return fmt.Sprint(int(k))
+}
+
+type Account struct {
+	Status Status ` + "`json:\"status\"`" + `
+	Level  Level  ` + "`json:\"level\"`" + `
+	Color  Color  ` + "`json:\"color\"`" + `
+	Kind   Kind   ` + "`json:\"kind\"`" + `
+}
+
+// @Success 200 {object} Account
+// @Router  /accounts [get]
+func GetAccount(){
+}
+`
+	parse := func(t *testing.T, mode string) spec.Definitions {
+		p := New()
+		p.StringerEnums = mode
+
+		require.NoError(t, p.packages.ParseFile("api", "testdata/stringer/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+		return p.swagger.Definitions
+	}
+
+	t.Run("varnames", func(t *testing.T) {
+		t.Parallel()
+
+		definitions := parse(t, StringerEnumsVarNames)
+
+		assert.Equal(t, []string{"active", "suspended"}, definitions["api.Status"].Extensions[enumVarNamesExtension])
+		assert.Equal(t, []string{"low", "high"}, definitions["api.Level"].Extensions[enumVarNamesExtension])
+		assert.Equal(t, []string{"Red", "Green", "Blue"}, definitions["api.Color"].Extensions[enumVarNamesExtension])
+		assert.Equal(t, spec.StringOrArray{INTEGER}, definitions["api.Status"].Type)
+
+		// the string forms of Kind are not literals
+		assert.Equal(t, []string{"Small", "Large"}, definitions["api.Kind"].Extensions[enumVarNamesExtension])
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		definitions := parse(t, StringerEnumsString)
+
+		status := definitions["api.Status"]
+		assert.Equal(t, spec.StringOrArray{STRING}, status.Type)
+		assert.Equal(t, []any{"active", "suspended"}, status.Enum)
+		assert.Equal(t, map[string]any{"active": 0, "suspended": 1}, status.Extensions[EnumMappingExtension])
+		assert.Equal(t, []string{"Active", "Suspended"}, status.Extensions[enumVarNamesExtension])
+
+		assert.Equal(t, []any{"Red", "Green", "Blue"}, definitions["api.Color"].Enum)
+		assert.Equal(t, spec.StringOrArray{INTEGER}, definitions["api.Kind"].Type)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		definitions := parse(t, "")
+
+		assert.Equal(t, []string{"Active", "Suspended"}, definitions["api.Status"].Extensions[enumVarNamesExtension])
+		assert.NotContains(t, definitions["api.Status"].Extensions, EnumMappingExtension)
+	})
+}
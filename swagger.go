@@ -36,6 +36,11 @@ func Register(name string, swagger Swagger) {
 		panic("Register called twice for swag: " + name)
 	}
 
+	// decompressed once, at init, instead of by concurrent ReadDoc calls
+	if spec, ok := swagger.(*Spec); ok && spec.SwaggerTemplate == "" && spec.CompressedTemplate != "" {
+		spec.SwaggerTemplate = spec.mustDecompressTemplate()
+	}
+
 	swags[name] = swagger
 }
 
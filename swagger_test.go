@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var doc = `{
@@ -216,6 +217,22 @@ func TestCalledTwicelRegister(t *testing.T) {
 	})
 }
 
+func TestRegisterCompressed(t *testing.T) {
+	setup()
+
+	spec := &Spec{Title: "Pets", CompressedTemplate: compressTemplate(t, `{"info": {"title": "{{.Title}}"}}`)}
+	Register(Name, spec)
+	assert.Equal(t, `{"info": {"title": "{{.Title}}"}}`, spec.SwaggerTemplate)
+
+	d, err := ReadDoc()
+	require.NoError(t, err)
+	assert.Equal(t, `{"info": {"title": "Pets"}}`, d)
+
+	assert.Panics(t, func() {
+		Register("invalid", &Spec{CompressedTemplate: "not base64"})
+	})
+}
+
 func setup() {
 	swags = nil
 }
@@ -0,0 +1,188 @@
+package swagtest
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag/gen"
+)
+
+// Route is a route registered in a router.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// String formats the route as METHOD path.
+func (route Route) String() string {
+	return route.Method + " " + route.Path
+}
+
+// Routes are the routes registered in a router.
+//
+// Routes of a chi router are collected by walking it:
+//
+//	var routes swagtest.Routes
+//	chi.Walk(router, routes.Walk)
+//
+// and routes of gin or echo with RoutesOf(router.Routes()).
+type Routes []Route
+
+// Walk adds a route, its signature matches chi.WalkFunc.
+func (routes *Routes) Walk(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+	*routes = append(*routes, Route{Method: method, Path: route})
+
+	return nil
+}
+
+// RoutesOf converts a slice of structs, or pointers to structs, with Method and Path string fields,
+// like gin.RoutesInfo or the routes of echo.
+func RoutesOf(routes any) Routes {
+	value := reflect.ValueOf(routes)
+	if value.Kind() != reflect.Slice {
+		return nil
+	}
+
+	result := make(Routes, 0, value.Len())
+
+	for i := 0; i < value.Len(); i++ {
+		item := reflect.Indirect(value.Index(i))
+		if item.Kind() != reflect.Struct {
+			continue
+		}
+
+		method, path := item.FieldByName("Method"), item.FieldByName("Path")
+		if method.Kind() != reflect.String || path.Kind() != reflect.String {
+			continue
+		}
+
+		result = append(result, Route{Method: method.String(), Path: path.String()})
+	}
+
+	return result
+}
+
+// SetIgnoredPaths sets paths of routes which do not need to be documented, like the route serving the swagger UI.
+// The paths are compared the same way as the routes, so "/swagger/*any" ignores "/swagger/{any}".
+func SetIgnoredPaths(paths ...string) Option {
+	return func(o *options) {
+		o.ignoredPaths = append(o.ignoredPaths, paths...)
+	}
+}
+
+// AssertRoutesDocumented generates the document of searchDir in memory and checks that every route has a
+// documented operation and every documented operation a route. Paths are compared without the base path and
+// the names of the path parameters, so "/users/:id" of gin and "/users/{userID}" of chi both match the
+// documented "/users/{id}".
+func AssertRoutesDocumented(t testing.TB, routes Routes, searchDir string, opts ...Option) {
+	t.Helper()
+
+	o := newOptions(t, searchDir, opts)
+
+	swagger, err := gen.New().Spec(o.config)
+	if err != nil {
+		t.Fatalf("swagtest: generate %s: %v", searchDir, err)
+
+		return
+	}
+
+	ignored := make(map[string]bool, len(o.ignoredPaths))
+	for _, path := range o.ignoredPaths {
+		ignored[normalizePath(path)] = true
+	}
+
+	registered := make(map[string]Route)
+
+	for _, route := range routes {
+		path := route.Path
+		if basePath := strings.TrimSuffix(swagger.BasePath, "/"); basePath != "" && strings.HasPrefix(path, basePath+"/") {
+			path = strings.TrimPrefix(path, basePath)
+		}
+
+		if ignored[normalizePath(path)] {
+			continue
+		}
+
+		registered[routeKey(route.Method, path)] = route
+	}
+
+	documented := documentedRoutes(swagger)
+
+	var undocumented, unregistered []string
+
+	for key, route := range registered {
+		if _, ok := documented[key]; !ok {
+			undocumented = append(undocumented, route.String())
+		}
+	}
+
+	for key, route := range documented {
+		if _, ok := registered[key]; !ok {
+			unregistered = append(unregistered, route.String())
+		}
+	}
+
+	if len(undocumented) == 0 && len(unregistered) == 0 {
+		return
+	}
+
+	sort.Strings(undocumented)
+	sort.Strings(unregistered)
+
+	var message strings.Builder
+
+	message.WriteString("swagtest: the routes do not match the document of " + searchDir)
+
+	if len(undocumented) > 0 {
+		message.WriteString("\nroutes without a documented operation:\n\t" + strings.Join(undocumented, "\n\t"))
+	}
+
+	if len(unregistered) > 0 {
+		message.WriteString("\ndocumented operations without a route:\n\t" + strings.Join(unregistered, "\n\t"))
+	}
+
+	t.Errorf("%s", message.String())
+}
+
+// documentedRoutes returns the operations of the document by the key of their route.
+func documentedRoutes(swagger *spec.Swagger) map[string]Route {
+	documented := make(map[string]Route)
+	if swagger.Paths == nil {
+		return documented
+	}
+
+	for path, item := range swagger.Paths.Paths {
+		for method, op := range map[string]*spec.Operation{
+			http.MethodGet:     item.Get,
+			http.MethodPut:     item.Put,
+			http.MethodPost:    item.Post,
+			http.MethodDelete:  item.Delete,
+			http.MethodOptions: item.Options,
+			http.MethodHead:    item.Head,
+			http.MethodPatch:   item.Patch,
+		} {
+			if op != nil {
+				documented[routeKey(method, path)] = Route{Method: method, Path: path}
+			}
+		}
+	}
+
+	return documented
+}
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}|[:*][^/]*`)
+
+// normalizePath replaces the path parameters of gin (:id, *path), chi ({id}, {id:[0-9]+}, *) and
+// swagger ({id}) by {}.
+func normalizePath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{}")
+}
+
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + normalizePath(path)
+}
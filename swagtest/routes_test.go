@@ -0,0 +1,52 @@
+package swagtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type routeInfo struct {
+	Method  string
+	Path    string
+	Handler string
+}
+
+func TestAssertRoutesDocumented(t *testing.T) {
+	var routes Routes
+	require.NoError(t, routes.Walk("GET", "/test", nil))
+	require.NoError(t, routes.Walk("GET", "/swagger/*", nil))
+
+	AssertRoutesDocumented(t, routes, "../testdata/single_file_api", SetIgnoredPaths("/swagger/*any"))
+}
+
+func TestAssertRoutesDocumented_Mismatch(t *testing.T) {
+	r := &recorder{TB: t}
+	routes := RoutesOf([]*routeInfo{
+		{Method: "GET", Path: "/v2/testapi/get-string-by-int/:id"},
+		{Method: "PATCH", Path: "/v2/GetPet5c"},
+		{Method: "DELETE", Path: "/v2/undocumented/{id:[0-9]+}"},
+	})
+
+	AssertRoutesDocumented(r, routes, "../testdata/simple")
+
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "routes without a documented operation:\n\tDELETE /v2/undocumented/{id:[0-9]+}\n")
+	assert.Contains(t, r.errors[0], "documented operations without a route:\n\t")
+	assert.Contains(t, r.errors[0], "\tHEAD /GetPet5b\n")
+	assert.NotContains(t, r.errors[0], "get-string-by-int")
+	assert.NotContains(t, r.errors[0], "GetPet5c")
+}
+
+func TestRoutesOf(t *testing.T) {
+	assert.Equal(t, Routes{{Method: "GET", Path: "/users/:id"}},
+		RoutesOf([]routeInfo{{Method: "GET", Path: "/users/:id", Handler: "main.getUser"}}))
+	assert.Nil(t, RoutesOf("not a slice"))
+}
+
+func TestNormalizePath(t *testing.T) {
+	for _, path := range []string{"/users/:id/*path", "/users/{userID}/*", "/users/{id:[0-9]+}/{path}"} {
+		assert.Equal(t, "/users/{}/{}", normalizePath(path))
+	}
+}
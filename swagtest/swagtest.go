@@ -0,0 +1,165 @@
+// Package swagtest pins the API documented by swag comments in unit tests.
+package swagtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/gen"
+)
+
+// UpdateEnv is the environment variable which, set to a non empty value, makes MatchSnapshot
+// write the generated document to the golden file instead of comparing them.
+const UpdateEnv = "SWAG_UPDATE_SNAPSHOTS"
+
+type options struct {
+	goldenFile   string
+	config       *gen.Config
+	ignoredPaths []string
+}
+
+// Option customizes MatchSnapshot and AssertRoutesDocumented.
+type Option func(*options)
+
+// SetGoldenFile sets the golden file, testdata/<test name>.json by default.
+func SetGoldenFile(path string) Option {
+	return func(o *options) {
+		o.goldenFile = path
+	}
+}
+
+// SetMainAPIFile sets the file with the general API info, relative to the search directory, main.go by default.
+func SetMainAPIFile(file string) Option {
+	return func(o *options) {
+		o.config.MainAPIFile = file
+	}
+}
+
+// SetConfig customizes the generator configuration, as set by the flags of swag init.
+func SetConfig(configure func(config *gen.Config)) Option {
+	return func(o *options) {
+		configure(o.config)
+	}
+}
+
+// MatchSnapshot generates the document of searchDir in memory and compares it with the golden file.
+// Differences are reported as a unified diff of the indented JSON. Run the tests with SWAG_UPDATE_SNAPSHOTS=1
+// to create or update the golden file.
+func MatchSnapshot(t testing.TB, searchDir string, opts ...Option) {
+	t.Helper()
+
+	o := newOptions(t, searchDir, opts)
+
+	swagger, err := gen.New().Spec(o.config)
+	if err != nil {
+		t.Fatalf("swagtest: generate %s: %v", searchDir, err)
+
+		return
+	}
+
+	actual, err := json.MarshalIndent(swagger, "", "    ")
+	if err != nil {
+		t.Fatalf("swagtest: marshal %s: %v", searchDir, err)
+
+		return
+	}
+
+	actual = append(actual, '\n')
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(o.goldenFile), os.ModePerm); err != nil {
+			t.Fatalf("swagtest: %v", err)
+
+			return
+		}
+
+		if err := os.WriteFile(o.goldenFile, actual, 0644); err != nil {
+			t.Fatalf("swagtest: %v", err)
+		}
+
+		return
+	}
+
+	expected, err := os.ReadFile(o.goldenFile)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("swagtest: golden file %s does not exist, run the tests with %s=1 to create it", o.goldenFile, UpdateEnv)
+
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("swagtest: %v", err)
+
+		return
+	}
+
+	if diff := Diff(expected, actual); diff != "" {
+		t.Errorf("swagtest: the document of %s does not match %s, run the tests with %s=1 to update it:\n%s",
+			searchDir, o.goldenFile, UpdateEnv, diff)
+	}
+}
+
+func newOptions(t testing.TB, searchDir string, opts []Option) *options {
+	o := &options{
+		goldenFile: filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".json"),
+		config: &gen.Config{
+			SearchDir:          searchDir,
+			MainAPIFile:        "main.go",
+			PropNamingStrategy: swag.CamelCase,
+			ParseDepth:         100,
+			Debugger:           quiet{},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// Diff returns the unified diff between two JSON documents, after indenting both the same way,
+// or an empty string when they are equal.
+func Diff(expected, actual []byte) string {
+	expected, actual = indent(expected), indent(actual)
+	if bytes.Equal(expected, actual) {
+		return ""
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(string(actual)),
+		FromFile: "golden",
+		ToFile:   "generated",
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Sprintf("%v\n", err)
+	}
+
+	return diff
+}
+
+// indent reformats a JSON document so that formatting differences of the golden file are ignored.
+func indent(document []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(document), "", "    "); err != nil {
+		return document
+	}
+
+	buf.WriteByte('\n')
+
+	return buf.Bytes()
+}
+
+type quiet struct{}
+
+func (quiet) Printf(string, ...any) {}
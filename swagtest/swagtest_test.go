@@ -0,0 +1,73 @@
+package swagtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggo/swag/gen"
+)
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMatchSnapshot(t *testing.T) {
+	MatchSnapshot(t, "../testdata/single_file_api")
+}
+
+func TestMatchSnapshot_Update(t *testing.T) {
+	t.Setenv(UpdateEnv, "1")
+
+	golden := filepath.Join(t.TempDir(), "single_file_api.json")
+	MatchSnapshot(t, "../testdata/single_file_api", SetGoldenFile(golden))
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "TestMatchSnapshot.json"))
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestMatchSnapshot_Mismatch(t *testing.T) {
+	r := &recorder{TB: t}
+	MatchSnapshot(r, "../testdata/single_file_api", SetGoldenFile(filepath.Join("testdata", "TestMatchSnapshot.json")),
+		SetConfig(func(config *gen.Config) {
+			config.SearchDir = "../testdata/pet"
+		}))
+
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "--- golden\n+++ generated\n")
+	assert.Contains(t, r.errors[0], `-        "title": "Swagger Example API",`)
+	assert.Contains(t, r.errors[0], `+        "title": "Swagger Petstore",`)
+}
+
+func TestMatchSnapshot_MissingGolden(t *testing.T) {
+	r := &recorder{TB: t}
+	MatchSnapshot(r, "../testdata/pet", SetGoldenFile(filepath.Join(t.TempDir(), "missing.json")))
+
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "does not exist, run the tests with SWAG_UPDATE_SNAPSHOTS=1")
+}
+
+func TestDiff(t *testing.T) {
+	assert.Empty(t, Diff([]byte(`{"a": 1, "b": [1, 2]}`), []byte("{\n    \"a\": 1,\n    \"b\": [\n        1,\n        2\n    ]\n}\n")))
+
+	diff := Diff([]byte(`{"a": 1, "b": 2}`), []byte(`{"a": 1, "b": 3}`))
+	assert.True(t, strings.HasPrefix(diff, "--- golden\n+++ generated\n"))
+	assert.Contains(t, diff, "-    \"b\": 2\n+    \"b\": 3\n")
+}
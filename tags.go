@@ -0,0 +1,99 @@
+package swag
+
+import (
+	"sort"
+
+	"github.com/go-openapi/spec"
+)
+
+// SetTagDeclarations sets the tags of the document in their order, e.g. the tags of a file, so that portals show
+// them in this order. They are merged with the @tag annotations, whose properties they replace when they have
+// them, and followed by the other annotated tags, then by the tags of operations declared nowhere, sorted.
+func SetTagDeclarations(tags []spec.Tag) func(*Parser) {
+	return func(p *Parser) {
+		p.tagDeclarations = tags
+	}
+}
+
+// mergeTagDeclarations merges the tags of SetTagDeclarations into the tags of the document.
+func (parser *Parser) mergeTagDeclarations() {
+	if parser.tagDeclarations == nil {
+		return
+	}
+
+	annotated := make(map[string]spec.Tag, len(parser.swagger.Tags))
+	for _, tag := range parser.swagger.Tags {
+		annotated[tag.Name] = tag
+	}
+
+	tags := make([]spec.Tag, 0, len(parser.tagDeclarations)+len(parser.swagger.Tags))
+	declared := make(map[string]bool, len(parser.tagDeclarations))
+
+	for _, declaration := range parser.tagDeclarations {
+		if declared[declaration.Name] || !parser.matchTag(declaration.Name) {
+			continue
+		}
+
+		declared[declaration.Name] = true
+
+		tag, ok := annotated[declaration.Name]
+		if !ok {
+			tags = append(tags, declaration)
+
+			continue
+		}
+
+		if declaration.Description != "" {
+			tag.Description = declaration.Description
+		}
+
+		if declaration.ExternalDocs != nil {
+			tag.ExternalDocs = declaration.ExternalDocs
+		}
+
+		for name, value := range declaration.Extensions {
+			if tag.Extensions == nil {
+				tag.Extensions = make(map[string]any)
+			}
+
+			tag.Extensions[name] = value
+		}
+
+		tags = append(tags, tag)
+	}
+
+	for _, tag := range parser.swagger.Tags {
+		if !declared[tag.Name] {
+			declared[tag.Name] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	var undeclared []string
+
+	if parser.swagger.Paths != nil {
+		for _, item := range parser.swagger.Paths.Paths {
+			for _, method := range sortedMethods() {
+				op := *refRouteMethodOp(&item, method)
+				if op == nil {
+					continue
+				}
+
+				for _, name := range op.Tags {
+					if !declared[name] {
+						declared[name] = true
+						undeclared = append(undeclared, name)
+					}
+				}
+			}
+		}
+	}
+
+	sort.Strings(undeclared)
+
+	for _, name := range undeclared {
+		tags = append(tags, spec.Tag{TagProps: spec.TagProps{Name: name}})
+	}
+
+	parser.swagger.Tags = tags
+}
@@ -0,0 +1,50 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_TagDeclarations(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+// @Tags    zebras,users
+// @Success 200
+// @Router  /users [get]
+func Users(){
+}
+
+// @Tags    accounts
+// @Success 200
+// @Router  /accounts [get]
+func Accounts(){
+}
+`
+	p := New(SetTagDeclarations([]spec.Tag{
+		{TagProps: spec.TagProps{Name: "users", Description: "Users of the shop"}},
+	}))
+	p.swagger.Tags = []spec.Tag{{TagProps: spec.TagProps{Name: "orders"}}}
+
+	require.NoError(t, p.packages.ParseFile("api", "api/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	p.mergeTagDeclarations()
+
+	var names []string
+	for _, tag := range p.swagger.Tags {
+		names = append(names, tag.Name)
+	}
+
+	assert.Equal(t, []string{"users", "orders", "accounts", "zebras"}, names)
+	assert.Equal(t, "Users of the shop", p.swagger.Tags[0].Description)
+}
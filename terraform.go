@@ -0,0 +1,190 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// TerraformDocument returns a copy of doc restricted to the subset of OpenAPI understood by the provider generator
+// of terraform-plugin-framework: the allOf compositions are merged into single objects, the definitions they
+// reference included, and the schemas without type get the type of their properties or items. It returns the
+// warnings of the transformed constructs and of the ones left unsupported, by location.
+func TerraformDocument(doc *spec.Swagger) (*spec.Swagger, []string, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var terraform spec.Swagger
+	if err := json.Unmarshal(b, &terraform); err != nil {
+		return nil, nil, err
+	}
+
+	transformer := subsetTransformer{doc: doc}
+
+	for _, name := range sortedKeys(terraform.Definitions) {
+		definition := terraform.Definitions[name]
+		if err := transformer.schema(&definition, "definitions/"+name, map[string]bool{name: true}); err != nil {
+			return nil, nil, err
+		}
+
+		terraform.Definitions[name] = definition
+	}
+
+	for _, documentSchema := range documentSchemas(&terraform) {
+		if err := transformer.schema(documentSchema.schema, documentSchema.name, map[string]bool{}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &terraform, transformer.warnings, nil
+}
+
+// subsetTransformer transforms schemas into the subset of OpenAPI of the tools which do not understand compositions,
+// e.g. for TerraformDocument and CRDSchema, doc holding the original definitions.
+type subsetTransformer struct {
+	doc      *spec.Swagger
+	warnings []string
+}
+
+func (transformer *subsetTransformer) warn(location, format string, args ...any) {
+	transformer.warnings = append(transformer.warnings, location+": "+fmt.Sprintf(format, args...))
+}
+
+// schema transforms schema at location and its children, stack holding the definitions being merged.
+func (transformer *subsetTransformer) schema(schema *spec.Schema, location string, stack map[string]bool) error {
+	if len(schema.AllOf) > 0 {
+		if err := transformer.mergeAllOf(schema, location, stack); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		if err := transformer.schema(&property, location+"/properties/"+escapePointer(name), stack); err != nil {
+			return err
+		}
+
+		schema.Properties[name] = property
+	}
+
+	if schema.Items != nil {
+		if len(schema.Items.Schemas) > 0 {
+			transformer.warn(location, "tuple items are not supported")
+		}
+
+		if schema.Items.Schema != nil {
+			if err := transformer.schema(schema.Items.Schema, location+"/items", stack); err != nil {
+				return err
+			}
+		}
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		err := transformer.schema(schema.AdditionalProperties.Schema, location+"/additionalProperties", stack)
+		if err != nil {
+			return err
+		}
+	}
+
+	if schema.Discriminator != "" {
+		transformer.warn(location, "the discriminator %s is not supported", schema.Discriminator)
+	}
+
+	if len(schema.Type) > 0 || schema.Ref.String() != "" {
+		return nil
+	}
+
+	switch {
+	case len(schema.Properties) > 0 || schema.AdditionalProperties != nil:
+		schema.Typed(OBJECT, "")
+	case schema.Items != nil:
+		schema.Typed(ARRAY, "")
+	default:
+		transformer.warn(location, "the schema has no type")
+	}
+
+	return nil
+}
+
+// mergeAllOf merges the parts of the allOf of schema into schema, the properties of the later parts overriding the
+// ones of the earlier parts.
+func (transformer *subsetTransformer) mergeAllOf(schema *spec.Schema, location string, stack map[string]bool) error {
+	parts := schema.AllOf
+	schema.AllOf = nil
+
+	var names []string
+
+	for i, part := range parts {
+		partLocation := fmt.Sprintf("%s/allOf/%d", location, i)
+
+		if name, ok := definitionName(&part); ok {
+			definition, found := transformer.doc.Definitions[name]
+			if !found || stack[name] {
+				transformer.warn(partLocation, "the reference to %s can not be merged", name)
+
+				continue
+			}
+
+			// each merge gets its own copy of the definition
+			b, err := json.Marshal(definition)
+			if err != nil {
+				return err
+			}
+
+			part = spec.Schema{}
+			if err := json.Unmarshal(b, &part); err != nil {
+				return err
+			}
+
+			stack[name] = true
+			err = transformer.schema(&part, partLocation, stack)
+			delete(stack, name)
+
+			if err != nil {
+				return err
+			}
+
+			names = append(names, name)
+		} else if err := transformer.schema(&part, partLocation, stack); err != nil {
+			return err
+		}
+
+		for _, name := range sortedKeys(part.Properties) {
+			if _, ok := schema.Properties[name]; ok {
+				transformer.warn(partLocation, "the property %s overrides the one of an earlier part", name)
+			}
+
+			schema.SetProperty(name, part.Properties[name])
+		}
+
+		for _, name := range part.Required {
+			if !containsString(schema.Required, name) {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+
+		if len(schema.Type) == 0 {
+			schema.Type = part.Type
+		}
+
+		if schema.Description == "" {
+			schema.Description = part.Description
+		}
+
+		if schema.AdditionalProperties == nil {
+			schema.AdditionalProperties = part.AdditionalProperties
+		}
+	}
+
+	if len(names) > 0 {
+		transformer.warn(location, "allOf merged with %s", strings.Join(names, ", "))
+	} else {
+		transformer.warn(location, "allOf merged")
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerraformDocument(t *testing.T) {
+	t.Parallel()
+
+	var doc spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(`{
+    "swagger": "2.0",
+    "paths": {
+        "/pets": {
+            "post": {
+                "parameters": [{"name": "pet", "in": "body", "schema": {"$ref": "#/definitions/Pet"}}],
+                "responses": {"200": {"description": "ok", "schema": {"properties": {"id": {"type": "integer"}}}}}
+            }
+        }
+    },
+    "definitions": {
+        "Base": {
+            "type": "object",
+            "required": ["id"],
+            "properties": {"id": {"type": "integer"}, "name": {"type": "string"}}
+        },
+        "Pet": {
+            "allOf": [
+                {"$ref": "#/definitions/Base"},
+                {"properties": {"name": {"type": "string", "maxLength": 10}, "tags": {"items": {"type": "string"}}}, "required": ["name"]}
+            ]
+        },
+        "Any": {"description": "anything"}
+    }
+}`), &doc))
+
+	terraform, warnings, err := TerraformDocument(&doc)
+	require.NoError(t, err)
+
+	pet := terraform.Definitions["Pet"]
+	assert.Empty(t, pet.AllOf)
+	assert.Equal(t, spec.StringOrArray{"object"}, pet.Type)
+	assert.Equal(t, []string{"id", "name"}, pet.Required)
+	assert.Equal(t, []string{"id", "name", "tags"}, sortedKeys(pet.Properties))
+	assert.Equal(t, int64(10), *pet.Properties["name"].MaxLength)
+	assert.Equal(t, spec.StringOrArray{"array"}, pet.Properties["tags"].Type)
+
+	response := terraform.Paths.Paths["/pets"].Post.Responses.StatusCodeResponses[200]
+	assert.Equal(t, spec.StringOrArray{"object"}, response.Schema.Type)
+
+	assert.Equal(t, []string{
+		"definitions/Any: the schema has no type",
+		"definitions/Pet/allOf/1: the property name overrides the one of an earlier part",
+		"definitions/Pet: allOf merged with Base",
+	}, warnings)
+
+	// the document is left unchanged
+	assert.Len(t, doc.Definitions["Pet"].AllOf, 2)
+}
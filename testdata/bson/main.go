@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/swaggo/swag/testdata/bson/models"
+)
+
+// Request creates a document.
+type Request struct {
+	Title string `bson:"title" json:"name"`
+	Draft bool   `bson:"-" json:"draft"`
+}
+
+// @title BSON API
+// @version 1.0
+
+// CreateDocument
+// @Param request body Request true "document"
+// @Success 200 {object} models.Document
+// @Router /documents [post]
+func CreateDocument() {
+	_ = models.Document{}
+}
+
+func main() {}
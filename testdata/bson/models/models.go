@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Document is stored in Mongo and returned as is.
+type Document struct {
+	ID        string    `bson:"_id" json:"id"`
+	Title     string    `bson:"title" json:"name"`
+	Tags      []string  `bson:"tags,omitempty" json:"tags"`
+	Revision  int       `bson:"-" json:"revision"`
+	CreatedAt time.Time `bson:"created_at" json:"createdAt"`
+	Owner     string    `json:"owner"`
+}
@@ -0,0 +1,41 @@
+package main
+
+// @title Emit
+// @version 1.0
+
+// @tag.name pets
+// @tag.description Everything about pets
+// @tag.name admin
+// @tag.description Administration
+func main() {}
+
+type Pet struct {
+	Name  string `json:"name"`
+	Owner Owner  `json:"owner"`
+}
+
+type Owner struct {
+	Name string `json:"name"`
+}
+
+type Audit struct {
+	Entries []string `json:"entries"`
+}
+
+// @Tags pets
+// @Success 200 {array} Pet
+// @Router /pets [get]
+func listPets() {}
+
+// @Tags pets
+// @x-visibility "partner"
+// @Success 201 {object} Pet
+// @Router /pets [post]
+func createPet() {}
+
+// @Tags admin
+// @x-visibility "internal"
+// @x-audit {"level": "high"}
+// @Success 200 {object} Audit
+// @Router /admin/audit [get]
+func getAudit() {}
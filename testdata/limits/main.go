@@ -0,0 +1,29 @@
+package main
+
+// @title Limits
+// @version 1.0
+func main() {}
+
+type Leaf struct {
+	Name string `json:"name"`
+}
+
+type Middle struct {
+	Leaf Leaf `json:"leaf"`
+}
+
+type Root struct {
+	Middle Middle `json:"middle"`
+}
+
+type Wrapper[T any] struct {
+	Data T `json:"data"`
+}
+
+// @Success 200 {object} Root
+// @Router /root [get]
+func getRoot() {}
+
+// @Success 200 {object} Wrapper[Wrapper[Wrapper[Leaf]]]
+// @Router /wrapped [get]
+func getWrapped() {}
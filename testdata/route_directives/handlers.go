@@ -0,0 +1,15 @@
+package main
+
+// User a user.
+type User struct {
+	Name string `json:"name"`
+}
+
+// ListUsers lists the users.
+//
+//	@Summary	List users
+//	@ID			listUsers
+//	@Produce	json
+//	@Success	200	{array}	User
+func ListUsers() {
+}
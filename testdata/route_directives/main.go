@@ -0,0 +1,6 @@
+package main
+
+// @title Route directives
+// @version 1.0
+func main() {
+}
@@ -0,0 +1,9 @@
+package main
+
+// @title  Users API
+// @tag.name users
+// @tag.description.markdown users.md users-auth.md
+// @tag.overview.markdown users-guide.md
+// @tag.name orders
+// @tag.overview.markdown
+func main() {}
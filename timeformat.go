@@ -0,0 +1,73 @@
+package swag
+
+import (
+	"go/ast"
+
+	"github.com/go-openapi/spec"
+)
+
+// Formats of time.Time, for Parser.TimeFormat and the swaggerformat tag. Any other format documents a string of
+// that format, e.g. rfc1123.
+const (
+	// TimeFormatDateTime documents an RFC 3339 date-time string, as encoding/json marshals time.Time.
+	TimeFormatDateTime = "date-time"
+
+	// TimeFormatDate documents a full-date string, e.g. 2006-01-02.
+	TimeFormatDate = "date"
+
+	// TimeFormatUnix documents an integer unix timestamp in seconds.
+	TimeFormatUnix = "unix"
+
+	// TimeFormatUnixMilli documents an integer unix timestamp in milliseconds.
+	TimeFormatUnixMilli = "unixmilli"
+)
+
+// timeType the name of the type documented by Parser.TimeFormat and the swaggerformat tag.
+const timeType = "time.Time"
+
+// timeSchema returns the schema of a time.Time documented in format.
+func timeSchema(format string) *spec.Schema {
+	switch format {
+	case TimeFormatUnix, TimeFormatUnixMilli:
+		schema := spec.Int64Property()
+
+		unit := "s"
+		if format == TimeFormatUnixMilli {
+			unit = "ms"
+		}
+
+		schema.AddExtension(unitExtension, unit)
+
+		return schema
+	default:
+		schema := spec.StringProperty()
+		schema.Format = format
+
+		return schema
+	}
+}
+
+// timeFieldSchema returns the schema of a field of type time.Time, a pointer to or a slice of it, documented in
+// format. It returns false for the fields of other types.
+func timeFieldSchema(expr ast.Expr, format string) (*spec.Schema, bool) {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return timeFieldSchema(expr.X, format)
+	case *ast.ArrayType:
+		items, ok := timeFieldSchema(expr.Elt, format)
+		if !ok {
+			return nil, false
+		}
+
+		return spec.ArrayProperty(items), true
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok || pkg.Name+"."+expr.Sel.Name != timeType {
+			return nil, false
+		}
+
+		return timeSchema(format), true
+	default:
+		return nil, false
+	}
+}
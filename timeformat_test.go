@@ -0,0 +1,90 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_TimeFormat(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+import "time"
+
+type Event struct {
+	At        time.Time    ` + "`json:\"at\"`" + `
+	UpdatedAt *time.Time   ` + "`json:\"updatedAt\"`" + `
+	Day       time.Time    ` + "`json:\"day\" swaggerformat:\"date\"`" + `
+	Seen      []time.Time  ` + "`json:\"seen\" swaggerformat:\"unixmilli\"`" + `
+}
+
+// @Success 200 {object} Event
+// @Router  /events [get]
+func GetEvent(){
+}
+`
+	parse := func(t *testing.T, timeFormat string) map[string]spec.Schema {
+		p := New()
+		p.TimeFormat = timeFormat
+
+		require.NoError(t, p.packages.ParseFile("api", "testdata/timeformat/api.go", src, ParseAll))
+
+		_, err := p.packages.ParseTypes()
+		require.NoError(t, err)
+
+		require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+		return p.swagger.Definitions["api.Event"].Properties
+	}
+
+	properties := parse(t, "")
+
+	assert.Equal(t, spec.StringOrArray{STRING}, properties["at"].Type)
+	assert.Empty(t, properties["at"].Format)
+	assert.Equal(t, "date", properties["day"].Format)
+
+	seen := properties["seen"]
+	require.NotNil(t, seen.Items.Schema)
+	assert.Equal(t, spec.StringOrArray{INTEGER}, seen.Items.Schema.Type)
+	assert.Equal(t, "ms", seen.Items.Schema.Extensions[unitExtension])
+
+	properties = parse(t, TimeFormatUnix)
+
+	for _, name := range []string{"at", "updatedAt"} {
+		assert.Equal(t, spec.StringOrArray{INTEGER}, properties[name].Type, name)
+		assert.Equal(t, "int64", properties[name].Format, name)
+		assert.Equal(t, "s", properties[name].Extensions[unitExtension], name)
+	}
+
+	assert.Equal(t, "date", properties["day"].Format)
+
+	properties = parse(t, TimeFormatDateTime)
+
+	assert.Equal(t, spec.StringOrArray{STRING}, properties["at"].Type)
+	assert.Equal(t, "date-time", properties["at"].Format)
+}
+
+func TestParser_TimeFormatTagOnOtherType(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type Event struct {
+	At int64 ` + "`json:\"at\" swaggerformat:\"unix\"`" + `
+}
+`
+	p := New()
+	require.NoError(t, p.packages.ParseFile("api", "testdata/timeformat/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	_, err = p.getTypeSchema("api.Event", nil, false)
+	assert.ErrorContains(t, err, "the swaggerformat tag only applies to time.Time fields")
+}
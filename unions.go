@@ -0,0 +1,82 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Extensions keeping the alternatives of a union, which Swagger 2.0 can not express, the oneOf and anyOf of the
+// schema in OpenAPI 3.0.
+const (
+	OneOfExtension = "x-oneOf"
+	AnyOfExtension = "x-anyOf"
+)
+
+// Struct tags of the fields whose value is one of several types, e.g. oneOf:"web.Card,web.Transfer".
+const (
+	oneOfTag = "oneOf"
+	anyOfTag = "anyOf"
+)
+
+// unionExtensions the extensions of the unions by keyword, oneOf(...) and anyOf(...).
+var unionExtensions = map[string]string{
+	oneOfTag: OneOfExtension,
+	anyOfTag: AnyOfExtension,
+}
+
+// unionKeyword returns the keyword and the comma separated types of a union like oneOf(web.User,web.Error).
+func unionKeyword(refType string) (string, string, bool) {
+	for keyword := range unionExtensions {
+		if types, ok := strings.CutPrefix(refType, keyword+"("); ok && strings.HasSuffix(types, ")") {
+			return keyword, strings.TrimSuffix(types, ")"), true
+		}
+	}
+
+	return "", "", false
+}
+
+// parseUnionSchema returns the schema of the union keyword of types, whose alternatives are kept in the extension of
+// the keyword, the schema itself accepting any value in Swagger 2.0.
+func parseUnionSchema(parser *Parser, keyword, types string, astFile *ast.File) (*spec.Schema, error) {
+	fields := parseFields(types)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("%s(%s) needs at least two types", keyword, types)
+	}
+
+	alternatives := make([]spec.Schema, 0, len(fields))
+
+	for _, field := range fields {
+		schema, err := parseObjectSchema(parser, strings.TrimSpace(field), astFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if schema == nil {
+			return nil, fmt.Errorf("%s(%s) can not have a nil type", keyword, types)
+		}
+
+		alternatives = append(alternatives, *schema)
+	}
+
+	schema := &spec.Schema{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{}}}
+	setExtension(schema.Extensions, unionExtensions[keyword], alternatives)
+
+	return schema, nil
+}
+
+// unionTagSchema returns the schema of the oneOf or anyOf tag of a field, nil without them.
+func unionTagSchema(parser *Parser, file *ast.File, oneOf, anyOf string) (*spec.Schema, error) {
+	switch {
+	case oneOf != "" && anyOf != "":
+		return nil, fmt.Errorf("a field can not have both the %s and the %s tags", oneOfTag, anyOfTag)
+	case oneOf != "":
+		return parseUnionSchema(parser, oneOfTag, oneOf, file)
+	case anyOf != "":
+		return parseUnionSchema(parser, anyOfTag, anyOf, file)
+	default:
+		return nil, nil
+	}
+}
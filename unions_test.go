@@ -0,0 +1,85 @@
+package swag
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Unions(t *testing.T) {
+	t.Parallel()
+
+	src := `
+package api
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type ErrorEnvelope struct {
+	Code int ` + "`json:\"code\"`" + `
+}
+
+type Card struct {
+	Number string ` + "`json:\"number\"`" + `
+}
+
+type Transfer struct {
+	IBAN string ` + "`json:\"iban\"`" + `
+}
+
+type Payment struct {
+	Method any ` + "`json:\"method\" oneOf:\"Card,Transfer\"`" + `
+	Note   any ` + "`json:\"note\" anyOf:\"string,[]string\"`" + `
+}
+
+// @Success 200 {object} oneOf(User,ErrorEnvelope) "the user or why it is missing"
+// @Success 201 {object} Payment
+// @Success 202 {object} User{data=anyOf(Card,Transfer)}
+// @Router  /users [get]
+func GetUser(){
+}
+`
+	p := New()
+
+	require.NoError(t, p.packages.ParseFile("api", "testdata/unions/api.go", src, ParseAll))
+
+	_, err := p.packages.ParseTypes()
+	require.NoError(t, err)
+
+	require.NoError(t, p.packages.RangeFiles(p.ParseRouterAPIInfo))
+
+	responses := p.swagger.Paths.Paths["/users"].Get.Responses.StatusCodeResponses
+
+	assert.Equal(t, []spec.Schema{
+		*spec.RefSchema("#/definitions/api.User"),
+		*spec.RefSchema("#/definitions/api.ErrorEnvelope"),
+	}, responses[200].Schema.Extensions[OneOfExtension])
+	assert.Empty(t, responses[200].Schema.Type)
+
+	properties := p.swagger.Definitions["api.Payment"].Properties
+	assert.Equal(t, []spec.Schema{
+		*spec.RefSchema("#/definitions/api.Card"),
+		*spec.RefSchema("#/definitions/api.Transfer"),
+	}, properties["method"].Extensions[OneOfExtension])
+	assert.Equal(t, []spec.Schema{*spec.StringProperty(), *spec.ArrayProperty(spec.StringProperty())},
+		properties["note"].Extensions[AnyOfExtension])
+
+	data := responses[202].Schema.AllOf[1].Properties["data"]
+	assert.Len(t, data.Extensions[AnyOfExtension], 2)
+}
+
+func TestParseUnionSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseObjectSchema(nil, "oneOf(web.User)", nil)
+	assert.EqualError(t, err, "oneOf(web.User) needs at least two types")
+
+	_, err = parseObjectSchema(nil, "allOf(web.User,web.Error)", nil)
+	assert.EqualError(t, err, "invalid type: allOf(web.User,web.Error)")
+
+	_, err = unionTagSchema(New(), nil, "web.Card,web.Transfer", "string,int")
+	assert.EqualError(t, err, "a field can not have both the oneOf and the anyOf tags")
+}
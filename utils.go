@@ -1,10 +1,14 @@
 package swag
 
 import (
+	"regexp"
 	"strings"
 	"unicode"
 )
 
+// descriptionsExtension holds per-language descriptions, e.g. @description[zh].
+const descriptionsExtension = "x-descriptions"
+
 // FieldsFunc split a string s by a func splitter into max n parts
 func FieldsFunc(s string, f func(rune2 rune) bool, n int) []string {
 	// A span is used to record a slice of s of the form s[start:end].
@@ -65,3 +69,104 @@ func AppendDescription(current, addition string) string {
 	}
 	return current + "\n" + addition
 }
+
+// JoinContinuedLines joins comment lines ending with a backslash with the following line, so that a long
+// annotation can span several lines. A following line starting a new annotation is not joined, which keeps
+// the backslash of repeated @description lines for AppendDescription.
+func JoinContinuedLines(lines []string) []string {
+	result := make([]string, 0, len(lines))
+
+	var delimiter string
+
+	for _, line := range lines {
+		// lines of a heredoc block are kept as they are
+		if delimiter != "" {
+			if strings.TrimSpace(trimCommentMarker(line)) == delimiter {
+				delimiter = ""
+			}
+
+			result = append(result, line)
+
+			continue
+		}
+
+		if fields := FieldsByAnySpace(strings.TrimSpace(trimCommentMarker(line)), 2); len(fields) == 2 {
+			delimiter, _ = heredocDelimiter(fields[1])
+		}
+
+		if len(result) > 0 {
+			previous := strings.TrimRight(result[len(result)-1], " \t")
+			next := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "/"))
+
+			if strings.HasSuffix(previous, "\\") && next != "" && !strings.HasPrefix(next, "@") {
+				result[len(result)-1] = strings.TrimRight(previous[:len(previous)-1], " \t") + " " + next
+
+				continue
+			}
+		}
+
+		result = append(result, line)
+	}
+
+	return result
+}
+
+var heredocPattern = regexp.MustCompile(`^<<(\w+)$`)
+
+// heredocDelimiter returns the delimiter of a value starting a heredoc block like <<EOF.
+func heredocDelimiter(value string) (string, bool) {
+	matches := heredocPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return "", false
+	}
+
+	return matches[1], true
+}
+
+// trimCommentMarker removes the // marker and the space following it, keeping further indentation.
+func trimCommentMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(trimmed, "//") {
+		return line
+	}
+
+	trimmed = strings.TrimPrefix(trimmed, "//")
+
+	return strings.TrimPrefix(trimmed, " ")
+}
+
+var localizedAttributePattern = regexp.MustCompile(`^(@[\w.\-]+)\[([\w\-]+)\]$`)
+
+// SplitLocalizedAttribute splits an attribute like @Description[zh-Hans] into its
+// base attribute in lower case and its language as written, ok is false if the attribute is not localized.
+func SplitLocalizedAttribute(attribute string) (base, lang string, ok bool) {
+	matches := localizedAttributePattern.FindStringSubmatch(attribute)
+	if len(matches) != 3 {
+		return strings.ToLower(attribute), "", false
+	}
+
+	return strings.ToLower(matches[1]), matches[2], true
+}
+
+// AppendLocalizedDescription appends a description line to the given language
+// entry of the x-descriptions extension.
+func AppendLocalizedDescription(extensions map[string]any, lang, addition string) map[string]any {
+	if extensions == nil {
+		extensions = make(map[string]any)
+	}
+
+	descriptions, _ := extensions[descriptionsExtension].(map[string]string)
+	if descriptions == nil {
+		descriptions = make(map[string]string)
+	}
+
+	if current, ok := descriptions[lang]; ok && current != "" {
+		descriptions[lang] = AppendDescription(current, addition)
+	} else {
+		descriptions[lang] = addition
+	}
+
+	extensions[descriptionsExtension] = descriptions
+
+	return extensions
+}
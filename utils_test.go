@@ -76,3 +76,49 @@ func TestAppendDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinContinuedLines(t *testing.T) {
+	t.Parallel()
+
+	lines := JoinContinuedLines([]string{
+		`// @Param   status  query  string  false  "order status" \`,
+		`//          Enums(placed, approved, \`,
+		`//          delivered)`,
+		`// @Description keep the backslash \`,
+		`// @Description of repeated descriptions`,
+		`// @Summary dangling \`,
+		``,
+	})
+
+	assert.Equal(t, []string{
+		`// @Param   status  query  string  false  "order status" Enums(placed, approved, delivered)`,
+		`// @Description keep the backslash \`,
+		`// @Description of repeated descriptions`,
+		`// @Summary dangling \`,
+		``,
+	}, lines)
+}
+
+func TestSplitLocalizedAttribute(t *testing.T) {
+	tests := []struct {
+		attribute string
+		base      string
+		lang      string
+		ok        bool
+	}{
+		{"@description[en]", "@description", "en", true},
+		{"@description[zh-CN]", "@description", "zh-CN", true},
+		{"@Description[zh-Hans]", "@description", "zh-Hans", true},
+		{"@Description", "@description", "", false},
+		{"@description", "@description", "", false},
+		{"@description[]", "@description[]", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attribute, func(t *testing.T) {
+			base, lang, ok := SplitLocalizedAttribute(tt.attribute)
+			assert.Equal(t, tt.base, base)
+			assert.Equal(t, tt.lang, lang)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ReadYAMLOrJSON reads the JSON or YAML file name into v like UnmarshalYAMLOrJSON.
+func ReadYAMLOrJSON(name string, v any) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	return UnmarshalYAMLOrJSON(b, name, v)
+}
+
+// UnmarshalYAMLOrJSON decodes b, the JSON or YAML content of name, into v. The numbers of untyped values decode as
+// json.Number to keep their precision.
+func UnmarshalYAMLOrJSON(b []byte, name string, v any) error {
+	// JSON is YAML too
+	b, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("cannot read %s: %w", name, err)
+	}
+
+	return nil
+}